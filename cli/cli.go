@@ -16,6 +16,7 @@ import (
 	filesharepb "github.com/NordSecurity/nordvpn-linux/fileshare/pb"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 	meshpb "github.com/NordSecurity/nordvpn-linux/meshnet/pb"
+	"github.com/NordSecurity/nordvpn-linux/slices"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
@@ -79,6 +80,9 @@ Options:
 
 var ErrConfig = errors.New(client.ConfigMessage)
 
+// ErrPolicyEnforced is returned when a setting is locked by the administrator policy
+var ErrPolicyEnforced = errors.New(MsgPolicyEnforced)
+
 func NewApp(version, environment, hash, daemonURL, salt string,
 	lastAppError, pingErr error,
 	conn *grpc.ClientConn,
@@ -118,6 +122,19 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 				BashComplete: cmd.SetAutoConnectAutoComplete,
 				ArgsUsage:    SetAutoConnectArgsUsageText,
 			},
+			{
+				Name:      "pinnedserver",
+				Aliases:   []string{"pin"},
+				Usage:     SetPinnedServerUsageText,
+				Action:    cmd.SetPinnedServer,
+				ArgsUsage: SetPinnedServerArgsUsageText,
+			},
+			{
+				Name:      "location",
+				Usage:     SetLocationUsageText,
+				Action:    cmd.SetLocation,
+				ArgsUsage: SetLocationArgsUsageText,
+			},
 			{
 				Name:         "threatprotectionlite",
 				Aliases:      []string{"tplite", "tpl", "cybersec"},
@@ -166,6 +183,37 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 				),
 				BashComplete: cmd.SetBoolAutocomplete,
 			},
+			{
+				Name:   "isolation",
+				Usage:  SetIsolationUsageText,
+				Action: cmd.SetIsolation,
+				ArgsUsage: fmt.Sprintf(
+					MsgSetBoolArgsUsage,
+					SetIsolationUsageText,
+					"isolation",
+					"isolation",
+				),
+				BashComplete: cmd.SetBoolAutocomplete,
+			},
+			{
+				Name:   "lan-discovery",
+				Usage:  SetLanDiscoveryUsageText,
+				Action: cmd.SetLanDiscovery,
+				ArgsUsage: fmt.Sprintf(
+					MsgSetBoolArgsUsage,
+					SetLanDiscoveryUsageText,
+					"lan-discovery",
+					"lan-discovery",
+				),
+				BashComplete: cmd.SetBoolAutocomplete,
+			},
+			{
+				Name:         "reconnect-policy",
+				Usage:        SetReconnectPolicyUsageText,
+				Action:       cmd.SetReconnectPolicy,
+				BashComplete: cmd.SetReconnectPolicyAutoComplete,
+				ArgsUsage:    SetReconnectPolicyArgsUsageText,
+			},
 			{
 				Name:   "routing",
 				Usage:  SetRoutingUsageText,
@@ -200,7 +248,13 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 					SetKillSwitchUsageText,
 					"killswitch",
 					"killswitch",
-				),
+				) + "\n" + SetKillSwitchPersistentExample,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  flagKillSwitchPersistent,
+						Usage: SetKillSwitchPersistentUsage,
+					},
+				},
 			},
 			{
 				Name:         "notify",
@@ -294,6 +348,10 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 					Name:  "group, g",
 					Usage: ConnectFlagGroupUsageText,
 				},
+				&cli.StringFlag{
+					Name:  flagProfile,
+					Usage: ConnectFlagProfileUsageText,
+				},
 			},
 		},
 		{
@@ -380,10 +438,114 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 		},
 		&setCommand,
 		{
-			Name:               "settings",
-			Usage:              SettingsUsageText,
-			Action:             cmd.Settings,
-			CustomHelpTemplate: CommandWithoutArgsHelpTemplate,
+			Name:  "servers",
+			Usage: ServersUsageText,
+			Subcommands: []*cli.Command{
+				{
+					Name:               "history",
+					Usage:              ServersHistoryUsageText,
+					Action:             cmd.ServersHistory,
+					CustomHelpTemplate: CommandWithoutArgsHelpTemplate,
+				},
+			},
+		},
+		{
+			Name:  "profile",
+			Usage: ProfileUsageText,
+			Subcommands: []*cli.Command{
+				{
+					Name:      "create",
+					Usage:     ProfileCreateUsageText,
+					Action:    cmd.ProfileCreate,
+					ArgsUsage: ProfileCreateArgsUsageText,
+				},
+				{
+					Name:         "use",
+					Usage:        ProfileUseUsageText,
+					Action:       cmd.ProfileUse,
+					BashComplete: cmd.ProfileAutoComplete,
+					ArgsUsage:    ProfileUseArgsUsageText,
+				},
+				{
+					Name:               "list",
+					Usage:              ProfileListUsageText,
+					Action:             cmd.ProfileList,
+					CustomHelpTemplate: CommandWithoutArgsHelpTemplate,
+				},
+				{
+					Name:         "delete",
+					Usage:        ProfileDeleteUsageText,
+					Action:       cmd.ProfileDelete,
+					BashComplete: cmd.ProfileAutoComplete,
+					ArgsUsage:    ProfileDeleteArgsUsageText,
+				},
+			},
+		},
+		{
+			Name:   "settings",
+			Usage:  SettingsUsageText,
+			Action: cmd.Settings,
+			Subcommands: []*cli.Command{
+				{
+					Name:      "export",
+					Usage:     SettingsExportUsageText,
+					Action:    cmd.SettingsExport,
+					ArgsUsage: SettingsExportArgsUsageText,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  flagSettingsFormat,
+							Usage: "Output format: yaml or json",
+							Value: config.SettingsFormatYAML,
+						},
+					},
+				},
+				{
+					Name:      "import",
+					Usage:     SettingsImportUsageText,
+					Action:    cmd.SettingsImport,
+					ArgsUsage: SettingsImportArgsUsageText,
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:  flagSettingsDryRun,
+							Usage: "Shows the changes without applying them",
+						},
+					},
+				},
+				{
+					Name:      "history",
+					Usage:     SettingsHistoryUsageText,
+					Action:    cmd.SettingsHistory,
+					ArgsUsage: SettingsHistoryArgsUsageText,
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  flagSettingsLimit,
+							Usage: "Number of latest changes to show, all when 0",
+							Value: 20,
+						},
+					},
+				},
+				{
+					Name:   "user",
+					Usage:  SettingsUserUsageText,
+					Action: cmd.SettingsUser,
+					Subcommands: []*cli.Command{
+						{
+							Name:         "set",
+							Usage:        SettingsUserSetUsageText,
+							Action:       cmd.SettingsUserSet,
+							BashComplete: cmd.SettingsUserAutoComplete,
+							ArgsUsage:    SettingsUserSetArgsUsageText,
+						},
+						{
+							Name:         "unset",
+							Usage:        SettingsUserUnsetUsageText,
+							Action:       cmd.SettingsUserUnset,
+							BashComplete: cmd.SettingsUserAutoComplete,
+							ArgsUsage:    SettingsUserUnsetArgsUsageText,
+						},
+					},
+				},
+			},
 		},
 		{
 			Name:               "status",
@@ -414,6 +576,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 							Action:       cmd.WhitelistAddPort,
 							BashComplete: cmd.WhitelistAddPortAutoComplete,
 							ArgsUsage:    WhitelistAddPortArgsUsageText,
+							Flags:        whitelistEntryFlags(),
 						},
 						{
 							Name:         "ports",
@@ -421,6 +584,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 							Action:       cmd.WhitelistAddPorts,
 							BashComplete: cmd.WhitelistAddPortsAutoComplete,
 							ArgsUsage:    WhitelistAddPortsArgsUsageText,
+							Flags:        whitelistEntryFlags(),
 						},
 						{
 							Name:         "subnet",
@@ -428,6 +592,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 							Action:       cmd.WhitelistAddSubnet,
 							BashComplete: cmd.WhitelistAddSubnetAutoComplete,
 							ArgsUsage:    WhitelistAddSubnetArgsUsageText,
+							Flags:        whitelistEntryFlags(),
 						},
 					},
 				},
@@ -447,6 +612,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 							Action:       cmd.WhitelistRemovePort,
 							BashComplete: cmd.WhitelistRemovePortAutoComplete,
 							ArgsUsage:    WhitelistRemovePortArgsUsageText,
+							Flags:        whitelistRemoveFlags(),
 						},
 						{
 							Name:         "ports",
@@ -454,6 +620,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 							Action:       cmd.WhitelistRemovePorts,
 							BashComplete: cmd.WhitelistRemovePortsAutoComplete,
 							ArgsUsage:    WhitelistRemovePortsArgsUsageText,
+							Flags:        whitelistRemoveFlags(),
 						},
 						{
 							Name:         "subnet",
@@ -469,6 +636,7 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 	}
 
 	app.Commands = append(app.Commands, meshnetCommand(cmd))
+	app.Commands = append(app.Commands, trustedNetworkCommand(cmd))
 
 	if pingErr == nil {
 		cmd.client = pb.NewDaemonClient(conn)
@@ -482,6 +650,10 @@ func NewApp(version, environment, hash, daemonURL, salt string,
 	}
 
 	app.Commands = addLoaderToActions(cmd, pingErr, app.Commands, daemonURL, lastAppError)
+	// releasing the kill switch has to work when the daemon is not running
+	app.Commands = append(app.Commands, killSwitchCommand(cmd))
+	// exec enters the namespace directly without the daemon
+	app.Commands = append(app.Commands, execCommand(cmd))
 	// Unknown command handler
 	app.CommandNotFound = func(c *cli.Context, command string) {
 		color.Red(fmt.Sprintf(NoSuchCommand, command))
@@ -960,6 +1132,8 @@ func meshnetErrorToError(code meshpb.MeshnetErrorCode) error {
 		return errors.New(MsgMeshnetNordlynxMustBeEnabled)
 	case meshpb.MeshnetErrorCode_TUNNEL_CLOSED:
 		return errors.New(DisconnectNotConnected)
+	case meshpb.MeshnetErrorCode_ISOLATION_ENABLED:
+		return errors.New(MsgMeshnetIsolation)
 	default:
 		return errors.New(AccountInternalError)
 	}
@@ -980,3 +1154,41 @@ func argsParseError(ctx *cli.Context) error {
 		ctx.Command.Name,
 	)
 }
+
+// argsWithTrailingFlags returns positional arguments of the command. The
+// cli library stops parsing flags at the first argument, so flags given
+// after the arguments, e.g. 'port 8080 --for 2h', are set here.
+func argsWithTrailingFlags(ctx *cli.Context) ([]string, error) {
+	var args []string
+	rest := ctx.Args().Slice()
+	for i := 0; i < len(rest); i++ {
+		if !strings.HasPrefix(rest[i], "--") {
+			args = append(args, rest[i])
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(rest[i], "--"), "=")
+		if !hasValue && isBoolFlag(ctx, name) {
+			hasValue, value = true, "true"
+		}
+		if !hasValue {
+			if i+1 == len(rest) {
+				return nil, argsParseError(ctx)
+			}
+			i++
+			value = rest[i]
+		}
+		if err := ctx.Set(name, value); err != nil {
+			return nil, argsParseError(ctx)
+		}
+	}
+	return args, nil
+}
+
+func isBoolFlag(ctx *cli.Context, name string) bool {
+	for _, flag := range ctx.Command.Flags {
+		if _, ok := flag.(*cli.BoolFlag); ok && slices.Contains(flag.Names(), name) {
+			return true
+		}
+	}
+	return false
+}
@@ -53,6 +53,7 @@ func (c *cmd) Connect(ctx *cli.Context) error {
 	// generate server tag from given args
 	serverTag := strings.Join(args.Slice(), " ")
 	serverGroup := ctx.String(flagGroup)
+	profile := ctx.String(flagProfile)
 
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
@@ -67,6 +68,7 @@ func (c *cmd) Connect(ctx *cli.Context) error {
 	resp, err := c.client.Connect(context.Background(), &pb.ConnectRequest{
 		ServerTag:   serverTag,
 		ServerGroup: serverGroup,
+		Profile:     profile,
 	})
 	if err != nil {
 		return formatError(err)
@@ -82,6 +84,14 @@ func (c *cmd) Connect(ctx *cli.Context) error {
 			return formatError(err)
 		}
 
+		// connection failures are sent without any details
+		if profile != "" && (out.Type != internal.CodeFailure || len(out.Data) > 0) {
+			if err := c.profileError(out, profile); err != nil {
+				rpcErr = err
+				continue
+			}
+		}
+
 		switch out.Type {
 		case internal.CodeFailure:
 			if ctx.NArg() > 0 {
@@ -111,10 +121,14 @@ func (c *cmd) Connect(ctx *cli.Context) error {
 			rpcErr = errors.New(internal.ServerUnavailableErrorMessage)
 		case internal.CodeDoubleGroupError:
 			rpcErr = errors.New(internal.DoubleGroupErrorMessage)
+		case internal.CodePolicyEnforced:
+			rpcErr = ErrPolicyEnforced
 		case internal.CodeVPNRunning:
 			color.Yellow(client.ConnectConnected)
 		case internal.CodeUFWDisabled:
 			color.Yellow(client.UFWDisabledMessage)
+		case internal.CodeCaptivePortal:
+			color.Yellow(fmt.Sprintf(internal.CaptivePortalDetected, internal.StringsToInterfaces(out.Data)...))
 		case internal.CodeConnecting:
 			color.Green(fmt.Sprintf(client.ConnectStart, internal.StringsToInterfaces(out.Data)...))
 		case internal.CodeConnected:
@@ -122,6 +136,13 @@ func (c *cmd) Connect(ctx *cli.Context) error {
 		}
 	}
 
+	// settings kept by the client might have been changed by the profile
+	if profile != "" && rpcErr == nil {
+		if err := c.syncSettings(); err != nil {
+			return formatError(ErrConfig)
+		}
+	}
+
 	return formatError(rpcErr)
 }
 
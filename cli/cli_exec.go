@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/isolation"
+
+	"github.com/urfave/cli/v2"
+)
+
+// ExecUsageText is shown next to exec command by nordvpn --help
+const ExecUsageText = "Runs a command in the isolated VPN network namespace. " +
+	"Has to be executed as root, the command is run with the privileges of the user who invoked sudo."
+
+// ExecArgsUsageText is shown by nordvpn exec --help
+const ExecArgsUsageText = `-- <command> [arguments...]
+
+Example: 'sudo nordvpn exec -- curl https://nordvpn.com'`
+
+func execCommand(c *cmd) *cli.Command {
+	return &cli.Command{
+		Name:            "exec",
+		Usage:           ExecUsageText,
+		ArgsUsage:       ExecArgsUsageText,
+		Action:          c.Exec,
+		SkipFlagParsing: true,
+	}
+}
+
+// Exec does not communicate with the daemon, the namespace is entered directly
+func (c *cmd) Exec(ctx *cli.Context) error {
+	args := ctx.Args().Slice()
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return formatError(argsCountError(ctx))
+	}
+	if os.Geteuid() != 0 {
+		return formatError(errors.New(MsgExecRequiresRoot))
+	}
+
+	err := isolation.Exec(args)
+	if errors.Is(err, isolation.ErrNotIsolated) {
+		return formatError(errors.New(MsgExecNotIsolated))
+	}
+	return formatError(err)
+}
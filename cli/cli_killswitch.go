@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/iptables"
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/persistent"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// KillSwitchUsageText is shown next to killswitch command by nordvpn --help
+const KillSwitchUsageText = "Manages Kill Switch"
+
+// KillSwitchReleaseUsageText is shown next to release command by nordvpn killswitch --help
+const KillSwitchReleaseUsageText = "Unblocks the traffic by turning off Kill Switch including its persistent mode. " +
+	"If the daemon is not running, it has to be executed as root."
+
+func killSwitchCommand(c *cmd) *cli.Command {
+	return &cli.Command{
+		Name:  "killswitch",
+		Usage: KillSwitchUsageText,
+		Subcommands: []*cli.Command{
+			{
+				Name:               "release",
+				Usage:              KillSwitchReleaseUsageText,
+				Action:             c.KillSwitchRelease,
+				CustomHelpTemplate: CommandWithoutArgsHelpTemplate,
+			},
+		},
+	}
+}
+
+func (c *cmd) KillSwitchRelease(ctx *cli.Context) error {
+	if c.client != nil {
+		resp, err := c.client.ReleaseKillSwitch(context.Background(), &pb.Empty{})
+		if err == nil {
+			switch resp.Type {
+			case internal.CodeConfigError:
+				return formatError(ErrConfig)
+			case internal.CodePolicyEnforced:
+				return formatError(ErrPolicyEnforced)
+			case internal.CodeKillSwitchError:
+				return formatError(internal.ErrUnhandled)
+			case internal.CodeNothingToDo:
+				color.Yellow(MsgKillSwitchNotEnabled)
+			case internal.CodeSuccess:
+				color.Green(MsgKillSwitchReleased)
+			}
+			return nil
+		}
+		log.Println(internal.WarningPrefix, "releasing killswitch via daemon:", err)
+	}
+
+	// daemon is not running, so the persistent rules are removed directly
+	if os.Geteuid() != 0 {
+		return formatError(errors.New(MsgKillSwitchReleaseRequiresRoot))
+	}
+	killSwitch := persistent.New(
+		persistent.RulesDir,
+		iptables.FilterSupportedIPTables(internal.GetSupportedIPTables()),
+		nil,
+		0,
+	)
+	if !killSwitch.IsEnabled() {
+		color.Yellow(MsgKillSwitchNotEnabled)
+		return nil
+	}
+	if err := killSwitch.Release(); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return formatError(internal.ErrUnhandled)
+	}
+	color.Green(MsgKillSwitchReleased)
+	return nil
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// ProfileUsageText is shown next to profile command by nordvpn --help
+const ProfileUsageText = "Manages named sets of connection settings"
+
+// ProfileCreateUsageText is shown next to create command by nordvpn profile --help
+const ProfileCreateUsageText = "Saves current connection settings as a profile"
+
+// ProfileCreateArgsUsageText is shown by nordvpn profile create --help
+const ProfileCreateArgsUsageText = `<name>
+
+Saves current technology, protocol, obfuscation, kill switch, Threat Protection Lite, DNS, IPv6, whitelist and auto-connect server as a profile.
+Names can contain letters, digits, '-' and '_'.
+
+Example: nordvpn profile create work`
+
+// ProfileUseUsageText is shown next to use command by nordvpn profile --help
+const ProfileUseUsageText = "Applies all settings of a profile at once"
+
+// ProfileUseArgsUsageText is shown by nordvpn profile use --help
+const ProfileUseArgsUsageText = `<name>
+
+Applies all settings of a profile at once. If any of them cannot be applied, none of them are.
+
+Example: nordvpn profile use travel`
+
+// ProfileListUsageText is shown next to list command by nordvpn profile --help
+const ProfileListUsageText = "Shows saved profiles"
+
+// ProfileDeleteUsageText is shown next to delete command by nordvpn profile --help
+const ProfileDeleteUsageText = "Deletes a profile"
+
+// ProfileDeleteArgsUsageText is shown by nordvpn profile delete --help
+const ProfileDeleteArgsUsageText = `<name>
+
+Deletes a profile. Current settings are not changed.
+
+Example: nordvpn profile delete travel`
+
+// ConnectFlagProfileUsageText is shown next to profile flag by nordvpn connect --help
+const ConnectFlagProfileUsageText = "Apply a profile before connecting"
+
+func (c *cmd) ProfileCreate(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+
+	resp, err := c.client.ProfileCreate(context.Background(), &pb.ProfileRequest{Name: name})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFormatError:
+		return formatError(argsParseError(ctx))
+	case internal.CodeConflict:
+		return formatError(fmt.Errorf(MsgProfileExists, name))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgProfileCreated, name))
+	}
+	return nil
+}
+
+func (c *cmd) ProfileUse(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+
+	resp, err := c.client.ProfileUse(context.Background(), &pb.ProfileRequest{Name: name})
+	if err != nil {
+		return formatError(err)
+	}
+	if err := c.profileError(resp, name); err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgProfileAlreadyUsed, name))
+	case internal.CodeSuccess:
+		if err := c.syncSettings(); err != nil {
+			return formatError(ErrConfig)
+		}
+		color.Green(fmt.Sprintf(MsgProfileUsed, name))
+		printLines(resp.Data)
+	}
+	return nil
+}
+
+// profileError converts payload of a failed profile usage to an error.
+func (c *cmd) profileError(resp *pb.Payload, name string) error {
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return ErrConfig
+	case internal.CodeProfileNonexisting:
+		return fmt.Errorf(MsgProfileNonexisting, name)
+	case internal.CodePolicyEnforced:
+		return fmt.Errorf(MsgPolicyEnforcedSettings, strings.Join(resp.Data, ", "))
+	case internal.CodeSettingsRollbackFailed:
+		return fmt.Errorf(MsgSettingsRollbackFailed, strings.Join(resp.Data, ", "))
+	case internal.CodeFormatError, internal.CodeFailure:
+		return fmt.Errorf(MsgProfileCannotBeUsed, name, strings.Join(resp.Data, ""))
+	case internal.CodeAutoConnectServerNotObfuscated:
+		return errors.New(AutoConnectOnNonObfuscatedServerObfuscateOn)
+	case internal.CodeAutoConnectServerObfuscated:
+		return errors.New(AutoConnectOnObfuscatedServerObfuscateOff)
+	}
+	return nil
+}
+
+func (c *cmd) ProfileList(ctx *cli.Context) error {
+	resp, err := c.client.Profiles(context.Background(), &pb.Empty{})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeSuccess:
+		break
+	default:
+		return formatError(internal.ErrUnhandled)
+	}
+
+	if len(resp.Profiles) == 0 {
+		color.Yellow(MsgProfilesEmpty)
+		return nil
+	}
+
+	for idx, profile := range resp.Profiles {
+		if idx > 0 {
+			fmt.Println()
+		}
+		if profile.Name == resp.Active {
+			fmt.Printf("%s (active)\n", profile.Name)
+		} else {
+			fmt.Println(profile.Name)
+		}
+		fmt.Printf("  Technology: %s\n", profile.Technology)
+		if profile.Technology == config.Technology_OPENVPN {
+			fmt.Printf("  Protocol: %s\n", profile.Protocol)
+			fmt.Printf("  Obfuscate: %s\n", nstrings.GetBoolLabel(profile.Obfuscate))
+		}
+		fmt.Printf("  Kill Switch: %s\n", nstrings.GetBoolLabel(profile.KillSwitch))
+		fmt.Printf("  Threat Protection Lite: %s\n", nstrings.GetBoolLabel(profile.ThreatProtectionLite))
+		fmt.Printf("  IPv6: %s\n", nstrings.GetBoolLabel(profile.Ipv6))
+		if len(profile.Dns) == 0 {
+			fmt.Printf("  DNS: %s\n", nstrings.GetBoolLabel(false))
+		} else {
+			fmt.Printf("  DNS: %s\n", strings.Join(profile.Dns, ", "))
+		}
+		for _, entry := range whitelistFromProtobuf(profile.GetWhitelist()).Entries {
+			fmt.Printf("  Whitelisted: %s\n", entry)
+		}
+	}
+	return nil
+}
+
+func (c *cmd) ProfileDelete(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+
+	resp, err := c.client.ProfileDelete(context.Background(), &pb.ProfileRequest{Name: name})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeProfileNonexisting:
+		return formatError(fmt.Errorf(MsgProfileNonexisting, name))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgProfileDeleted, name))
+	}
+	return nil
+}
+
+func (c *cmd) ProfileAutoComplete(ctx *cli.Context) {
+	if ctx.NArg() > 0 {
+		return
+	}
+	resp, err := c.client.Profiles(context.Background(), &pb.Empty{})
+	if err != nil {
+		return
+	}
+	for _, profile := range resp.Profiles {
+		fmt.Println(profile.Name)
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+
+	"github.com/fatih/color"
+	"github.com/hako/durafmt"
+	"github.com/urfave/cli/v2"
+)
+
+// ServersUsageText is shown next to servers command by nordvpn --help
+const ServersUsageText = "Shows information about servers"
+
+// ServersHistoryUsageText is shown next to history command by nordvpn servers --help
+const ServersHistoryUsageText = "Shows outcomes of past connections to servers. Servers which repeatedly fail to connect are picked less often."
+
+func (c *cmd) ServersHistory(ctx *cli.Context) error {
+	resp, err := c.client.ServersHistory(context.Background(), &pb.Empty{})
+	if err != nil {
+		return formatError(err)
+	}
+
+	if len(resp.Servers) == 0 {
+		color.Yellow(MsgServersHistoryEmpty)
+		return nil
+	}
+	fmt.Print(ServersHistory(resp.Servers))
+	return nil
+}
+
+// ServersHistory returns ready to print servers history string.
+func ServersHistory(servers []*pb.ServerHistory) string {
+	var b strings.Builder
+	for _, server := range servers {
+		b.WriteString(fmt.Sprintf("%s\n", server.Hostname))
+		b.WriteString(fmt.Sprintf(
+			"  Connections: %d successful, %d failed\n",
+			server.Successes, server.Failures,
+		))
+		if server.ConnectedAt != 0 {
+			b.WriteString(fmt.Sprintf(
+				"  Last connected: %s\n",
+				time.Unix(server.ConnectedAt, 0).Format(time.RFC1123),
+			))
+		}
+		if server.AverageConnectTime != 0 {
+			// truncate to skip microseconds from being displayed
+			connectTime := time.Duration(server.AverageConnectTime).Truncate(time.Millisecond)
+			b.WriteString(fmt.Sprintf(
+				"  Average time to connect: %s\n",
+				durafmt.Parse(connectTime).String(),
+			))
+		}
+		if server.AverageThroughput != 0 {
+			b.WriteString(fmt.Sprintf(
+				"  Average throughput: %s/s\n",
+				uint64ToHumanBytes(server.AverageThroughput),
+			))
+		}
+		if server.DisconnectReason != "" {
+			b.WriteString(fmt.Sprintf("  Last disconnect reason: %s\n", server.DisconnectReason))
+		}
+	}
+	return b.String()
+}
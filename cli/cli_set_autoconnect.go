@@ -62,13 +62,6 @@ func (c *cmd) SetAutoConnect(ctx *cli.Context) error {
 		ThreatProtectionLite: c.config.ThreatProtectionLite,
 		AutoConnect:          flag,
 		Dns:                  c.config.DNS,
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(c.config.Whitelist.Ports.UDP),
-				Tcp: client.SetToInt64s(c.config.Whitelist.Ports.TCP),
-			},
-			Subnets: internal.SetToStrings(c.config.Whitelist.Subnets),
-		},
 	})
 	if err != nil {
 		return formatError(err)
@@ -77,6 +70,8 @@ func (c *cmd) SetAutoConnect(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeFailure, internal.CodeEmptyPayloadError:
 		if ctx.NArg() > 1 {
 			return formatError(fmt.Errorf(client.ConnectCantConnectTo, internal.StringsToInterfaces(ctx.Args().Slice()[1:])...))
@@ -89,6 +89,8 @@ func (c *cmd) SetDNS(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeFailure, internal.CodeVPNMisconfig:
 		return formatError(internal.ErrUnhandled)
 	case internal.CodeSuccess:
@@ -39,6 +39,8 @@ func (c *cmd) SetFirewall(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeNothingToDo:
 		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Firewall", nstrings.GetBoolLabel(flag)))
 	case internal.CodeDependencyError:
@@ -68,6 +70,8 @@ func (c *cmd) SetFirewallMark(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeNothingToDo:
 		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Firewall Mark", args.First()))
 	case internal.CodeSuccess:
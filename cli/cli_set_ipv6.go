@@ -32,6 +32,8 @@ func (c *cmd) SetIpv6(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeNothingToDo:
 		color.Yellow(fmt.Sprintf(MsgAlreadySet, "IPv6", nstrings.GetBoolLabel(flag)))
 	case internal.CodeSuccess:
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+const SetIsolationUsageText = "Enables or disables isolation. When enabled, " +
+	"VPN connection is set up in a dedicated network namespace instead of " +
+	"changing the network of the whole system. Only commands started with " +
+	"'nordvpn exec' use the VPN. Requires NordLynx technology."
+
+func (c *cmd) SetIsolation(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+
+	flag, err := nstrings.BoolFromString(ctx.Args().First())
+	if err != nil {
+		return formatError(argsParseError(ctx))
+	}
+
+	resp, err := c.client.SetIsolation(context.Background(), &pb.SetGenericRequest{Enabled: flag})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Isolation", nstrings.GetBoolLabel(flag)))
+	case internal.CodeDependencyError:
+		if len(resp.Data) > 0 && resp.Data[0] == config.SettingMeshnet {
+			color.Yellow(MsgIsolationMeshnet)
+		} else {
+			color.Yellow(MsgIsolationTechnology)
+		}
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgSetSuccess, "Isolation", nstrings.GetBoolLabel(flag)))
+		if connected, _ := strconv.ParseBool(resp.Data[0]); connected {
+			color.Yellow(SetReconnect)
+		}
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 	"github.com/NordSecurity/nordvpn-linux/nstrings"
@@ -16,25 +15,31 @@ import (
 // SetKillSwitchUsageText is shown next to killswitch command by nordvpn set --help
 const SetKillSwitchUsageText = "Enables or disables Kill Switch. This security feature blocks your device from accessing the Internet while not connected to the VPN or in case connection with a VPN server is lost."
 
+// SetKillSwitchPersistentUsage is shown next to the persistent flag by nordvpn set killswitch --help
+const SetKillSwitchPersistentUsage = "Keeps blocking the traffic from the system boot and while the daemon is not running. Only VPN servers are reachable until the daemon starts."
+
+// SetKillSwitchPersistentExample is appended to nordvpn set killswitch --help
+const SetKillSwitchPersistentExample = `Example: nordvpn set killswitch on --persistent
+
+Use 'nordvpn killswitch release' to unblock the traffic if the daemon is not running.`
+
 func (c *cmd) SetKillSwitch(ctx *cli.Context) error {
-	if ctx.NArg() != 1 {
+	args, err := argsWithTrailingFlags(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	if len(args) != 1 {
 		return formatError(argsCountError(ctx))
 	}
 
-	flag, err := nstrings.BoolFromString(ctx.Args().First())
+	flag, err := nstrings.BoolFromString(args[0])
 	if err != nil {
 		return formatError(argsParseError(ctx))
 	}
 
 	resp, err := c.client.SetKillSwitch(context.Background(), &pb.SetKillSwitchRequest{
 		KillSwitch: flag,
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(c.config.Whitelist.Ports.UDP),
-				Tcp: client.SetToInt64s(c.config.Whitelist.Ports.TCP),
-			},
-			Subnets: internal.SetToStrings(c.config.Whitelist.Subnets),
-		},
+		Persistent: ctx.Bool(flagKillSwitchPersistent),
 	})
 	if err != nil {
 		return formatError(err)
@@ -43,6 +48,8 @@ func (c *cmd) SetKillSwitch(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeVPNMisconfig, internal.CodeKillSwitchError, internal.CodeFailure:
 		return formatError(internal.ErrUnhandled)
 	case internal.CodeNothingToDo:
@@ -51,6 +58,9 @@ func (c *cmd) SetKillSwitch(ctx *cli.Context) error {
 		color.Yellow(fmt.Sprintf(FirewallRequired, "killswitch"))
 	case internal.CodeSuccess:
 		color.Green(fmt.Sprintf(MsgSetSuccess, "Kill Switch", nstrings.GetBoolLabel(flag)))
+		if flag && ctx.Bool(flagKillSwitchPersistent) {
+			color.Green(MsgKillSwitchPersistent)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSetKillSwitchArgs(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name       string
+		input      []string
+		args       []string
+		persistent bool
+	}{
+		{name: "without flag", input: []string{"on"}, args: []string{"on"}},
+		{name: "flag before argument", input: []string{"--persistent", "on"}, args: []string{"on"}, persistent: true},
+		{name: "flag after argument", input: []string{"on", "--persistent"}, args: []string{"on"}, persistent: true},
+		{name: "flag with value", input: []string{"on", "--persistent=false"}, args: []string{"on"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := []cli.Flag{&cli.BoolFlag{Name: flagKillSwitchPersistent}}
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			for _, f := range flags {
+				require.NoError(t, f.Apply(set))
+			}
+			require.NoError(t, set.Parse(test.input))
+			ctx := cli.NewContext(cli.NewApp(), set, &cli.Context{Context: context.Background()})
+			ctx.Command = &cli.Command{Name: "killswitch", Flags: flags}
+
+			args, err := argsWithTrailingFlags(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, test.args, args)
+			assert.Equal(t, test.persistent, ctx.Bool(flagKillSwitchPersistent))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+const SetLanDiscoveryUsageText = "Enables or disables LAN discovery. When " +
+	"enabled, devices in the local networks, such as printers or NAS, stay " +
+	"reachable while connected to VPN and can be discovered using mDNS and " +
+	"SSDP. Local networks are updated automatically when the network changes."
+
+func (c *cmd) SetLanDiscovery(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+
+	flag, err := nstrings.BoolFromString(ctx.Args().First())
+	if err != nil {
+		return formatError(argsParseError(ctx))
+	}
+
+	resp, err := c.client.SetLanDiscovery(context.Background(), &pb.SetGenericRequest{Enabled: flag})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFailure:
+		return formatError(internal.ErrUnhandled)
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgAlreadySet, "LAN Discovery", nstrings.GetBoolLabel(flag)))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgSetSuccess, "LAN Discovery", nstrings.GetBoolLabel(flag)))
+	}
+	return nil
+}
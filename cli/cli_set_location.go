@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SetLocationUsageText is shown next to location command by nordvpn set --help
+const SetLocationUsageText = "Overrides your detected location. It is used to find the nearest servers."
+
+// SetLocationArgsUsageText is shown by nordvpn set location --help
+const SetLocationArgsUsageText = `[country_code]/[latitude] [longitude]/[disabled]
+
+Overrides your detected location. It is used to find the nearest servers when connecting without specifying a country or a city.
+
+Provide a [country_code] argument as a two letter country code. For example: 'nordvpn set location de'
+Provide [latitude] and [longitude] arguments in degrees. For example: 'nordvpn set location 54.6872 25.2797'
+
+Supported values for [disabled]: 0, false, disable, off, disabled
+Example: nordvpn set location off`
+
+func (c *cmd) SetLocation(ctx *cli.Context) error {
+	args := ctx.Args()
+	req := &pb.SetLocationRequest{}
+	switch args.Len() {
+	case 1:
+		location := strings.TrimSpace(args.First())
+		if !nstrings.CanParseFalseFromString(location) {
+			req.Location = &pb.SetLocationRequest_CountryCode{CountryCode: location}
+		}
+	case 2:
+		latitude, err := strconv.ParseFloat(args.Get(0), 64)
+		if err != nil {
+			return formatError(argsParseError(ctx))
+		}
+		longitude, err := strconv.ParseFloat(args.Get(1), 64)
+		if err != nil {
+			return formatError(argsParseError(ctx))
+		}
+		req.Location = &pb.SetLocationRequest_Coordinates{
+			Coordinates: &pb.Location{Latitude: latitude, Longitude: longitude},
+		}
+	default:
+		return formatError(argsCountError(ctx))
+	}
+
+	resp, err := c.client.SetLocation(context.Background(), req)
+	if err != nil {
+		return formatError(err)
+	}
+
+	value := nstrings.GetBoolLabel(false)
+	if len(resp.Data) > 0 {
+		value = strings.Join(resp.Data, "")
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeTagNonexisting:
+		return formatError(errors.New(internal.TagNonexistentErrorMessage))
+	case internal.CodeFormatError:
+		return formatError(argsParseError(ctx))
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Location", value))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgSetSuccess, "Location", value))
+	}
+	return nil
+}
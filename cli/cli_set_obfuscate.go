@@ -46,6 +46,8 @@ func (c *cmd) SetObfuscate(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeAutoConnectServerNotObfuscated:
 		return formatError(errors.New(ObfuscateOnServerNotObfuscated))
 	case internal.CodeAutoConnectServerObfuscated:
@@ -74,6 +76,8 @@ func (c *cmd) BeforeSetObfuscate(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return ErrConfig
+	case internal.CodePolicyEnforced:
+		return ErrPolicyEnforced
 	case internal.CodeSuccess:
 		break
 	default:
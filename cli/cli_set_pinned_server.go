@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/client"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SetPinnedServerUsageText is shown next to pinnedserver command by nordvpn set --help
+const SetPinnedServerUsageText = "Pins automatic connections to a single server. Auto-connect, reconnects and server checks will never switch away from it."
+
+// SetPinnedServerArgsUsageText is shown by nordvpn set pinnedserver --help
+const SetPinnedServerArgsUsageText = `[server]/[disabled]
+
+Pins automatic connections to a single server. Auto-connect, reconnects and server checks will never switch away from it.
+When the pinned server goes offline, you will be notified instead.
+
+Provide a [server] argument as a hostname or a server ID. For example: 'nordvpn set pinnedserver lt16' or 'nordvpn set pinnedserver 947373'
+
+Supported values for [disabled]: 0, false, disable, off, disabled
+Example: nordvpn set pinnedserver off`
+
+func (c *cmd) SetPinnedServer(ctx *cli.Context) error {
+	args := ctx.Args()
+	if args.Len() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+
+	server := strings.ToLower(strings.TrimSpace(args.First()))
+	if nstrings.CanParseFalseFromString(server) {
+		server = ""
+	}
+
+	resp, err := c.client.SetPinnedServer(context.Background(), &pb.SetPinnedServerRequest{
+		Server: server,
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeTagNonexisting:
+		return formatError(errors.New(internal.TagNonexistentErrorMessage))
+	case internal.CodeNothingToDo:
+		if server == "" {
+			color.Yellow(fmt.Sprintf(MsgAlreadySet, "Pinned server", nstrings.GetBoolLabel(false)))
+		} else {
+			color.Yellow(fmt.Sprintf(MsgAlreadySet, "Pinned server", strings.Join(resp.Data, "")))
+		}
+	case internal.CodeExpiredRenewToken:
+		color.Yellow(client.RelogRequest)
+		err = c.Login(ctx)
+		if err != nil {
+			return err
+		}
+		return c.SetPinnedServer(ctx)
+	case internal.CodeTokenRenewError:
+		return formatError(errors.New(client.AccountTokenRenewError))
+	case internal.CodeSuccess:
+		if server == "" {
+			color.Green(fmt.Sprintf(MsgSetSuccess, "Pinned server", nstrings.GetBoolLabel(false)))
+		} else {
+			color.Green(fmt.Sprintf(MsgSetSuccess, "Pinned server", strings.Join(resp.Data, "")))
+		}
+	}
+	return nil
+}
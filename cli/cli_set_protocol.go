@@ -66,6 +66,8 @@ func (c *cmd) SetProtocol(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeSuccess:
 		c.config.Protocol = proto
 		err = c.configManager.Save(c.config)
@@ -90,6 +92,8 @@ func (c *cmd) BeforeSetProtocol(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return ErrConfig
+	case internal.CodePolicyEnforced:
+		return ErrPolicyEnforced
 	case internal.CodeSuccessWithoutAC:
 		// must be right before CodeSuccess
 		color.Yellow(SetAutoConnectForceOff)
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SetReconnectPolicyUsageText is shown next to reconnect-policy command by nordvpn set --help
+const SetReconnectPolicyUsageText = "Sets how VPN connection is refreshed " +
+	"after the network changes or the device wakes up"
+
+// SetReconnectPolicyArgsUsageText is shown by nordvpn set reconnect-policy --help
+const SetReconnectPolicyArgsUsageText = `[policy]
+
+Use this command to set how VPN connection is refreshed after the network
+changes or the device wakes up from suspend.
+Supported values for [policy]:
+  full - reconnects to the same server (default)
+  fast - moves the existing connection to the new network, if supported by
+         the technology, and reconnects otherwise
+  reselect - reconnects and picks a new server if the new network is in a
+             different country
+
+Example: 'nordvpn set reconnect-policy fast'`
+
+func (c *cmd) SetReconnectPolicy(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+
+	policy, err := config.ParseReconnectPolicy(ctx.Args().First())
+	if err != nil {
+		return formatError(argsParseError(ctx))
+	}
+
+	resp, err := c.client.SetReconnectPolicy(context.Background(), &pb.SetReconnectPolicyRequest{
+		Policy: string(policy),
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFormatError:
+		return formatError(argsParseError(ctx))
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Reconnect policy", policy))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgSetSuccess, "Reconnect policy", policy))
+	}
+	return nil
+}
+
+func (c *cmd) SetReconnectPolicyAutoComplete(ctx *cli.Context) {
+	if ctx.NArg() > 0 {
+		return
+	}
+	for _, policy := range config.ReconnectPolicies {
+		fmt.Println(policy)
+	}
+}
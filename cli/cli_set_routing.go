@@ -37,6 +37,8 @@ func (c *cmd) SetRouting(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeNothingToDo:
 		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Routing", nstrings.GetBoolLabel(flag)))
 	case internal.CodeDependencyError:
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -56,9 +57,14 @@ func (c *cmd) SetTechnology(ctx *cli.Context) error {
 	switch resp.Type {
 	case internal.CodeConfigError:
 		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
 	case internal.CodeFormatError:
 		return formatError(argsParseError(ctx))
 	case internal.CodeDependencyError:
+		if len(resp.Data) > 0 && resp.Data[0] == config.SettingIsolation {
+			return formatError(errors.New(MsgTechnologyIsolation))
+		}
 		return formatError(fmt.Errorf(SetTechnologyDepsError, internal.StringsToInterfaces(resp.Data)...))
 	case internal.CodeNothingToDo:
 		color.Yellow(fmt.Sprintf(MsgAlreadySet, "Technology", strings.Join(resp.Data, " ")))
@@ -4,11 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
-	"strconv"
 	"strings"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
 	cconfig "github.com/NordSecurity/nordvpn-linux/client/config"
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
@@ -22,12 +19,6 @@ import (
 // SettingsUsageText is show next to settings command by nordvpn --help
 const SettingsUsageText = "Shows current settings"
 
-type PortRange struct {
-	start     int64
-	end       int64
-	protocols []string
-}
-
 func (c *cmd) Settings(ctx *cli.Context) error {
 	resp, err := c.client.Settings(context.Background(), &pb.SettingsRequest{
 		Uid: int64(os.Getuid()),
@@ -45,84 +36,83 @@ func (c *cmd) Settings(ctx *cli.Context) error {
 		return formatError(internal.ErrUnhandled)
 	}
 
-	fmt.Printf("Technology: %s\n", resp.Data.GetTechnology())
+	// settings locked by the administrator policy are marked
+	enforced := func(setting string) string {
+		if slices.Contains(resp.Data.GetEnforced(), setting) {
+			return " " + SettingEnforcedLabel
+		}
+		return ""
+	}
+
+	fmt.Printf("Technology: %s%s\n", resp.Data.GetTechnology(), enforced(config.SettingTechnology))
 	if resp.Data.Technology == config.Technology_OPENVPN {
-		fmt.Printf("Protocol: %s\n", c.config.Protocol)
+		fmt.Printf("Protocol: %s%s\n", c.config.Protocol, enforced(config.SettingProtocol))
 	}
-	fmt.Printf("Firewall: %+v\n", nstrings.GetBoolLabel(resp.Data.GetFirewall()))
-	fmt.Printf("Firewall Mark: 0x%x\n", resp.Data.GetFwmark())
-	fmt.Printf("Routing: %+v\n", nstrings.GetBoolLabel(resp.Data.GetRouting()))
+	fmt.Printf("Firewall: %+v%s\n", nstrings.GetBoolLabel(resp.Data.GetFirewall()), enforced(config.SettingFirewall))
+	fmt.Printf("Firewall Mark: 0x%x%s\n", resp.Data.GetFwmark(), enforced(config.SettingFirewallMark))
+	fmt.Printf("Routing: %+v%s\n", nstrings.GetBoolLabel(resp.Data.GetRouting()), enforced(config.SettingRouting))
 	fmt.Printf("Analytics: %+v\n", nstrings.GetBoolLabel(resp.Data.GetAnalytics()))
-	fmt.Printf("Kill Switch: %+v\n", nstrings.GetBoolLabel(resp.Data.GetKillSwitch()))
-	fmt.Printf("Threat Protection Lite: %+v\n", nstrings.GetBoolLabel(c.config.ThreatProtectionLite))
+	killSwitch := nstrings.GetBoolLabel(resp.Data.GetKillSwitch())
+	if resp.Data.GetKillSwitchPersistent() {
+		killSwitch += " (persistent)"
+	}
+	fmt.Printf("Kill Switch: %+v%s\n", killSwitch, enforced(config.SettingKillSwitch))
+	fmt.Printf("Threat Protection Lite: %+v%s\n", nstrings.GetBoolLabel(c.config.ThreatProtectionLite), enforced(config.SettingThreatProtectionLite))
 	if resp.Data.Technology == config.Technology_OPENVPN {
-		fmt.Printf("Obfuscate: %+v\n", nstrings.GetBoolLabel(c.config.Obfuscate))
+		fmt.Printf("Obfuscate: %+v%s\n", nstrings.GetBoolLabel(c.config.Obfuscate), enforced(config.SettingObfuscate))
 	}
 	fmt.Printf("Notify: %+v\n", nstrings.GetBoolLabel(resp.Data.Notify))
-	fmt.Printf("Auto-connect: %+v\n", nstrings.GetBoolLabel(resp.Data.AutoConnect))
-	fmt.Printf("IPv6: %+v\n", nstrings.GetBoolLabel(resp.Data.Ipv6))
+	fmt.Printf("Auto-connect: %+v%s\n", nstrings.GetBoolLabel(resp.Data.AutoConnect), enforced(config.SettingAutoConnect))
+	if resp.Data.PinnedServer != "" {
+		fmt.Printf("Pinned server: %s\n", resp.Data.PinnedServer)
+	}
+	if location := resp.Data.Location; location != nil {
+		fmt.Printf("Location: %s (%.4f, %.4f)\n", location.CountryCode, location.Latitude, location.Longitude)
+	}
+	fmt.Printf("IPv6: %+v%s\n", nstrings.GetBoolLabel(resp.Data.Ipv6), enforced(config.SettingIPv6))
 	fmt.Printf("Meshnet: %+v\n", nstrings.GetBoolLabel(resp.Data.Meshnet))
+	fmt.Printf("Isolation: %+v\n", nstrings.GetBoolLabel(resp.Data.GetIsolation()))
+	fmt.Printf("LAN Discovery: %+v\n", nstrings.GetBoolLabel(resp.Data.GetLanDiscovery()))
+	fmt.Printf("Reconnect Policy: %s\n", resp.Data.GetReconnectPolicy())
 	if len(c.config.DNS) == 0 {
-		fmt.Printf("DNS: %+v\n", nstrings.GetBoolLabel(false))
+		fmt.Printf("DNS: %+v%s\n", nstrings.GetBoolLabel(false), enforced(config.SettingDNS))
 	} else {
-		fmt.Printf("DNS: %+v\n", strings.Join(c.config.DNS, ", "))
+		fmt.Printf("DNS: %+v%s\n", strings.Join(c.config.DNS, ", "), enforced(config.SettingDNS))
 	}
 
 	displayWhitelist(&c.config.Whitelist)
+	if label := enforced(config.SettingWhitelist); label != "" {
+		fmt.Printf("Whitelist:%s\n", label)
+	}
 	return nil
 }
 
 func displayWhitelist(whitelist *cconfig.Whitelist) {
-	if whitelist != nil {
-		udpPorts := whitelist.Ports.UDP.ToSlice()
-		tcpPorts := whitelist.Ports.TCP.ToSlice()
-		if len(udpPorts)+len(tcpPorts) > 0 {
-			allPorts := whitelist.Ports.UDP.Union(whitelist.Ports.TCP).ToSlice()
-			sort.Slice(allPorts, func(i, j int) bool {
-				return client.InterfaceToInt64(allPorts[i]) < client.InterfaceToInt64(allPorts[j])
-			})
-			whitelistedRanges := make([]PortRange, 0)
-			for _, port := range allPorts {
-				//find current iteration's protocols
-				var protos []string
-				if whitelist.Ports.UDP.Contains(port) {
-					protos = append(protos, "UDP")
-				}
-				if whitelist.Ports.TCP.Contains(port) {
-					protos = append(protos, "TCP")
-				}
-
-				var lastProtos []string
-				var lastEndPort int64
-				if len(whitelistedRanges) > 0 {
-					last := whitelistedRanges[len(whitelistedRanges)-1]
-					lastProtos = last.protocols
-					lastEndPort = last.end
-				}
-				//check if the range whitelist range continues or should we be starting a new one
-				if !slices.Equal(protos, lastProtos) || client.InterfaceToInt64(port)-lastEndPort > 1 {
-					whitelistedRanges = append(whitelistedRanges, PortRange{start: client.InterfaceToInt64(port), protocols: protos})
-				}
-				//populate the range
-				whitelistedRanges[len(whitelistedRanges)-1].end = client.InterfaceToInt64(port)
-			}
-			fmt.Printf("Whitelisted ports:\n")
-			maxLength := len(strconv.FormatInt(client.InterfaceToInt64(allPorts[len(allPorts)-1]), 10))
-			for _, wlRange := range whitelistedRanges {
-				protoString := strings.Join(wlRange.protocols, "|")
-				if wlRange.start == wlRange.end {
-					fmt.Printf("  %*d (%s)\n", maxLength*2+3, wlRange.start, protoString)
-				} else {
-					fmt.Printf("  %*d - %*d (%s)\n", maxLength, wlRange.start, maxLength, wlRange.end, protoString)
-				}
-			}
+	if whitelist == nil {
+		return
+	}
+	var ports, subnets []config.WhitelistEntry
+	for _, entry := range whitelist.Entries {
+		if entry.Ports != nil {
+			ports = append(ports, entry)
+		} else {
+			subnets = append(subnets, entry)
 		}
-		subnets := whitelist.Subnets.ToSlice()
-		if len(subnets) > 0 {
-			fmt.Printf("Whitelisted subnets:\n")
-			for _, subnet := range subnets {
-				fmt.Printf("\t%s\n", subnet)
+	}
+	if len(ports) > 0 {
+		fmt.Printf("Whitelisted ports:\n")
+		for _, entry := range ports {
+			portString := entry.Ports.String()
+			if entry.Ports.Min != entry.Ports.Max {
+				portString = fmt.Sprintf("%d - %d", entry.Ports.Min, entry.Ports.Max)
 			}
+			fmt.Printf("  %s (%s)%s\n", portString, whitelistProtocolLabel(entry.Protocol), whitelistEntryDetails(entry))
+		}
+	}
+	if len(subnets) > 0 {
+		fmt.Printf("Whitelisted subnets:\n")
+		for _, entry := range subnets {
+			fmt.Printf("\t%s%s\n", entry.Subnet, whitelistEntryDetails(entry))
 		}
 	}
 }
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SettingsExportUsageText is shown next to export command by nordvpn settings --help
+const SettingsExportUsageText = "Prints settings which can be imported on another machine"
+
+// SettingsExportArgsUsageText is shown by nordvpn settings export --help
+const SettingsExportArgsUsageText = `Prints settings which can be imported on another machine. Login tokens are never exported.
+
+Example: nordvpn settings export --format json > nordvpn.json`
+
+// SettingsImportUsageText is shown next to import command by nordvpn settings --help
+const SettingsImportUsageText = "Applies settings from a YAML or JSON file"
+
+// SettingsImportArgsUsageText is shown by nordvpn settings import --help
+const SettingsImportArgsUsageText = `<file>
+
+Applies settings from a YAML or JSON file created by 'nordvpn settings export'.
+Settings missing in the file are not changed. Either all of the settings are applied or none of them.
+
+Example: nordvpn settings import nordvpn.yaml`
+
+const (
+	flagSettingsFormat = "format"
+	flagSettingsDryRun = "dry-run"
+)
+
+func (c *cmd) SettingsExport(ctx *cli.Context) error {
+	if ctx.NArg() != 0 {
+		return formatError(argsCountError(ctx))
+	}
+
+	resp, err := c.client.SettingsExport(context.Background(), &pb.SettingsExportRequest{
+		Format: ctx.String(flagSettingsFormat),
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFormatError:
+		return formatError(errors.New(strings.Join(resp.Data, "")))
+	case internal.CodeSuccess:
+		fmt.Println(strings.TrimSpace(strings.Join(resp.Data, "")))
+	}
+	return nil
+}
+
+func (c *cmd) SettingsImport(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+
+	data, err := internal.FileRead(ctx.Args().First())
+	if err != nil {
+		return formatError(err)
+	}
+
+	dryRun := ctx.Bool(flagSettingsDryRun)
+	resp, err := c.client.SettingsImport(context.Background(), &pb.SettingsImportRequest{
+		Settings: data,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFormatError, internal.CodeFailure:
+		return formatError(errors.New(strings.Join(resp.Data, "")))
+	case internal.CodePolicyEnforced:
+		return formatError(fmt.Errorf(MsgPolicyEnforcedSettings, strings.Join(resp.Data, ", ")))
+	case internal.CodeSettingsRollbackFailed:
+		return formatError(fmt.Errorf(MsgSettingsRollbackFailed, strings.Join(resp.Data, ", ")))
+	case internal.CodeAutoConnectServerNotObfuscated:
+		return formatError(errors.New(AutoConnectOnNonObfuscatedServerObfuscateOn))
+	case internal.CodeAutoConnectServerObfuscated:
+		return formatError(errors.New(AutoConnectOnObfuscatedServerObfuscateOff))
+	case internal.CodeNothingToDo:
+		color.Yellow(MsgSettingsImportNothingToDo)
+		printLines(resp.Data)
+	case internal.CodeSuccess:
+		if dryRun {
+			fmt.Println(MsgSettingsImportDryRun)
+			printLines(resp.Data)
+			return nil
+		}
+		if err := c.syncSettings(); err != nil {
+			return formatError(ErrConfig)
+		}
+		color.Green(MsgSettingsImported)
+		printLines(resp.Data)
+	}
+	return nil
+}
+
+// syncSettings updates settings which are also kept by the client.
+func (c *cmd) syncSettings() error {
+	resp, err := c.client.SettingsExport(context.Background(), &pb.SettingsExportRequest{
+		Format: config.SettingsFormatJSON,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Type != internal.CodeSuccess {
+		return ErrConfig
+	}
+
+	var settings config.Settings
+	if err := json.Unmarshal([]byte(strings.Join(resp.Data, "")), &settings); err != nil {
+		return err
+	}
+
+	cfg := settings.Apply(config.Config{})
+	c.config.Technology = cfg.Technology
+	c.config.Protocol = cfg.AutoConnectData.Protocol
+	c.config.Obfuscate = cfg.AutoConnectData.Obfuscate
+	c.config.ThreatProtectionLite = cfg.AutoConnectData.ThreatProtectionLite
+	c.config.DNS = cfg.AutoConnectData.DNS
+	c.config.Whitelist.Whitelist = cfg.AutoConnectData.Whitelist
+	return c.configManager.Save(c.config)
+}
+
+func printLines(lines []string) {
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SettingsHistoryUsageText is shown next to history command by nordvpn settings --help
+const SettingsHistoryUsageText = "Shows who changed settings and when"
+
+// SettingsHistoryArgsUsageText is shown by nordvpn settings history --help
+const SettingsHistoryArgsUsageText = `Shows latest settings changes together with the user and the command which made them.
+
+Example: nordvpn settings history --limit 5`
+
+const flagSettingsLimit = "limit"
+
+func (c *cmd) SettingsHistory(ctx *cli.Context) error {
+	if ctx.NArg() != 0 {
+		return formatError(argsCountError(ctx))
+	}
+
+	resp, err := c.client.SettingsHistory(context.Background(), &pb.SettingsHistoryRequest{
+		Limit: int64(ctx.Int(flagSettingsLimit)),
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeFormatError:
+		return formatError(argsParseError(ctx))
+	case internal.CodeFailure:
+		return formatError(internal.ErrUnhandled)
+	case internal.CodeSuccess:
+		break
+	default:
+		return formatError(internal.ErrUnhandled)
+	}
+
+	if len(resp.Entries) == 0 {
+		color.Yellow(MsgSettingsHistoryEmpty)
+		return nil
+	}
+
+	for _, entry := range resp.Entries {
+		fmt.Printf("%s %s: %s\n",
+			time.Unix(entry.Time, 0).Format(time.DateTime),
+			historyCaller(entry),
+			historyCommand(entry),
+		)
+		for _, change := range entry.Changes {
+			fmt.Printf("  %s: %s -> %s\n", change.Name, change.Old, change.New)
+		}
+	}
+	return nil
+}
+
+func historyCaller(entry *pb.SettingsHistoryEntry) string {
+	if entry.Rpc == "" {
+		return "daemon"
+	}
+	if entry.UnknownUid {
+		return "unknown user"
+	}
+	uid := strconv.FormatUint(uint64(entry.Uid), 10)
+	if u, err := user.LookupId(uid); err == nil {
+		return fmt.Sprintf("%s (uid %s)", u.Username, uid)
+	}
+	return "uid " + uid
+}
+
+func historyCommand(entry *pb.SettingsHistoryEntry) string {
+	if entry.Rpc == "" {
+		return "automatic change"
+	}
+	return entry.Rpc
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// SettingsUserUsageText is shown next to user command by nordvpn settings --help
+const SettingsUserUsageText = "Shows settings overridden for the current user"
+
+// SettingsUserSetUsageText is shown next to set command by nordvpn settings user --help
+const SettingsUserSetUsageText = "Overrides a setting for the current user"
+
+// SettingsUserSetArgsUsageText is shown by nordvpn settings user set --help
+const SettingsUserSetArgsUsageText = `<setting> <value>...
+
+Overrides a global setting for the current user only. The value is used every time you connect, other users keep the global value.
+Settings which can be overridden: protocol, obfuscate, threat_protection_lite and dns.
+Protocol and obfuscation are used only with OpenVPN technology.
+
+Example: nordvpn settings user set protocol tcp
+Example: nordvpn settings user set dns 1.1.1.1 1.0.0.1
+Example: nordvpn settings user set dns off`
+
+// SettingsUserUnsetUsageText is shown next to unset command by nordvpn settings user --help
+const SettingsUserUnsetUsageText = "Uses a global setting for the current user again"
+
+// SettingsUserUnsetArgsUsageText is shown by nordvpn settings user unset --help
+const SettingsUserUnsetArgsUsageText = `<setting>
+
+Example: nordvpn settings user unset dns`
+
+func (c *cmd) SettingsUser(ctx *cli.Context) error {
+	if ctx.NArg() != 0 {
+		return formatError(argsCountError(ctx))
+	}
+
+	resp, err := c.client.UserSettings(context.Background(), &pb.Empty{})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeSuccess:
+		break
+	default:
+		return formatError(internal.ErrUnhandled)
+	}
+
+	if len(resp.Settings) == 0 {
+		color.Yellow(MsgUserSettingsEmpty)
+		return nil
+	}
+	for _, setting := range resp.Settings {
+		fmt.Printf("%s: %s\n", setting.Name, setting.Value)
+	}
+	return nil
+}
+
+func (c *cmd) SettingsUserSet(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+	values := ctx.Args().Tail()
+
+	resp, err := c.client.SetUserSetting(context.Background(), &pb.UserSettingRequest{
+		Name:   name,
+		Values: values,
+	})
+	if err != nil {
+		return formatError(err)
+	}
+	if err := userSettingError(resp, name); err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgUserSettingAlreadySet, name))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgUserSettingSet, name, strings.Join(values, " ")))
+	}
+	return nil
+}
+
+func (c *cmd) SettingsUserUnset(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+
+	resp, err := c.client.UnsetUserSetting(context.Background(), &pb.UserSettingRequest{Name: name})
+	if err != nil {
+		return formatError(err)
+	}
+	if err := userSettingError(resp, name); err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeNothingToDo:
+		color.Yellow(fmt.Sprintf(MsgUserSettingAlreadySet, name))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgUserSettingUnset, name))
+	}
+	return nil
+}
+
+// userSettingError converts payload of a failed user setting change to an error.
+func userSettingError(resp *pb.Payload, name string) error {
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return ErrConfig
+	case internal.CodePolicyEnforced:
+		return ErrPolicyEnforced
+	case internal.CodeGlobalSetting:
+		return fmt.Errorf(MsgUserSettingGlobal, name, strings.Join(config.UserSettings, ", "))
+	case internal.CodeFormatError:
+		return errors.New(strings.Join(resp.Data, ""))
+	case internal.CodeFailure:
+		return internal.ErrUnhandled
+	}
+	return nil
+}
+
+func (c *cmd) SettingsUserAutoComplete(ctx *cli.Context) {
+	if ctx.NArg() > 0 {
+		return
+	}
+	for _, name := range config.UserSettings {
+		fmt.Println(name)
+	}
+}
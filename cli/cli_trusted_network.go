@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagTrustedNetworkGatewayIP  = "gateway-ip"
+	flagTrustedNetworkGatewayMAC = "gateway-mac"
+	flagTrustedNetworkSubnet     = "subnet"
+	flagTrustedNetworkInterface  = "interface"
+	flagTrustedNetworkDHCPDomain = "dhcp-domain"
+	flagTrustedNetworkAction     = "action"
+	flagTrustedNetworkCurrent    = "current"
+)
+
+// TrustedNetworkUsageText is shown next to trusted-network command by nordvpn --help
+const TrustedNetworkUsageText = "Connects or disconnects VPN automatically depending on the network"
+
+// TrustedNetworkAddUsageText is shown next to add command by nordvpn trusted-network --help
+const TrustedNetworkAddUsageText = "Adds a trusted network rule"
+
+// TrustedNetworkAddArgsUsageText is shown by nordvpn trusted-network add --help
+const TrustedNetworkAddArgsUsageText = `<name>
+
+Adds a rule which is checked every time the network changes and when the daemon starts.
+A network matches the rule when it matches all the given options. Rule without options
+matches any network and is always checked last.
+Rules are checked in the order they were added and the first matching one is applied.
+
+Example: nordvpn trusted-network add office --gateway-mac 00:11:22:33:44:55 --subnet 10.10.0.0/16
+Example: nordvpn trusted-network add home --current
+Example: nordvpn trusted-network add other --action connect`
+
+// TrustedNetworkRemoveUsageText is shown next to remove command by nordvpn trusted-network --help
+const TrustedNetworkRemoveUsageText = "Removes a trusted network rule"
+
+// TrustedNetworkRemoveArgsUsageText is shown by nordvpn trusted-network remove --help
+const TrustedNetworkRemoveArgsUsageText = `<name>
+
+Example: nordvpn trusted-network remove office`
+
+// TrustedNetworkListUsageText is shown next to list command by nordvpn trusted-network --help
+const TrustedNetworkListUsageText = "Shows trusted network rules and the current network"
+
+func trustedNetworkCommand(c *cmd) *cli.Command {
+	return &cli.Command{
+		Name:  "trusted-network",
+		Usage: TrustedNetworkUsageText,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     TrustedNetworkAddUsageText,
+				Action:    c.TrustedNetworkAdd,
+				ArgsUsage: TrustedNetworkAddArgsUsageText,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkGatewayIP,
+						Usage: "Matches the default gateway address",
+					},
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkGatewayMAC,
+						Usage: "Matches the default gateway hardware address",
+					},
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkSubnet,
+						Usage: "Matches when the gateway or the address of this device is in the subnet",
+					},
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkInterface,
+						Usage: "Matches the interface of the default route",
+					},
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkDHCPDomain,
+						Usage: "Matches the domain provided by DHCP",
+					},
+					&cli.StringFlag{
+						Name:  flagTrustedNetworkAction,
+						Usage: "What to do on the network: disconnect or connect",
+						Value: config.TrustedNetworkActionDisconnect,
+					},
+					&cli.BoolFlag{
+						Name:  flagTrustedNetworkCurrent,
+						Usage: "Matches the network this device is connected to now",
+					},
+				},
+			},
+			{
+				Name:         "remove",
+				Usage:        TrustedNetworkRemoveUsageText,
+				Action:       c.TrustedNetworkRemove,
+				BashComplete: c.TrustedNetworkAutoComplete,
+				ArgsUsage:    TrustedNetworkRemoveArgsUsageText,
+			},
+			{
+				Name:               "list",
+				Usage:              TrustedNetworkListUsageText,
+				Action:             c.TrustedNetworkList,
+				CustomHelpTemplate: CommandWithoutArgsHelpTemplate,
+			},
+		},
+	}
+}
+
+func (c *cmd) TrustedNetworkAdd(ctx *cli.Context) error {
+	args, err := argsWithTrailingFlags(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	if len(args) != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := args[0]
+
+	resp, err := c.client.AddTrustedNetwork(context.Background(), &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{
+			Name:       name,
+			GatewayIp:  ctx.String(flagTrustedNetworkGatewayIP),
+			GatewayMac: ctx.String(flagTrustedNetworkGatewayMAC),
+			Subnet:     ctx.String(flagTrustedNetworkSubnet),
+			Interface:  ctx.String(flagTrustedNetworkInterface),
+			DhcpDomain: ctx.String(flagTrustedNetworkDHCPDomain),
+			Action:     ctx.String(flagTrustedNetworkAction),
+		},
+		Current: ctx.Bool(flagTrustedNetworkCurrent),
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeFormatError:
+		return formatError(argsParseError(ctx))
+	case internal.CodeConflict:
+		return formatError(fmt.Errorf(MsgTrustedNetworkExists, name))
+	case internal.CodeNoNetwork:
+		return formatError(fmt.Errorf(MsgTrustedNetworkNoNetwork))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgTrustedNetworkAdded, name))
+	}
+	return nil
+}
+
+func (c *cmd) TrustedNetworkRemove(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return formatError(argsCountError(ctx))
+	}
+	name := ctx.Args().First()
+
+	resp, err := c.client.RemoveTrustedNetwork(context.Background(), &pb.RemoveTrustedNetworkRequest{Name: name})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodeTrustedNetworkNonexisting:
+		return formatError(fmt.Errorf(MsgTrustedNetworkNonexisting, name))
+	case internal.CodeSuccess:
+		color.Green(fmt.Sprintf(MsgTrustedNetworkRemoved, name))
+	}
+	return nil
+}
+
+func (c *cmd) TrustedNetworkList(ctx *cli.Context) error {
+	resp, err := c.client.TrustedNetworks(context.Background(), &pb.Empty{})
+	if err != nil {
+		return formatError(err)
+	}
+	if resp.Type != internal.CodeSuccess {
+		return formatError(ErrConfig)
+	}
+
+	if current := resp.GetCurrent(); current != nil {
+		fmt.Println("Current network:")
+		fmt.Printf("  Interface: %s\n", current.Interface)
+		fmt.Printf("  Gateway: %s\n", current.GatewayIp)
+		if current.GatewayMac != "" {
+			fmt.Printf("  Gateway MAC: %s\n", current.GatewayMac)
+		}
+		if len(current.Addresses) > 0 {
+			fmt.Printf("  Addresses: %s\n", strings.Join(current.Addresses, ", "))
+		}
+		if current.DhcpDomain != "" {
+			fmt.Printf("  DHCP domain: %s\n", current.DhcpDomain)
+		}
+	} else {
+		fmt.Println(MsgTrustedNetworkNoNetwork)
+	}
+
+	if len(resp.Networks) == 0 {
+		fmt.Println(MsgTrustedNetworksEmpty)
+		return nil
+	}
+	fmt.Println("Rules:")
+	for _, network := range resp.Networks {
+		rule := config.TrustedNetwork{
+			Name:       network.Name,
+			GatewayIP:  network.GatewayIp,
+			GatewayMAC: network.GatewayMac,
+			Subnet:     network.Subnet,
+			Interface:  network.Interface,
+			DHCPDomain: network.DhcpDomain,
+			Action:     network.Action,
+		}
+		if network.Name == resp.Matched {
+			color.Green("* %s", rule)
+		} else {
+			fmt.Printf("  %s\n", rule)
+		}
+	}
+	return nil
+}
+
+func (c *cmd) TrustedNetworkAutoComplete(ctx *cli.Context) {
+	if ctx.NArg() > 0 {
+		return
+	}
+	resp, err := c.client.TrustedNetworks(context.Background(), &pb.Empty{})
+	if err != nil {
+		return
+	}
+	for _, network := range resp.Networks {
+		fmt.Println(network.Name)
+	}
+}
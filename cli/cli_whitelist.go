@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/urfave/cli/v2"
+)
+
+// whitelistEntryFlags are shared by all whitelist add commands.
+func whitelistEntryFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagWhitelistDirection,
+			Usage: WhitelistFlagDirectionUsage,
+			Value: config.WhitelistDirectionBoth,
+		},
+		&cli.StringFlag{
+			Name:  flagWhitelistInterface,
+			Usage: WhitelistFlagInterfaceUsage,
+		},
+		&cli.StringFlag{
+			Name:  flagWhitelistComment,
+			Usage: WhitelistFlagCommentUsage,
+		},
+		&cli.DurationFlag{
+			Name:  flagWhitelistFor,
+			Usage: WhitelistFlagForUsage,
+		},
+	}
+}
+
+// whitelistRemoveFlags select which entries are affected by port removal.
+func whitelistRemoveFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagWhitelistDirection,
+			Usage: WhitelistFlagDirectionUsage,
+			Value: config.WhitelistDirectionBoth,
+		},
+		&cli.StringFlag{
+			Name:  flagWhitelistInterface,
+			Usage: WhitelistFlagInterfaceUsage,
+		},
+	}
+}
+
+// argAt returns an empty string for missing arguments.
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// whitelistEntry with options given by whitelistEntryFlags.
+func whitelistEntry(ctx *cli.Context) (config.WhitelistEntry, error) {
+	entry := config.WhitelistEntry{
+		Direction: ctx.String(flagWhitelistDirection),
+		Interface: ctx.String(flagWhitelistInterface),
+		Comment:   ctx.String(flagWhitelistComment),
+	}
+	if ctx.IsSet(flagWhitelistFor) {
+		duration := ctx.Duration(flagWhitelistFor)
+		if duration <= 0 {
+			return config.WhitelistEntry{}, argsParseError(ctx)
+		}
+		expiry := time.Now().Add(duration)
+		entry.Expiry = &expiry
+	}
+	return entry, nil
+}
+
+// printWhitelistExpiry informs when a temporary entry is going to be removed.
+func printWhitelistExpiry(entry config.WhitelistEntry) {
+	if entry.IsTemporary() {
+		fmt.Printf(WhitelistExpiryInfo+"\n", entry.Expiry.Local().Format(time.DateTime))
+	}
+}
+
+// whitelistProtocol converts the optional protocol argument. Empty
+// argument means both protocols.
+func whitelistProtocol(arg string) (string, bool) {
+	switch arg {
+	case "":
+		return config.WhitelistProtocolAny, true
+	case config.Protocol_UDP.String():
+		return config.WhitelistProtocolUDP, true
+	case config.Protocol_TCP.String():
+		return config.WhitelistProtocolTCP, true
+	default:
+		return "", false
+	}
+}
+
+// whitelistProtocolLabel is used in the command output.
+func whitelistProtocolLabel(protocol string) string {
+	switch protocol {
+	case config.WhitelistProtocolUDP:
+		return config.Protocol_UDP.String()
+	case config.WhitelistProtocolTCP:
+		return config.Protocol_TCP.String()
+	default:
+		return fmt.Sprintf("%s|%s", config.Protocol_UDP, config.Protocol_TCP)
+	}
+}
+
+// whitelistEntryDetails describes entry options which differ from defaults.
+func whitelistEntryDetails(entry config.WhitelistEntry) string {
+	var details string
+	if entry.Direction != config.WhitelistDirectionBoth {
+		details += " " + entry.Direction
+	}
+	if entry.Interface != "" {
+		details += " on " + entry.Interface
+	}
+	if entry.Comment != "" {
+		details += " - " + entry.Comment
+	}
+	if entry.IsTemporary() {
+		details += " until " + entry.Expiry.Local().Format(time.DateTime)
+	}
+	return details
+}
+
+// whitelistedPorts returns the first port of every whitelisted range.
+func whitelistedPorts(whitelist config.Whitelist) []int64 {
+	var ports []int64
+	for _, entry := range whitelist.Entries {
+		if entry.Ports != nil && (len(ports) == 0 || ports[len(ports)-1] != entry.Ports.Min) {
+			ports = append(ports, entry.Ports.Min)
+		}
+	}
+	return ports
+}
+
+// whitelistedProtocols returns protocol labels for which the port is whitelisted.
+func whitelistedProtocols(whitelist config.Whitelist, port int64) []string {
+	var udp, tcp bool
+	for _, entry := range whitelist.Entries {
+		if entry.Ports == nil || port < entry.Ports.Min || entry.Ports.Max < port {
+			continue
+		}
+		udp = udp || entry.Protocol != config.WhitelistProtocolTCP
+		tcp = tcp || entry.Protocol != config.WhitelistProtocolUDP
+	}
+
+	var protocols []string
+	if udp {
+		protocols = append(protocols, config.Protocol_UDP.String())
+	}
+	if tcp {
+		protocols = append(protocols, config.Protocol_TCP.String())
+	}
+	return protocols
+}
+
+// daemonWhitelist returns the whitelist stored by the daemon. Changes have
+// to start from it, because the client copy does not know about entries
+// removed by the daemon once they expire.
+func (c *cmd) daemonWhitelist() (config.Whitelist, error) {
+	if err := c.syncSettings(); err != nil {
+		return config.Whitelist{}, err
+	}
+	// the expiry job runs once a minute
+	whitelist, _ := c.config.Whitelist.RemoveExpired(time.Now())
+	return whitelist, nil
+}
+
+// setWhitelist replaces the daemon whitelist and stores it in the client
+// config on success. failure is returned when the daemon fails to apply it.
+func (c *cmd) setWhitelist(whitelist config.Whitelist, failure error) error {
+	resp, err := c.client.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
+		Whitelist: whitelistToProtobuf(whitelist),
+	})
+	if err != nil {
+		return formatError(err)
+	}
+
+	switch resp.Type {
+	case internal.CodeConfigError:
+		return formatError(ErrConfig)
+	case internal.CodePolicyEnforced:
+		return formatError(ErrPolicyEnforced)
+	case internal.CodeFailure:
+		return formatError(failure)
+	case internal.CodeSuccess:
+		c.config.Whitelist.Whitelist = whitelist
+		if err := c.configManager.Save(c.config); err != nil {
+			return formatError(ErrConfig)
+		}
+		return nil
+	default:
+		return formatError(internal.ErrUnhandled)
+	}
+}
+
+func whitelistToProtobuf(whitelist config.Whitelist) *pb.Whitelist {
+	entries := make([]*pb.WhitelistEntry, 0, len(whitelist.Entries))
+	for _, entry := range whitelist.Entries {
+		item := &pb.WhitelistEntry{
+			Subnet:    entry.Subnet,
+			Protocol:  entry.Protocol,
+			Direction: entry.Direction,
+			Interface: entry.Interface,
+			Comment:   entry.Comment,
+		}
+		if entry.Ports != nil {
+			item.Ports = entry.Ports.String()
+		}
+		if entry.Expiry != nil {
+			item.Expiry = entry.Expiry.Unix()
+		}
+		entries = append(entries, item)
+	}
+	return &pb.Whitelist{Entries: entries}
+}
+
+// whitelistFromProtobuf is used for displaying entries, so the ones which
+// cannot be parsed are skipped.
+func whitelistFromProtobuf(in *pb.Whitelist) config.Whitelist {
+	var whitelist config.Whitelist
+	for _, item := range in.GetEntries() {
+		entry := config.WhitelistEntry{
+			Subnet:    item.GetSubnet(),
+			Protocol:  item.GetProtocol(),
+			Direction: item.GetDirection(),
+			Interface: item.GetInterface(),
+			Comment:   item.GetComment(),
+		}
+		if item.GetPorts() != "" {
+			ports, err := config.ParsePortRange(item.GetPorts())
+			if err != nil {
+				continue
+			}
+			entry.Ports = ports
+		}
+		if item.GetExpiry() != 0 {
+			expiry := time.Unix(item.GetExpiry(), 0)
+			entry.Expiry = &expiry
+		}
+		whitelist, _ = whitelist.Add(entry)
+	}
+	return whitelist
+}
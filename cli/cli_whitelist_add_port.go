@@ -2,16 +2,12 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
-	"github.com/NordSecurity/nordvpn-linux/internal"
 
-	mapset "github.com/deckarep/golang-set"
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
@@ -29,17 +25,24 @@ Example: 'nordvpn whitelist add port 22'
 Optionally, protocol can be provided to specify which protocol should be whitelisted.
 Supported values for [protocol]: TCP, UDP
 
-Example: 'nordvpn whitelist add port 22 protocol TCP'`
+Example: 'nordvpn whitelist add port 22 protocol TCP'
+
+Optionally, direction, interface, comment and duration after which the port is removed can be provided.
+
+Example: 'nordvpn whitelist add port 22 --direction inbound --interface eth0 --comment ssh'
+Example: 'nordvpn whitelist add port 8080 --for 2h'`
 
 func (c *cmd) WhitelistAddPort(ctx *cli.Context) error {
-	args := ctx.Args()
-	if !(args.Len() == 1 || (args.Len() == 3 && args.Get(1) == WhitelistProtocol)) {
+	args, err := argsWithTrailingFlags(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	if !(len(args) == 1 || (len(args) == 3 && args[1] == WhitelistProtocol)) {
 		return formatError(argsCountError(ctx))
 	}
 
-	portString := args.First()
-	portJSONNumber := json.Number(portString)
-	port, err := strconv.Atoi(args.First())
+	portString := args[0]
+	port, err := strconv.Atoi(portString)
 	if err != nil {
 		return formatError(argsParseError(ctx))
 	}
@@ -48,64 +51,36 @@ func (c *cmd) WhitelistAddPort(ctx *cli.Context) error {
 		return formatError(fmt.Errorf(WhitelistPortRangeError, portString, strconv.Itoa(WhitelistMinPort), strconv.Itoa(WhitelistMaxPort)))
 	}
 
-	var (
-		data    = []interface{}{portString}
-		success bool
-		UDPSet  = mapset.NewSet()
-		TCPSet  = mapset.NewSet()
-	)
-	if args.Len() == 1 {
-		success = UDPSet.Add(portJSONNumber) || success
-		success = TCPSet.Add(portJSONNumber) || success
-		data = append(data, fmt.Sprintf("%s|%s", config.Protocol_UDP, config.Protocol_TCP))
-	} else {
-		switch args.Get(2) {
-		case config.Protocol_UDP.String():
-			success = UDPSet.Add(portJSONNumber) || success
-			data = append(data, config.Protocol_UDP)
-		case config.Protocol_TCP.String():
-			success = TCPSet.Add(portJSONNumber) || success
-			data = append(data, config.Protocol_TCP)
-		default:
-			return formatError(argsParseError(ctx))
-		}
+	protocol, ok := whitelistProtocol(argAt(args, 2))
+	if !ok {
+		return formatError(argsParseError(ctx))
 	}
 
-	if !success {
-		return formatError(fmt.Errorf(WhitelistAddPortExistsError, data...))
+	entry, err := whitelistEntry(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	entry.Ports = config.NewPortRange(int64(port), int64(port))
+	entry.Protocol = protocol
+	if err := entry.Normalized().Validate(); err != nil {
+		return formatError(argsParseError(ctx))
 	}
 
-	UDPSet = c.config.Whitelist.Ports.UDP.Union(UDPSet)
-	TCPSet = c.config.Whitelist.Ports.TCP.Union(TCPSet)
-	resp, err := c.client.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(UDPSet),
-				Tcp: client.SetToInt64s(TCPSet),
-			},
-			Subnets: internal.SetToStrings(c.config.Whitelist.Subnets),
-		},
-	})
+	data := []interface{}{portString, whitelistProtocolLabel(protocol)}
+	current, err := c.daemonWhitelist()
 	if err != nil {
 		return formatError(err)
 	}
-
-	switch resp.Type {
-	case internal.CodeConfigError:
-		return formatError(ErrConfig)
-	case internal.CodeFailure:
+	whitelist, ok := current.Add(entry)
+	if !ok {
 		return formatError(fmt.Errorf(WhitelistAddPortExistsError, data...))
-	case internal.CodeVPNMisconfig:
-		return formatError(internal.ErrUnhandled)
-	case internal.CodeSuccess:
-		c.config.Whitelist.Ports.UDP = UDPSet
-		c.config.Whitelist.Ports.TCP = TCPSet
-		err = c.configManager.Save(c.config)
-		if err != nil {
-			return formatError(ErrConfig)
-		}
-		color.Green(fmt.Sprintf(WhitelistAddPortSuccess, data...))
 	}
+
+	if err := c.setWhitelist(whitelist, fmt.Errorf(WhitelistAddPortExistsError, data...)); err != nil {
+		return err
+	}
+	color.Green(fmt.Sprintf(WhitelistAddPortSuccess, data...))
+	printWhitelistExpiry(entry)
 	return nil
 }
 
@@ -2,16 +2,12 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
-	"github.com/NordSecurity/nordvpn-linux/internal"
 
-	mapset "github.com/deckarep/golang-set"
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
@@ -29,20 +25,28 @@ Example: 'nordvpn whitelist add ports 3000 8000'
 Optionally, protocol can be provided to specify which protocol should be whitelisted.
 Supported values for [protocol]: TCP, UDP
 
-Example: 'nordvpn whitelist add ports 3000 8000 protocol TCP'`
+Example: 'nordvpn whitelist add ports 3000 8000 protocol TCP'
+
+Optionally, direction, interface, comment and duration after which the ports are removed can be provided.
+
+Example: 'nordvpn whitelist add ports 3000 8000 --direction outbound --comment "dev server"'
+Example: 'nordvpn whitelist add ports 3000 8000 protocol TCP --for 30m'`
 
 func (c *cmd) WhitelistAddPorts(ctx *cli.Context) error {
-	args := ctx.Args()
-	if !(args.Len() == 2 || (args.Len() == 4 && args.Get(2) == WhitelistProtocol)) {
+	args, err := argsWithTrailingFlags(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	if !(len(args) == 2 || (len(args) == 4 && args[2] == WhitelistProtocol)) {
 		return formatError(argsCountError(ctx))
 	}
 
-	startPort, err := strconv.Atoi(args.First())
+	startPort, err := strconv.Atoi(args[0])
 	if err != nil {
 		return formatError(argsParseError(ctx))
 	}
 
-	endPort, err := strconv.Atoi(args.Get(1))
+	endPort, err := strconv.Atoi(args[1])
 	if err != nil {
 		return formatError(argsParseError(ctx))
 	}
@@ -52,76 +56,39 @@ func (c *cmd) WhitelistAddPorts(ctx *cli.Context) error {
 	}
 
 	if !(WhitelistMinPort <= startPort && startPort <= WhitelistMaxPort && WhitelistMinPort <= endPort && endPort <= WhitelistMaxPort) {
-		return formatError(fmt.Errorf(WhitelistPortsRangeError, args.First(), args.Get(1), strconv.Itoa(WhitelistMinPort), strconv.Itoa(WhitelistMaxPort)))
+		return formatError(fmt.Errorf(WhitelistPortsRangeError, args[0], args[1], strconv.Itoa(WhitelistMinPort), strconv.Itoa(WhitelistMaxPort)))
 	}
 
-	var (
-		data    = []interface{}{args.First(), args.Get(1)}
-		success bool
-		UDPSet  = mapset.NewSet()
-		TCPSet  = mapset.NewSet()
-	)
-	if args.Len() == 2 {
-		for port := startPort; port <= endPort; port++ {
-			portJSONNumber := json.Number(strconv.Itoa(port))
-			success = UDPSet.Add(portJSONNumber) || success
-			success = TCPSet.Add(portJSONNumber) || success
-		}
-		data = append(data, fmt.Sprintf("%s|%s", config.Protocol_UDP.String(), config.Protocol_TCP.String()))
-	} else {
-		var set = mapset.NewSet()
-		switch args.Get(3) {
-		case config.Protocol_UDP.String():
-			set = UDPSet
-			data = append(data, config.Protocol_UDP.String())
-		case config.Protocol_TCP.String():
-			set = TCPSet
-			data = append(data, config.Protocol_TCP.String())
-		default:
-			return formatError(argsParseError(ctx))
-		}
-
-		for port := startPort; port <= endPort; port++ {
-			portJSONNumber := json.Number(strconv.Itoa(port))
-			success = set.Add(portJSONNumber) || success
-		}
+	protocol, ok := whitelistProtocol(argAt(args, 3))
+	if !ok {
+		return formatError(argsParseError(ctx))
 	}
 
-	if !success {
-		return formatError(fmt.Errorf(WhitelistAddPortsExistsError, data...))
+	entry, err := whitelistEntry(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	entry.Ports = config.NewPortRange(int64(startPort), int64(endPort))
+	entry.Protocol = protocol
+	if err := entry.Normalized().Validate(); err != nil {
+		return formatError(argsParseError(ctx))
 	}
 
-	UDPSet = c.config.Whitelist.Ports.UDP.Union(UDPSet)
-	TCPSet = c.config.Whitelist.Ports.TCP.Union(TCPSet)
-	resp, err := c.client.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(UDPSet),
-				Tcp: client.SetToInt64s(TCPSet),
-			},
-			Subnets: internal.SetToStrings(c.config.Whitelist.Subnets),
-		},
-	})
+	data := []interface{}{args[0], args[1], whitelistProtocolLabel(protocol)}
+	current, err := c.daemonWhitelist()
 	if err != nil {
 		return formatError(err)
 	}
-
-	switch resp.Type {
-	case internal.CodeConfigError:
-		return formatError(ErrConfig)
-	case internal.CodeFailure:
+	whitelist, ok := current.Add(entry)
+	if !ok {
 		return formatError(fmt.Errorf(WhitelistAddPortsExistsError, data...))
-	case internal.CodeVPNMisconfig:
-		return formatError(internal.ErrUnhandled)
-	case internal.CodeSuccess:
-		c.config.Whitelist.Ports.UDP = UDPSet
-		c.config.Whitelist.Ports.TCP = TCPSet
-		err = c.configManager.Save(c.config)
-		if err != nil {
-			return formatError(ErrConfig)
-		}
-		color.Green(fmt.Sprintf(WhitelistAddPortsSuccess, data...))
 	}
+
+	if err := c.setWhitelist(whitelist, fmt.Errorf(WhitelistAddPortsExistsError, data...)); err != nil {
+		return err
+	}
+	color.Green(fmt.Sprintf(WhitelistAddPortsSuccess, data...))
+	printWhitelistExpiry(entry)
 	return nil
 }
 
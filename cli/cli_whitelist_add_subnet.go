@@ -1,15 +1,9 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 	"net"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
-	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
-	"github.com/NordSecurity/nordvpn-linux/internal"
-
-	mapset "github.com/deckarep/golang-set"
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
@@ -24,55 +18,52 @@ Use this command to whitelist subnet.
 
 Example: 'nordvpn whitelist add subnet 192.168.1.1/24'
 
+Optionally, direction, interface, comment and duration after which the subnet is removed can be provided.
+
+Example: 'nordvpn whitelist add subnet 192.168.1.1/24 --direction inbound --interface eth0'
+Example: 'nordvpn whitelist add subnet 192.168.1.1/24 --for 1h'
+
 Notes:
   Address should be in CIDR notation`
 
 func (c *cmd) WhitelistAddSubnet(ctx *cli.Context) error {
-	args := ctx.Args()
+	args, err := argsWithTrailingFlags(ctx)
+	if err != nil {
+		return formatError(err)
+	}
 
-	if args.Len() != 1 {
+	if len(args) != 1 {
 		return formatError(argsCountError(ctx))
 	}
 
-	_, subnet, err := net.ParseCIDR(args.First())
+	_, subnet, err := net.ParseCIDR(args[0])
 	if err != nil {
 		return formatError(argsParseError(ctx))
 	}
 
-	var subnets = mapset.NewSet()
-	if !subnets.Add(subnet.String()) {
-		return formatError(fmt.Errorf(WhitelistAddSubnetExistsError, subnet.String()))
+	entry, err := whitelistEntry(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+	entry.Subnet = subnet.String()
+	if err := entry.Normalized().Validate(); err != nil {
+		return formatError(argsParseError(ctx))
 	}
 
-	subnets = c.config.Whitelist.Subnets.Union(subnets)
-	resp, err := c.client.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(c.config.Whitelist.Ports.UDP),
-				Tcp: client.SetToInt64s(c.config.Whitelist.Ports.TCP),
-			},
-			Subnets: internal.SetToStrings(subnets),
-		},
-	})
+	current, err := c.daemonWhitelist()
 	if err != nil {
 		return formatError(err)
 	}
-
-	switch resp.Type {
-	case internal.CodeConfigError:
-		return formatError(ErrConfig)
-	case internal.CodeFailure:
+	whitelist, ok := current.Add(entry)
+	if !ok {
 		return formatError(fmt.Errorf(WhitelistAddSubnetExistsError, subnet))
-	case internal.CodeVPNMisconfig:
-		return formatError(internal.ErrUnhandled)
-	case internal.CodeSuccess:
-		c.config.Whitelist.Subnets = subnets
-		err = c.configManager.Save(c.config)
-		if err != nil {
-			return formatError(ErrConfig)
-		}
-		color.Green(fmt.Sprintf(WhitelistAddSubnetSuccess, subnet))
 	}
+
+	if err := c.setWhitelist(whitelist, fmt.Errorf(WhitelistAddSubnetExistsError, subnet)); err != nil {
+		return err
+	}
+	color.Green(fmt.Sprintf(WhitelistAddSubnetSuccess, subnet))
+	printWhitelistExpiry(entry)
 	return nil
 }
 
@@ -1,17 +1,11 @@
 package cli
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
-	"github.com/NordSecurity/nordvpn-linux/client"
 	"github.com/NordSecurity/nordvpn-linux/config"
-	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
-	"github.com/NordSecurity/nordvpn-linux/internal"
 
-	mapset "github.com/deckarep/golang-set"
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
@@ -29,7 +23,11 @@ Example: 'nordvpn whitelist remove port 22'
 Optionally, protocol can be provided to specify which protocol should be removed from whitelist.
 Supported values for [protocol]: TCP, UDP
 
-Example: 'nordvpn whitelist remove port 22 protocol TCP'`
+Example: 'nordvpn whitelist remove port 22 protocol TCP'
+
+Optionally, direction and interface can be provided to remove the port only from matching entries.
+
+Example: 'nordvpn whitelist remove port 22 --direction inbound --interface eth0'`
 
 func (c *cmd) WhitelistRemovePort(ctx *cli.Context) error {
 	args := ctx.Args()
@@ -39,7 +37,6 @@ func (c *cmd) WhitelistRemovePort(ctx *cli.Context) error {
 	}
 
 	portString := args.First()
-	portJSONNumber := json.Number(portString)
 	port, err := strconv.Atoi(portString)
 	if err != nil {
 		return formatError(argsParseError(ctx))
@@ -49,84 +46,53 @@ func (c *cmd) WhitelistRemovePort(ctx *cli.Context) error {
 		return formatError(fmt.Errorf(WhitelistPortRangeError, portString, strconv.Itoa(WhitelistMinPort), strconv.Itoa(WhitelistMaxPort)))
 	}
 
-	var (
-		data    = []interface{}{portString}
-		success bool
-		UDPSet  = mapset.NewSetFromSlice(c.config.Whitelist.Ports.UDP.ToSlice())
-		TCPSet  = mapset.NewSetFromSlice(c.config.Whitelist.Ports.TCP.ToSlice())
-	)
-	if args.Len() == 1 {
-		success = UDPSet.Contains(portJSONNumber) || success
-		success = TCPSet.Contains(portJSONNumber) || success
-		UDPSet.Remove(portJSONNumber)
-		TCPSet.Remove(portJSONNumber)
-		data = append(data, fmt.Sprintf("%s|%s", config.Protocol_UDP.String(), config.Protocol_TCP.String()))
-	} else {
-		switch args.Get(2) {
-		case config.Protocol_UDP.String():
-			success = UDPSet.Contains(portJSONNumber) || success
-			UDPSet.Remove(portJSONNumber)
-			data = append(data, config.Protocol_UDP.String())
-		case config.Protocol_TCP.String():
-			success = TCPSet.Contains(portJSONNumber) || success
-			TCPSet.Remove(portJSONNumber)
-			data = append(data, config.Protocol_TCP.String())
-		default:
-			return formatError(argsParseError(ctx))
-		}
+	protocol, ok := whitelistProtocol(args.Get(2))
+	if !ok {
+		return formatError(argsParseError(ctx))
 	}
 
-	if !success {
-		return formatError(fmt.Errorf(WhitelistRemovePortExistsError, data...))
+	removal := config.WhitelistEntry{
+		Ports:     config.NewPortRange(int64(port), int64(port)),
+		Protocol:  protocol,
+		Direction: ctx.String(flagWhitelistDirection),
+		Interface: ctx.String(flagWhitelistInterface),
+	}
+	if err := removal.Normalized().Validate(); err != nil {
+		return formatError(argsParseError(ctx))
 	}
 
-	resp, err := c.client.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
-		Whitelist: &pb.Whitelist{
-			Ports: &pb.Ports{
-				Udp: client.SetToInt64s(UDPSet),
-				Tcp: client.SetToInt64s(TCPSet),
-			},
-			Subnets: internal.SetToStrings(c.config.Whitelist.Subnets),
-		},
-	})
+	data := []interface{}{portString, whitelistProtocolLabel(protocol)}
+	current, err := c.daemonWhitelist()
 	if err != nil {
 		return formatError(err)
 	}
-
-	switch resp.Type {
-	case internal.CodeConfigError:
-		return formatError(ErrConfig)
-	case internal.CodeFailure:
+	whitelist, ok := current.RemovePorts(removal)
+	if !ok {
 		return formatError(fmt.Errorf(WhitelistRemovePortExistsError, data...))
-	case internal.CodeVPNMisconfig:
-		return formatError(internal.ErrUnhandled)
-	case internal.CodeSuccess:
-		c.config.Whitelist.Ports.UDP = UDPSet
-		c.config.Whitelist.Ports.TCP = TCPSet
-		err = c.configManager.Save(c.config)
-		if err != nil {
-			return formatError(ErrConfig)
-		}
-		color.Green(fmt.Sprintf(WhitelistRemovePortSuccess, data...))
 	}
+
+	if err := c.setWhitelist(whitelist, fmt.Errorf(WhitelistRemovePortExistsError, data...)); err != nil {
+		return err
+	}
+	color.Green(fmt.Sprintf(WhitelistRemovePortSuccess, data...))
 	return nil
 }
 
 func (c *cmd) WhitelistRemovePortAutoComplete(ctx *cli.Context) {
 	switch ctx.NArg() {
 	case 0:
-		// create config after auth
-		for port := range c.config.Whitelist.Ports.UDP.Union(c.config.Whitelist.Ports.TCP).Iter() {
+		for _, port := range whitelistedPorts(c.config.Whitelist.Whitelist) {
 			fmt.Println(port)
 		}
 	case 1:
 		fmt.Println(stringProtocol)
 	case 2:
-		if c.config.Whitelist.Ports.UDP.Contains(json.Number(ctx.Args().First())) {
-			fmt.Println(config.Protocol_UDP.String())
+		port, err := strconv.ParseInt(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return
 		}
-		if c.config.Whitelist.Ports.TCP.Contains(json.Number(ctx.Args().First())) {
-			fmt.Println(config.Protocol_TCP.String())
+		for _, protocol := range whitelistedProtocols(c.config.Whitelist.Whitelist, port) {
+			fmt.Println(protocol)
 		}
 	default:
 		return
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestWhitelistArgs(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name      string
+		input     []string
+		args      []string
+		direction string
+		comment   string
+		duration  time.Duration
+		hasErr    bool
+	}{
+		{
+			name:      "no flags",
+			input:     []string{"22", "protocol", "TCP"},
+			args:      []string{"22", "protocol", "TCP"},
+			direction: "both",
+		},
+		{
+			name:      "flags before arguments",
+			input:     []string{"--direction", "inbound", "22"},
+			args:      []string{"22"},
+			direction: "inbound",
+		},
+		{
+			name:      "flags after arguments",
+			input:     []string{"8080", "--for", "2h", "--comment=debugging"},
+			args:      []string{"8080"},
+			direction: "both",
+			comment:   "debugging",
+			duration:  2 * time.Hour,
+		},
+		{
+			name:   "missing flag value",
+			input:  []string{"8080", "--for"},
+			hasErr: true,
+		},
+		{
+			name:   "unknown flag",
+			input:  []string{"8080", "--forever"},
+			hasErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := cli.NewApp()
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			for _, f := range whitelistEntryFlags() {
+				require.NoError(t, f.Apply(set))
+			}
+			require.NoError(t, set.Parse(test.input))
+			ctx := cli.NewContext(app, set, &cli.Context{Context: context.Background()})
+			ctx.Command = &cli.Command{Name: "port"}
+
+			args, err := argsWithTrailingFlags(ctx)
+			if test.hasErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.args, args)
+
+			entry, err := whitelistEntry(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, test.direction, entry.Direction)
+			assert.Equal(t, test.comment, entry.Comment)
+			if test.duration == 0 {
+				assert.False(t, entry.IsTemporary())
+			} else {
+				require.True(t, entry.IsTemporary())
+				assert.WithinDuration(t, time.Now().Add(test.duration), *entry.Expiry, time.Minute)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ const (
 
 const (
 	flagGroup         = "group"
+	flagProfile       = "profile"
 	flagUsername      = "username"
 	flagPassword      = "password"
 	flagLegacy        = "legacy"
@@ -28,3 +29,12 @@ const (
 	flagLoginCallback = "callback"
 	stringProtocol    = "protocol"
 )
+
+const (
+	flagWhitelistDirection = "direction"
+	flagWhitelistInterface = "interface"
+	flagWhitelistComment   = "comment"
+	flagWhitelistFor       = "for"
+)
+
+const flagKillSwitchPersistent = "persistent"
@@ -21,6 +21,82 @@ const (
 	SetReconnect = "You are connected to NordVPN. Please reconnect to enable the setting."
 
 	MsgNothingToRate = "There was no connection - nothing to rate."
+	// MsgServersHistoryEmpty is shown when no connections were made yet.
+	MsgServersHistoryEmpty = "There are no past connections yet."
+	// MsgPolicyEnforced is shown when a setting is locked by the administrator policy.
+	MsgPolicyEnforced = "This setting is managed by your administrator and cannot be changed."
+	// MsgPolicyEnforcedSettings is shown when imported settings are locked by the administrator policy.
+	MsgPolicyEnforcedSettings = "The following settings are managed by your administrator and cannot be changed: %s"
+	// SettingEnforcedLabel marks settings locked by the administrator policy.
+	SettingEnforcedLabel = "(enforced by administrator)"
+	// MsgProfileCreated is shown after a profile is created.
+	MsgProfileCreated = "Profile '%s' has been created."
+	// MsgProfileExists is shown when a profile with the same name already exists.
+	MsgProfileExists = "Profile '%s' already exists."
+	// MsgProfileUsed is shown after a profile is applied.
+	MsgProfileUsed = "Profile '%s' is used now."
+	// MsgProfileAlreadyUsed is shown when settings already match the profile.
+	MsgProfileAlreadyUsed = "Profile '%s' is already used."
+	// MsgProfileCannotBeUsed is shown when profile settings cannot be applied.
+	MsgProfileCannotBeUsed = "Profile '%s' cannot be used: %s"
+	// MsgProfileNonexisting is shown when a profile does not exist.
+	MsgProfileNonexisting = "Profile '%s' does not exist."
+	// MsgProfileDeleted is shown after a profile is deleted.
+	MsgProfileDeleted = "Profile '%s' has been deleted."
+	// MsgProfilesEmpty is shown when no profiles were created yet.
+	MsgProfilesEmpty = "There are no profiles yet. Create one with 'nordvpn profile create <name>'."
+	// MsgKillSwitchPersistent is shown after the persistent mode is enabled.
+	MsgKillSwitchPersistent = "Traffic will be blocked from the system boot, even if the daemon is not running. Use 'nordvpn killswitch release' to unblock it."
+	// MsgKillSwitchReleased is shown after the kill switch is released.
+	MsgKillSwitchReleased = "Kill Switch has been released. Traffic is no longer blocked."
+	// MsgKillSwitchNotEnabled is shown when there is nothing to release.
+	MsgKillSwitchNotEnabled = "Kill Switch is not enabled."
+	// MsgKillSwitchReleaseRequiresRoot is shown when the daemon is not running and the CLI lacks privileges.
+	MsgKillSwitchReleaseRequiresRoot = "NordVPN daemon is not running. Run 'sudo nordvpn killswitch release' to remove the persistent Kill Switch rules."
+	// MsgIsolationTechnology is shown when isolation is enabled with OpenVPN.
+	MsgIsolationTechnology = "Isolation is available only with NordLynx technology. Run 'nordvpn set technology nordlynx' first."
+	// MsgIsolationMeshnet is shown when isolation is enabled together with meshnet.
+	MsgIsolationMeshnet = "Isolation cannot be used together with Meshnet. Disable Meshnet first."
+	// MsgTechnologyIsolation is shown when technology is changed from NordLynx while isolation is enabled.
+	MsgTechnologyIsolation = "Isolation is available only with NordLynx technology. Run 'nordvpn set isolation off' first."
+	// MsgMeshnetIsolation is shown when meshnet is enabled while isolation is enabled.
+	MsgMeshnetIsolation = "Meshnet cannot be used together with isolation. Run 'nordvpn set isolation off' first."
+	// MsgExecRequiresRoot is shown when exec is run without privileges.
+	MsgExecRequiresRoot = "Entering the isolated network namespace requires root privileges. Run the command with sudo."
+	// MsgExecNotIsolated is shown when exec is run while there is no isolated connection.
+	MsgExecNotIsolated = "There is no isolated VPN connection. Run 'nordvpn set isolation on' and connect to NordVPN first."
+	// MsgTrustedNetworkAdded is shown after a trusted network rule is added.
+	MsgTrustedNetworkAdded = "Trusted network '%s' has been added. It will be applied the next time the network changes."
+	// MsgTrustedNetworkExists is shown when a rule with the same name already exists.
+	MsgTrustedNetworkExists = "Trusted network '%s' already exists."
+	// MsgTrustedNetworkNonexisting is shown when a rule does not exist.
+	MsgTrustedNetworkNonexisting = "Trusted network '%s' does not exist."
+	// MsgTrustedNetworkRemoved is shown after a rule is removed.
+	MsgTrustedNetworkRemoved = "Trusted network '%s' has been removed."
+	// MsgTrustedNetworkNoNetwork is shown when the current network cannot be identified.
+	MsgTrustedNetworkNoNetwork = "This device is not connected to any network."
+	// MsgTrustedNetworksEmpty is shown when no rules were added yet.
+	MsgTrustedNetworksEmpty = "There are no trusted networks yet. Add one with 'nordvpn trusted-network add <name>'."
+	// MsgSettingsHistoryEmpty is shown when no settings changes were recorded.
+	MsgSettingsHistoryEmpty = "No settings changes were recorded yet."
+	// MsgUserSettingsEmpty is shown when the user follows all global settings.
+	MsgUserSettingsEmpty = "You are using global settings. Override them with 'nordvpn settings user set <setting> <value>'."
+	// MsgUserSettingSet is shown after a setting is overridden for the user.
+	MsgUserSettingSet = "Your %s setting is set to '%s'. It will be used the next time you connect."
+	// MsgUserSettingUnset is shown after the user goes back to a global setting.
+	MsgUserSettingUnset = "Your %s setting follows the global setting now."
+	// MsgUserSettingAlreadySet is shown when the user setting is not changed.
+	MsgUserSettingAlreadySet = "Your %s setting is already up to date."
+	// MsgUserSettingGlobal is shown when a setting cannot be overridden per user.
+	MsgUserSettingGlobal = "Setting '%s' can be changed only globally. Per user settings are: %s."
+	// MsgSettingsImported is shown after settings import.
+	MsgSettingsImported = "Settings have been imported successfully."
+	// MsgSettingsImportDryRun is shown before the changes which would be applied.
+	MsgSettingsImportDryRun = "The following settings would be changed:"
+	// MsgSettingsImportNothingToDo is shown when imported settings match the current ones.
+	MsgSettingsImportNothingToDo = "Settings are already up to date."
+	// MsgSettingsRollbackFailed is shown when settings failed to apply and could not be reverted.
+	MsgSettingsRollbackFailed = "Settings could not be applied and the previous values of the following ones could not be restored: %s"
 	// MsgSetSuccess is a generic success message template.
 	MsgSetSuccess = "%s is set to '%s' successfully."
 	// MsgAlreadySet is a generic noop message template.
@@ -80,6 +156,12 @@ Example: nordvpn set %s on`
 	WhitelistRemoveAllError   = "Whitelist elements could not be removed."
 	WhitelistRemoveAllSuccess = "All ports and subnets have been removed from the whitelist successfully."
 
+	WhitelistFlagDirectionUsage = "Limits the entry to inbound or outbound traffic. Supported values: inbound, outbound, both"
+	WhitelistFlagInterfaceUsage = "Limits the entry to a single network interface"
+	WhitelistFlagCommentUsage   = "Describes why the entry is whitelisted"
+	WhitelistFlagForUsage       = "Removes the entry from the whitelist automatically after the given duration, e.g. 30m or 2h"
+	WhitelistExpiryInfo         = "It will be removed from the whitelist at %s."
+
 	WhitelistPortRangeError  = "Port %s value is out of range [%s - %s]."
 	WhitelistPortsRangeError = "Ports %s - %s value is out of range [%s - %s]."
 
@@ -9,8 +9,6 @@ import (
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/internal"
-
-	mapset "github.com/deckarep/golang-set"
 )
 
 // Manager is responsible for loading and saving configurations
@@ -166,15 +164,6 @@ func (m EncryptedManager) Save(c Config) error {
 
 // setDefaultsIfEmpty sets default values
 func (c *Config) setDefaultsIfEmpty() *Config {
-	if c.Whitelist.Subnets == nil {
-		c.Whitelist.Subnets = mapset.NewSet()
-	}
-	if c.Whitelist.Ports.UDP == nil {
-		c.Whitelist.Ports.UDP = mapset.NewSet()
-	}
-	if c.Whitelist.Ports.TCP == nil {
-		c.Whitelist.Ports.TCP = mapset.NewSet()
-	}
 	if c.Protocol == config.Protocol_UNKNOWN_PROTOCOL {
 		c.Protocol = config.Protocol_UDP
 	}
@@ -1,62 +1,38 @@
 package config
 
 import (
-	"bytes"
 	"encoding/json"
 
-	mapset "github.com/deckarep/golang-set"
+	"github.com/NordSecurity/nordvpn-linux/config"
 )
 
+// Whitelist is a client side copy of the daemon whitelist.
 type Whitelist struct {
-	Ports   Ports      `json:"ports"`
-	Subnets mapset.Set `json:"subnets"`
-}
-
-type Ports struct {
-	UDP mapset.Set `json:"udp"`
-	TCP mapset.Set `json:"tcp"`
+	config.Whitelist
 }
 
+// UnmarshalJSON also accepts the legacy format which listed single ports
+// per protocol.
 func (w *Whitelist) UnmarshalJSON(b []byte) error {
 	var i struct {
-		Ports   Ports
-		Subnets []interface{}
+		Entries []config.WhitelistEntry `json:"entries"`
+		Ports   struct {
+			UDP []int64 `json:"udp"`
+			TCP []int64 `json:"tcp"`
+		} `json:"ports"`
+		Subnets []string `json:"subnets"`
 	}
-
-	d := json.NewDecoder(bytes.NewReader(b))
-	d.UseNumber()
-	err := d.Decode(&i)
-	if err != nil {
+	if err := json.Unmarshal(b, &i); err != nil {
 		return err
 	}
 
-	w.Ports = i.Ports
-	w.Subnets = mapset.NewSetFromSlice(i.Subnets)
-
-	if w.Ports.UDP == nil {
-		w.Ports.UDP = mapset.NewSet()
+	if i.Entries != nil {
+		w.Whitelist = config.Whitelist{}
+		for _, entry := range i.Entries {
+			w.Whitelist, _ = w.Whitelist.Add(entry)
+		}
+		return nil
 	}
-	if w.Ports.TCP == nil {
-		w.Ports.TCP = mapset.NewSet()
-	}
-
-	return nil
-}
-
-func (p *Ports) UnmarshalJSON(b []byte) error {
-	var i struct {
-		UDP []interface{}
-		TCP []interface{}
-	}
-
-	d := json.NewDecoder(bytes.NewReader(b))
-	d.UseNumber()
-	err := d.Decode(&i)
-	if err != nil {
-		return err
-	}
-
-	p.UDP = mapset.NewSetFromSlice(i.UDP)
-	p.TCP = mapset.NewSetFromSlice(i.TCP)
+	w.Whitelist = config.NewWhitelist(i.Ports.UDP, i.Ports.TCP, i.Subnets)
 	return nil
 }
@@ -34,7 +34,7 @@ func (*dummyAnalytics) NotifyRequestAPI(events.DataRequestAPI) error   { return
 func (*dummyAnalytics) NotifyDomain(string) error                      { return nil }
 func (*dummyAnalytics) NotifyRate(events.ServerRating) error           { return nil }
 
-func newAnalytics(eventsDbPath string, fs *config.Filesystem,
+func newAnalytics(eventsDbPath string, fs config.Manager,
 	version, salt, env string) *dummyAnalytics {
 	return &dummyAnalytics{}
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/events/moose"
 )
 
-func newAnalytics(eventsDbPath string, fs *config.Filesystem,
+func newAnalytics(eventsDbPath string, fs config.Manager,
 	ver, salt, env string) *moose.Subscriber {
 	return &moose.Subscriber{
 		EventsDbPath:  eventsDbPath,
@@ -23,12 +23,15 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/daemon/firewall"
 	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/iptables"
 	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/notables"
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/persistent"
+	"github.com/NordSecurity/nordvpn-linux/daemon/isolation"
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/daemon/netstate"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
 	"github.com/NordSecurity/nordvpn-linux/daemon/response"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
-	"github.com/NordSecurity/nordvpn-linux/daemon/routes/iprouter"
-	"github.com/NordSecurity/nordvpn-linux/daemon/routes/iprule"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/netlinkrouter"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/netlinkrule"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes/norouter"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes/norule"
 	"github.com/NordSecurity/nordvpn-linux/daemon/vpn/nordlynx"
@@ -41,6 +44,7 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/events/subs"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 	"github.com/NordSecurity/nordvpn-linux/ipv6"
+	"github.com/NordSecurity/nordvpn-linux/kernel"
 	"github.com/NordSecurity/nordvpn-linux/meshnet"
 	"github.com/NordSecurity/nordvpn-linux/meshnet/exitnode"
 	"github.com/NordSecurity/nordvpn-linux/meshnet/fork"
@@ -52,6 +56,8 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/request"
 	"github.com/NordSecurity/nordvpn-linux/request/rotator"
 
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
 	"google.golang.org/grpc"
 )
 
@@ -73,6 +79,8 @@ var (
 const (
 	EnvKeyPath = "PATH"
 	EnvValPath = ":/bin:/sbin:/usr/bin:/usr/sbin"
+	// EnvConfigBackend selects config storage: encrypted (default) or plaintext
+	EnvConfigBackend = "NORDVPN_CONFIG_BACKEND"
 )
 
 func init() {
@@ -110,11 +118,19 @@ func main() {
 
 	// Config
 
-	fsystem := config.NewFilesystem(
+	encrypted := config.NewFilesystem(
 		config.SettingsDataFilePath,
 		config.InstallFilePath,
 		Salt,
 	)
+	backend, err := config.NewBackend(os.Getenv(EnvConfigBackend), encrypted)
+	if err != nil {
+		log.Println(internal.WarningPrefix, "config backend:", err, "- using", config.BackendEncrypted, "backend")
+		backend = encrypted
+	}
+	// shared with the RPC, so that reads are serialized with writes and rotation
+	auditLog := config.NewAuditLog(config.AuditFilePath)
+	fsystem := config.NewAuditedManager(backend, auditLog)
 	var cfg config.Config
 	if err := fsystem.Load(&cfg); err != nil {
 		log.Println(err)
@@ -122,6 +138,11 @@ func main() {
 			log.Fatalln(err)
 		}
 	}
+	if err := daemon.EnforcePolicy(fsystem, config.PolicyFilePath); err != nil {
+		log.Println(internal.ErrorPrefix, "enforcing policy:", err)
+	} else if err := fsystem.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
 
 	// Events
 
@@ -171,11 +192,12 @@ func main() {
 	stateModule := "conntrack"
 	stateFlag := "--ctstate"
 	chainPrefix := ""
+	supportedIPTables := iptables.FilterSupportedIPTables(internal.GetSupportedIPTables())
 	iptablesAgent := iptables.New(
 		stateModule,
 		stateFlag,
 		chainPrefix,
-		iptables.FilterSupportedIPTables(internal.GetSupportedIPTables()),
+		supportedIPTables,
 	)
 	fw := firewall.NewFirewall(
 		&notables.Facade{},
@@ -250,8 +272,9 @@ func main() {
 	// Networker
 
 	gwret := routes.IPGatewayRetriever{}
-	dnsSetter := dns.NewSetter(infoSubject)
-	dnsHostSetter := dns.NewHostsFileSetter(dns.HostsFilePath)
+	changes := journal.New(journal.FilePath)
+	dnsSetter := dns.NewSetter(infoSubject, changes)
+	dnsHostSetter := dns.NewHostsFileSetter(dns.HostsFilePath, changes)
 
 	versionGetter := versionGetterImplementation()
 
@@ -330,19 +353,32 @@ func main() {
 		log.Fatalln(err)
 	}
 
+	nlHandle, err := netlink.NewHandle()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	hostNs, err := netns.Get()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	isolator, err := isolation.New(hostNs, changes)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	whitelistRouter := routes.NewRouter(
 		&norouter.Facade{},
-		&iprouter.Router{},
+		netlinkrouter.NewRouter(nlHandle, changes),
 		cfg.Routing.Get(),
 	)
 	vpnRouter := routes.NewRouter(
 		&norouter.Facade{},
-		&iprouter.Router{},
+		netlinkrouter.NewRouter(nlHandle, changes),
 		cfg.Routing.Get(),
 	)
 	meshRouter := routes.NewRouter(
 		&norouter.Facade{},
-		&iprouter.Router{},
+		netlinkrouter.NewRouter(nlHandle, changes),
 		cfg.Routing.Get(),
 	)
 
@@ -351,25 +387,43 @@ func main() {
 		mesh,
 		gwret,
 		infoSubject,
+		daemonEvents.Service.Disconnect,
 		whitelistRouter,
 		dnsSetter,
-		ipv6.NewIpv6(),
+		ipv6.NewIpv6(changes),
 		fw,
 		device.ListPhysical,
 		routes.NewPolicyRouter(
 			&norule.Facade{},
-			iprule.NewRouter(
-				routes.NewSysctlRPFilterManager(),
+			netlinkrule.NewRouter(
+				nlHandle,
+				routes.NewSysctlRPFilterManager(changes),
 				cfg.FirewallMark,
+				changes,
 			),
 			cfg.Routing.Get(),
 		),
 		dnsHostSetter,
 		vpnRouter,
 		meshRouter,
-		exitnode.NewServer(ifaceNames),
+		exitnode.NewServer(ifaceNames, changes),
+		isolator,
 		cfg.FirewallMark,
 	)
+	netw.SetIsolation(cfg.Isolation)
+	netw.SetReconnectPolicy(cfg.ReconnectPolicy)
+
+	// Revert system changes left behind if the daemon was not stopped gracefully
+	if err := changes.Replay(map[journal.Kind]journal.Reverter{
+		journal.KindSysctl: kernel.RevertSysctl,
+		journal.KindDNS:    dnsSetter.Revert,
+		journal.KindHosts:  dnsHostSetter.Revert,
+		journal.KindRoute:  netlinkrouter.Reverter(nlHandle),
+		journal.KindRule:   netlinkrule.Reverter(nlHandle),
+		journal.KindNetns:  isolation.Revert,
+	}); err != nil {
+		log.Println(internal.WarningPrefix, "reverting journaled system changes:", err)
+	}
 
 	// RPC Servers
 
@@ -420,7 +474,16 @@ func main() {
 		daemon.ServersDataFilePath,
 		daemon.CountryDataFilePath,
 		daemon.VersionFilePath,
+		daemon.ServerHistoryFilePath,
 	)
+	serverHistory := daemon.NewServerHistoryRecorder(dm)
+	daemonEvents.Service.Connect.Subscribe(serverHistory.NotifyConnect)
+	daemonEvents.Service.Disconnect.Subscribe(serverHistory.NotifyDisconnect)
+
+	monitor, err := netstate.NewNetlinkMonitor([]string{openvpn.InterfaceName, nordlynx.InterfaceName})
+	if err != nil {
+		log.Fatalln(err)
+	}
 
 	rpc := daemon.NewRPC(
 		internal.Environment(Environment),
@@ -446,6 +509,15 @@ func main() {
 		supportChecker,
 		analytics,
 		fileshareImplementation,
+		netstate.NewCaptivePortalProbe(netstate.CaptivePortalProbeURL, cfg.FirewallMark),
+		persistent.New(
+			persistent.RulesDir,
+			supportedIPTables,
+			[]string{nordlynx.InterfaceName, openvpn.InterfaceName},
+			cfg.FirewallMark,
+		),
+		monitor.LocalSubnets,
+		auditLog,
 	)
 	meshService := meshnet.NewServer(
 		authChecker,
@@ -461,7 +533,11 @@ func main() {
 		fileshareImplementation,
 	)
 
-	s := grpc.NewServer(grpc.Creds(internal.UnixSocketCredentials{}))
+	s := grpc.NewServer(
+		grpc.Creds(internal.UnixSocketCredentials{}),
+		grpc.UnaryInterceptor(daemon.CallerUnaryInterceptor),
+		grpc.StreamInterceptor(daemon.CallerStreamInterceptor),
+	)
 	pb.RegisterDaemonServer(s, rpc)
 	meshpb.RegisterMeshnetServer(s, meshService)
 
@@ -505,13 +581,19 @@ func main() {
 	go rpc.StartJobs()
 	go meshService.StartJobs()
 	rpc.StartKillSwitch()
+	rpc.StartLanDiscovery()
 	go rpc.StartAutoConnect()
 
-	monitor, err := netstate.NewNetlinkMonitor([]string{openvpn.InterfaceName, nordlynx.InterfaceName})
-	if err != nil {
-		log.Fatalln(err)
+	reconnector := rpc.LanDiscoveryReconnector(
+		rpc.TrustedNetworkReconnector(rpc.ReconnectPolicyReconnector(netw, defaultAPI)),
+	)
+	monitor.Start(reconnector)
+	// logind is not available on every system, so resume is optional
+	if sleepMonitor, err := netstate.NewSleepMonitor(); err != nil {
+		log.Println(internal.WarningPrefix, "monitoring resume from suspend:", err)
+	} else {
+		sleepMonitor.Start(func() { monitor.Revalidate(reconnector) })
 	}
-	monitor.Start(netw)
 
 	if authChecker.IsLoggedIn() {
 		go daemon.StartNotificationCenter(defaultAPI, notificationClient, fsystem)
@@ -28,7 +28,7 @@ var Salt = ""
 func main() {
 	dataPath := os.Args[1]
 	cm := config.NewFilesystem(config.SettingsDataFilePath, config.InstallFilePath, Salt)
-	dm := daemon.NewDataManager(dataPath+InsightsFilename, dataPath+ServersFilename, dataPath+countriesFilename, "")
+	dm := daemon.NewDataManager(dataPath+InsightsFilename, dataPath+ServersFilename, dataPath+countriesFilename, "", "")
 	client := request.NewStdHTTP()
 	clientEx := request.NewHTTPClient(client, daemon.BaseURL, nil, nil)
 	api := core.NewDefaultAPI(
@@ -55,6 +55,8 @@ func main() {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 		0,
 	)
 	daemon.JobInsights(dm, api, netw, true)()
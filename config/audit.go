@@ -0,0 +1,293 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// AuditFilePath defines path to the config change audit log
+const AuditFilePath = internal.LogPath + "audit.log"
+
+// Caller identifies who changes the config.
+type Caller struct {
+	UID uint32
+	// UnknownUID is set when the caller could not be identified, so that it
+	// is not mistaken for root
+	UnknownUID bool
+	// RPC is the name of the called method. Empty when the daemon
+	// changes the config on its own.
+	RPC string
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx which carries the caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored by WithCaller.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// AuditEntry describes a single config change.
+type AuditEntry struct {
+	Time    time.Time       `json:"time"`
+	UID     uint32          `json:"uid"`
+	RPC     string          `json:"rpc,omitempty"`
+	Changes []SettingChange `json:"changes"`
+	// UnknownUID is set when the caller could not be identified
+	UnknownUID bool `json:"unknown_uid,omitempty"`
+}
+
+// AuditLog stores config changes in a rotating file.
+//
+// Thread-safe.
+type AuditLog struct {
+	logger *lumberjack.Logger
+	mu     sync.Mutex
+}
+
+// NewAuditLog writes to the given path and keeps a few rotated files next to it.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{logger: &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    1, // megabytes
+		MaxBackups: 3,
+	}}
+}
+
+// Write appends the entry to the log.
+func (l *AuditLog) Write(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.logger.Write(append(data, '\n'))
+	return err
+}
+
+// Read up to limit latest entries, oldest first. Zero limit means all entries.
+func (l *AuditLog) Read(limit int) ([]AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// rotated files are named after the rotation time
+	ext := filepath.Ext(l.logger.Filename)
+	rotated, err := filepath.Glob(strings.TrimSuffix(l.logger.Filename, ext) + "-*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rotated)
+
+	var entries []AuditEntry
+	for _, path := range append(rotated, l.logger.Filename) {
+		fileEntries, err := readAuditFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func readAuditFile(path string) ([]AuditEntry, error) {
+	// #nosec G304 -- path is not controlled by the user
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G307 -- no writes are made
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		// skip lines cut by a crash instead of losing the whole history
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// AuditedManager records every config change to the audit log.
+//
+// Thread-safe.
+type AuditedManager struct {
+	Manager
+	log *AuditLog
+}
+
+// NewAuditedManager wraps the given manager.
+func NewAuditedManager(m Manager, log *AuditLog) *AuditedManager {
+	return &AuditedManager{Manager: m, log: log}
+}
+
+// SaveWith records the change as made by the daemon itself.
+func (m *AuditedManager) SaveWith(fn SaveFunc) error {
+	return m.SaveWithContext(context.Background(), fn)
+}
+
+// SaveWithContext records the change as made by the caller stored in ctx.
+func (m *AuditedManager) SaveWithContext(ctx context.Context, fn SaveFunc) error {
+	var changes []SettingChange
+	err := m.Manager.SaveWith(func(c Config) Config {
+		// taken before fn, because maps can be modified in place
+		old := newAuditSnapshot(c)
+		c = fn(c)
+		changes = old.diff(newAuditSnapshot(c))
+		return c
+	})
+	if err != nil {
+		return err
+	}
+	m.record(ctx, changes)
+	return nil
+}
+
+// Reset records the change as made by the daemon itself.
+func (m *AuditedManager) Reset() error {
+	return m.ResetWithContext(context.Background())
+}
+
+// ResetWithContext records the change as made by the caller stored in ctx.
+func (m *AuditedManager) ResetWithContext(ctx context.Context) error {
+	var old Config
+	if err := m.Manager.Load(&old); err != nil {
+		return err
+	}
+	if err := m.Manager.Reset(); err != nil {
+		return err
+	}
+	var reset Config
+	if err := m.Manager.Load(&reset); err != nil {
+		return err
+	}
+	m.record(ctx, newAuditSnapshot(old).diff(newAuditSnapshot(reset)))
+	return nil
+}
+
+func (m *AuditedManager) record(ctx context.Context, changes []SettingChange) {
+	if len(changes) == 0 {
+		return
+	}
+	caller, _ := CallerFromContext(ctx)
+	if err := m.log.Write(AuditEntry{
+		Time:       time.Now(),
+		UID:        caller.UID,
+		UnknownUID: caller.UnknownUID,
+		RPC:        caller.RPC,
+		Changes:    changes,
+	}); err != nil {
+		// failing to record must not prevent changing the settings
+		log.Println(internal.ErrorPrefix, "recording config change:", err)
+	}
+}
+
+// contextManager is implemented by managers which record who changes the config.
+type contextManager interface {
+	SaveWithContext(context.Context, SaveFunc) error
+	ResetWithContext(context.Context) error
+}
+
+// SaveWithContext saves the config on behalf of the caller stored in ctx.
+func SaveWithContext(ctx context.Context, m Manager, fn SaveFunc) error {
+	if cm, ok := m.(contextManager); ok {
+		return cm.SaveWithContext(ctx, fn)
+	}
+	return m.SaveWith(fn)
+}
+
+// ResetWithContext resets the config on behalf of the caller stored in ctx.
+func ResetWithContext(ctx context.Context, m Manager) error {
+	if cm, ok := m.(contextManager); ok {
+		return cm.ResetWithContext(ctx)
+	}
+	return m.Reset()
+}
+
+// auditSnapshot is a copy of the config parts recorded in the audit log.
+// Tokens and keys are never recorded.
+type auditSnapshot struct {
+	settings Settings
+	fields   auditedFields
+}
+
+// auditedFields are parts of Config outside of Settings which are recorded.
+type auditedFields struct {
+	Analytics       bool     `json:"analytics"`
+	Notify          []int64  `json:"notify"`
+	Overlays        []string `json:"overlays"`
+	Location        string   `json:"location"`
+	PinnedServer    string   `json:"pinned_server"`
+	Profiles        []string `json:"profiles"`
+	ActiveProfile   string   `json:"active_profile"`
+	TrustedNetworks []string `json:"trusted_networks"`
+	// KillSwitchPersistent is audited, because it can block traffic at boot
+	KillSwitchPersistent bool `json:"kill_switch_persistent"`
+}
+
+func newAuditSnapshot(c Config) auditSnapshot {
+	fields := auditedFields{
+		Analytics:            c.Analytics.Get(),
+		PinnedServer:         c.AutoConnectData.PinnedServer,
+		ActiveProfile:        c.ActiveProfile,
+		KillSwitchPersistent: c.KillSwitchPersistent,
+	}
+	if c.UsersData != nil {
+		for uid, notify := range c.UsersData.Notify {
+			if notify {
+				fields.Notify = append(fields.Notify, uid)
+			}
+		}
+		sort.Slice(fields.Notify, func(i, j int) bool { return fields.Notify[i] < fields.Notify[j] })
+		fields.Overlays = overlayStrings(c.UsersData.Overlays)
+	}
+	if c.Location != nil {
+		fields.Location = c.Location.CountryCode
+	}
+	for name := range c.Profiles {
+		fields.Profiles = append(fields.Profiles, name)
+	}
+	sort.Strings(fields.Profiles)
+	for _, network := range c.TrustedNetworks {
+		fields.TrustedNetworks = append(fields.TrustedNetworks, network.String())
+	}
+	return auditSnapshot{settings: NewSettings(c), fields: fields}
+}
+
+func (s auditSnapshot) diff(other auditSnapshot) []SettingChange {
+	changes := s.settings.Diff(other.settings)
+	diffValues("", reflect.ValueOf(s.fields), reflect.ValueOf(other.fields), &changes)
+	return changes
+}
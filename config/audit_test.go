@@ -0,0 +1,107 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryManager struct{ cfg Config }
+
+func (m *memoryManager) SaveWith(fn SaveFunc) error { m.cfg = fn(m.cfg); return nil }
+func (m *memoryManager) Load(c *Config) error       { *c = m.cfg; return nil }
+func (m *memoryManager) Reset() error               { m.cfg = *newConfig(); return nil }
+
+func TestAuditedManager(t *testing.T) {
+	category.Set(t, category.File)
+
+	auditLog := NewAuditLog(filepath.Join(t.TempDir(), "audit.log"))
+	cfg := testSettingsConfig()
+	cfg.UsersData = &UsersData{Notify: Notify{}}
+	m := NewAuditedManager(&memoryManager{cfg: cfg}, auditLog)
+
+	ctx := WithCaller(context.Background(), Caller{UID: 1000, RPC: "SetKillSwitch"})
+	require.NoError(t, SaveWithContext(ctx, m, func(c Config) Config {
+		c.KillSwitch = false
+		return c
+	}))
+	// nothing changed
+	require.NoError(t, SaveWithContext(ctx, m, func(c Config) Config { return c }))
+	// tokens are not recorded
+	require.NoError(t, m.SaveWith(func(c Config) Config {
+		c.TokensData[2] = TokenData{Token: "secret"}
+		return c
+	}))
+	// maps modified in place
+	require.NoError(t, m.SaveWith(func(c Config) Config {
+		c.UsersData.Notify[1000] = true
+		return c
+	}))
+
+	entries, err := auditLog.Read(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, uint32(1000), entries[0].UID)
+	assert.Equal(t, "SetKillSwitch", entries[0].RPC)
+	assert.Equal(t, []SettingChange{{Name: SettingKillSwitch, Old: "true", New: "false"}}, entries[0].Changes)
+	assert.WithinDuration(t, time.Now(), entries[0].Time, time.Minute)
+
+	assert.Empty(t, entries[1].RPC)
+	assert.Equal(t, []SettingChange{{Name: "notify", Old: "[]", New: "[1000]"}}, entries[1].Changes)
+
+	entries, err = auditLog.Read(1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "notify", entries[0].Changes[0].Name)
+}
+
+func TestAuditedManager_Reset(t *testing.T) {
+	category.Set(t, category.File)
+
+	auditLog := NewAuditLog(filepath.Join(t.TempDir(), "audit.log"))
+	m := NewAuditedManager(&memoryManager{cfg: testSettingsConfig()}, auditLog)
+
+	ctx := WithCaller(context.Background(), Caller{RPC: "SetDefaults", UnknownUID: true})
+	require.NoError(t, ResetWithContext(ctx, m))
+
+	entries, err := auditLog.Read(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "SetDefaults", entries[0].RPC)
+	// not mistaken for root
+	assert.True(t, entries[0].UnknownUID)
+	assert.Contains(t, entries[0].Changes, SettingChange{Name: SettingKillSwitch, Old: "true", New: "false"})
+}
+
+func TestAuditLog_Read(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	// rotated files are named after the rotation time
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit-2023-01-02T10-00-00.000.log"),
+		[]byte(`{"time":"2023-01-01T10:00:00Z","uid":1000,"rpc":"SetDNS","changes":[]}`+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit-2023-01-03T10-00-00.000.log"),
+		[]byte(`{"time":"2023-01-02T10:00:00Z","uid":1001,"rpc":"SetIpv6","changes":[]}`+"\n{\"time\":"), 0600))
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`{"time":"2023-01-03T10:00:00Z","uid":0,"changes":[]}`+"\n"), 0600))
+
+	entries, err := NewAuditLog(path).Read(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "SetDNS", entries[0].RPC)
+	assert.Equal(t, "SetIpv6", entries[1].RPC)
+	assert.Empty(t, entries[2].RPC)
+
+	entries, err = NewAuditLog(filepath.Join(dir, "missing.log")).Read(0)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
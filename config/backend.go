@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// Config storage backends selectable at daemon start
+const (
+	// BackendEncrypted keeps the whole config in a single encrypted file
+	BackendEncrypted = "encrypted"
+	// BackendPlaintext keeps non-secret settings in a plain-text file and
+	// secrets in a separate file readable only by root
+	BackendPlaintext = "plaintext"
+)
+
+// NewBackend creates a Manager for the given backend. Empty name selects
+// the encrypted backend. Error is returned when the backend cannot be used.
+// When another backend is used for the first time, config is copied over
+// from the encrypted one, so that users do not lose their settings and logins.
+func NewBackend(name string, encrypted *Filesystem) (Manager, error) {
+	var split *Split
+	switch strings.ToLower(name) {
+	case BackendEncrypted, "":
+		return encrypted, nil
+	case BackendPlaintext:
+		split = NewSplit(PlaintextSettingsFilePath, NewFileSecretStore(SecretsFilePath))
+	default:
+		return nil, fmt.Errorf("unknown config backend %q", name)
+	}
+
+	if err := importEncrypted(split, encrypted); err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+// importEncrypted copies config to the split backend unless it was used before.
+func importEncrypted(split *Split, encrypted *Filesystem) error {
+	if split.exists() || !internal.FileExists(encrypted.location) {
+		return nil
+	}
+	var cfg Config
+	if err := encrypted.Load(&cfg); err != nil {
+		return fmt.Errorf("loading encrypted config: %w", err)
+	}
+	if err := split.SaveWith(func(Config) Config { return cfg }); err != nil {
+		return fmt.Errorf("copying encrypted config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySecretStore struct{ data []byte }
+
+func (m *memorySecretStore) Load() ([]byte, error)  { return m.data, nil }
+func (m *memorySecretStore) Save(data []byte) error { m.data = data; return nil }
+
+type failingSecretStore struct{ memorySecretStore }
+
+func (*failingSecretStore) Save([]byte) error { return errors.New("secrets are not writable") }
+
+// TestManager_Contract checks behavior shared by all config backends.
+func TestManager_Contract(t *testing.T) {
+	category.Set(t, category.File)
+
+	backends := map[string]func(dir string) Manager{
+		"encrypted": func(dir string) Manager {
+			return NewFilesystem(filepath.Join(dir, "settings.dat"), filepath.Join(dir, "install.dat"), "")
+		},
+		"plaintext": func(dir string) Manager {
+			return NewSplit(filepath.Join(dir, "settings.json"), NewFileSecretStore(filepath.Join(dir, "secrets.json")))
+		},
+		"split with memory secrets": func(dir string) Manager {
+			return NewSplit(filepath.Join(dir, "settings.json"), &memorySecretStore{})
+		},
+		"audited": func(dir string) Manager {
+			return NewAuditedManager(
+				NewSplit(filepath.Join(dir, "settings.json"), &memorySecretStore{}),
+				NewAuditLog(filepath.Join(dir, "audit.log")),
+			)
+		},
+	}
+
+	for name, newManager := range backends {
+		t.Run(name, func(t *testing.T) {
+			t.Run("defaults are loaded when nothing is saved", func(t *testing.T) {
+				m := newManager(t.TempDir())
+				var cfg Config
+				require.NoError(t, m.Load(&cfg))
+				assert.Equal(t, ConfigVersion, cfg.Version)
+				assert.Equal(t, Technology_NORDLYNX, cfg.Technology)
+				assert.Equal(t, defaultFWMarkValue, cfg.FirewallMark)
+				assert.NotNil(t, cfg.TokensData)
+				assert.NotNil(t, cfg.Features)
+			})
+
+			t.Run("saved values are loaded", func(t *testing.T) {
+				m := newManager(t.TempDir())
+				require.NoError(t, m.SaveWith(func(c Config) Config {
+					c.KillSwitch = true
+					c.MeshPrivateKey = "mesh-key"
+					c.TokensData[1000] = TokenData{Token: "token", NordLynxPrivateKey: "private-key"}
+					c.AutoConnectData.Whitelist = NewWhitelist([]int64{53}, nil, []string{"10.0.0.0/8"})
+					return c
+				}))
+				require.NoError(t, m.SaveWith(func(c Config) Config {
+					// previous changes are visible
+					assert.True(t, c.KillSwitch)
+					c.IPv6 = true
+					return c
+				}))
+
+				var cfg Config
+				require.NoError(t, m.Load(&cfg))
+				assert.True(t, cfg.KillSwitch)
+				assert.True(t, cfg.IPv6)
+				assert.Equal(t, "mesh-key", cfg.MeshPrivateKey)
+				assert.Equal(t, "token", cfg.TokensData[1000].Token)
+				assert.Equal(t, NewWhitelist([]int64{53}, nil, []string{"10.0.0.0/8"}), cfg.AutoConnectData.Whitelist)
+			})
+
+			t.Run("reset restores defaults", func(t *testing.T) {
+				m := newManager(t.TempDir())
+				require.NoError(t, m.SaveWith(func(c Config) Config {
+					c.KillSwitch = true
+					c.TokensData[1000] = TokenData{Token: "token"}
+					return c
+				}))
+				require.NoError(t, m.Reset())
+
+				var cfg Config
+				require.NoError(t, m.Load(&cfg))
+				assert.False(t, cfg.KillSwitch)
+				assert.Empty(t, cfg.TokensData)
+			})
+
+			t.Run("concurrent saves are not lost", func(t *testing.T) {
+				m := newManager(t.TempDir())
+				var wg sync.WaitGroup
+				for i := int64(0); i < 10; i++ {
+					wg.Add(1)
+					go func(id int64) {
+						defer wg.Done()
+						assert.NoError(t, m.SaveWith(func(c Config) Config {
+							c.TokensData[id] = TokenData{Token: "token"}
+							return c
+						}))
+					}(i)
+				}
+				wg.Wait()
+
+				var cfg Config
+				require.NoError(t, m.Load(&cfg))
+				assert.Len(t, cfg.TokensData, 10)
+			})
+		})
+	}
+}
+
+func TestSplit_SecretsAreSeparated(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	settingsPath := filepath.Join(dir, "settings.json")
+	secretsPath := filepath.Join(dir, "secrets.json")
+	m := NewSplit(settingsPath, NewFileSecretStore(secretsPath))
+	require.NoError(t, m.SaveWith(func(c Config) Config {
+		c.KillSwitch = true
+		c.MeshPrivateKey = "mesh-key"
+		c.TokensData[1000] = TokenData{Token: "token", RenewToken: "renew-token"}
+		return c
+	}))
+
+	settings, err := os.ReadFile(settingsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(settings), `"kill_switch": true`)
+	for _, secret := range []string{"mesh-key", "renew-token", "tokens_data", "mesh_private_key"} {
+		assert.NotContains(t, string(settings), secret)
+	}
+	info, err := os.Stat(settingsPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(internal.PermUserRW), info.Mode().Perm())
+
+	info, err = os.Stat(secretsPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(internal.PermUserRW), info.Mode().Perm())
+	secrets, err := os.ReadFile(secretsPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(secrets), "renew-token")
+}
+
+func TestSplit_SaveFailure(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, NewSplit(path, &memorySecretStore{}).SaveWith(func(c Config) Config {
+		c.KillSwitch = true
+		return c
+	}))
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// settings are not replaced when secrets cannot be saved
+	assert.Error(t, NewSplit(path, &failingSecretStore{}).SaveWith(func(c Config) Config {
+		c.KillSwitch = false
+		return c
+	}))
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestSplit_Migration(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+	old, err := os.ReadFile("testdata/migrations/3.13.0.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, old, internal.PermUserRW))
+
+	var cfg Config
+	require.NoError(t, NewSplit(path, &memorySecretStore{}).Load(&cfg))
+	assert.Equal(t, ConfigVersion, cfg.Version)
+	assert.True(t, cfg.AutoConnectData.ThreatProtectionLite)
+	assert.FileExists(t, BackupFilePath(path, 0))
+}
+
+func TestNewBackend(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	encrypted := NewFilesystem(filepath.Join(t.TempDir(), "settings.dat"), filepath.Join(t.TempDir(), "install.dat"), "")
+	m, err := NewBackend("", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, encrypted, m)
+
+	m, err = NewBackend(BackendEncrypted, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, encrypted, m)
+
+	_, err = NewBackend("sqlite", encrypted)
+	assert.Error(t, err)
+
+	_, err = NewBackend("keyring", encrypted)
+	assert.Error(t, err)
+}
+
+func TestSplit_ImportsEncrypted(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	encrypted := NewFilesystem(filepath.Join(dir, "settings.dat"), filepath.Join(dir, "install.dat"), "")
+	require.NoError(t, encrypted.SaveWith(func(c Config) Config {
+		c.KillSwitch = true
+		c.TokensData[1000] = TokenData{Token: "token"}
+		return c
+	}))
+
+	split := NewSplit(filepath.Join(dir, "settings.json"), &memorySecretStore{})
+	require.NoError(t, importEncrypted(split, encrypted))
+
+	var cfg Config
+	require.NoError(t, split.Load(&cfg))
+	assert.True(t, cfg.KillSwitch)
+	assert.Equal(t, "token", cfg.TokensData[1000].Token)
+
+	// imported only once
+	require.NoError(t, split.SaveWith(func(c Config) Config {
+		c.KillSwitch = false
+		return c
+	}))
+	require.NoError(t, importEncrypted(split, encrypted))
+	var reloaded Config
+	require.NoError(t, split.Load(&reloaded))
+	assert.False(t, reloaded.KillSwitch)
+}
@@ -20,6 +20,7 @@ func newConfig() *Config {
 	}
 
 	return &Config{
+		Version:      ConfigVersion,
 		Technology:   Technology_NORDLYNX,
 		Firewall:     true,
 		FirewallMark: defaultFWMarkValue,
@@ -38,6 +39,8 @@ func newConfig() *Config {
 // Config should be evolved is such a way, that it does not
 // require any use of constructors by the caller.
 type Config struct {
+	// Version of the config schema. See ConfigVersion.
+	Version      uint32     `json:"version"`
 	Technology   Technology `json:"technology,omitempty"`
 	Firewall     bool       `json:"firewall"` // omitempty breaks this
 	FirewallMark uint32     `json:"fwmark"`
@@ -45,29 +48,65 @@ type Config struct {
 	Analytics    TrueField  `json:"analytics"`
 	Mesh         bool       `json:"mesh"`
 	// MeshPrivateKey is base64 encoded
-	MeshPrivateKey   string                    `json:"mesh_private_key"`
-	MeshDevice       *mesh.Machine             `json:"mesh_device"`
-	KillSwitch       bool                      `json:"kill_switch,omitempty"`
-	AutoConnect      bool                      `json:"auto_connect,omitempty"`
-	IPv6             bool                      `json:"ipv6"`
-	Meshnet          meshnet                   `json:"meshnet"`
-	AutoConnectData  AutoConnectData           `json:"auto_connect_data"` // omitempty breaks this
-	UsersData        *UsersData                `json:"users_data,omitempty"`
-	TokensData       map[int64]TokenData       `json:"tokens_data,omitempty"`
-	MachineID        uuid.UUID                 `json:"machine_id,omitempty"`
-	RouteThroughPeer string                    `json:"route_through_peer"`
-	Features         map[Feature]FeatureConfig `json:"features,omitempty"`
+	MeshPrivateKey string        `json:"mesh_private_key"`
+	MeshDevice     *mesh.Machine `json:"mesh_device"`
+	KillSwitch     bool          `json:"kill_switch,omitempty"`
+	// KillSwitchPersistent keeps traffic blocked at boot and while the
+	// daemon is not running. Only valid together with KillSwitch.
+	KillSwitchPersistent bool                      `json:"kill_switch_persistent,omitempty"`
+	AutoConnect          bool                      `json:"auto_connect,omitempty"`
+	IPv6                 bool                      `json:"ipv6"`
+	Meshnet              meshnet                   `json:"meshnet"`
+	AutoConnectData      AutoConnectData           `json:"auto_connect_data"` // omitempty breaks this
+	UsersData            *UsersData                `json:"users_data,omitempty"`
+	TokensData           map[int64]TokenData       `json:"tokens_data"`
+	MachineID            uuid.UUID                 `json:"machine_id,omitempty"`
+	RouteThroughPeer     string                    `json:"route_through_peer"`
+	Features             map[Feature]FeatureConfig `json:"features"`
+	// Location overrides geo location detected by the insights
+	// API. Nil when the detected location is used.
+	Location *Location `json:"location,omitempty"`
+	// Profiles are named snapshots of the connection settings.
+	Profiles      map[string]Profile `json:"profiles,omitempty"`
+	ActiveProfile string             `json:"active_profile,omitempty"`
+	// TrustedNetworks decide whether VPN is connected after the network
+	// changes.
+	TrustedNetworks TrustedNetworks `json:"trusted_networks,omitempty"`
+	// Isolation runs VPN connections in a dedicated network namespace
+	// instead of changing the host network.
+	Isolation bool `json:"isolation,omitempty"`
+	// LanDiscovery allows traffic to the local networks and device
+	// discovery while connected.
+	LanDiscovery bool `json:"lan_discovery,omitempty"`
+	// ReconnectPolicy is applied on network changes and resume. Empty
+	// means ReconnectPolicyFull.
+	ReconnectPolicy ReconnectPolicy `json:"reconnect_policy,omitempty"`
+}
+
+// Location is used to pick the nearest servers.
+type Location struct {
+	CountryCode string  `json:"country_code"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
 }
 
 type AutoConnectData struct {
-	ID        int64    `json:"id,omitempty"`
-	ServerTag string   `json:"server_tag,omitempty"`
-	Protocol  Protocol `json:"protocol,omitempty"`
-	// TODO: rename json key when v4 comes out.
-	ThreatProtectionLite bool      `json:"cybersec,omitempty"`
+	ID                   int64     `json:"id,omitempty"`
+	ServerTag            string    `json:"server_tag,omitempty"`
+	Protocol             Protocol  `json:"protocol,omitempty"`
+	ThreatProtectionLite bool      `json:"threat_protection_lite,omitempty"`
 	Obfuscate            bool      `json:"obfuscate,omitempty"`
 	DNS                  DNS       `json:"dns,omitempty"`
 	Whitelist            Whitelist `json:"whitelist,omitempty"`
+	// PinnedServerID and PinnedServer identify the server to which
+	// all automatic connections go back. Empty when nothing is pinned.
+	PinnedServerID int64  `json:"pinned_server_id,omitempty"`
+	PinnedServer   string `json:"pinned_server,omitempty"`
+}
+
+// IsServerPinned reports whether connections are bound to a single server.
+func (d AutoConnectData) IsServerPinned() bool {
+	return d.PinnedServer != ""
 }
 
 type DNS []string
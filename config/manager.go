@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/rand"
 	"os"
 	"sync"
@@ -128,23 +129,22 @@ func (f *Filesystem) load(c *Config) error {
 		return err
 	}
 
-	// this overrides default values
-	if err := json.Unmarshal(decrypted, c); err != nil {
+	migrated, changed, err := migrate(decrypted, func(version uint32, data []byte) error {
+		return f.backup(version, data, pass)
+	})
+	if err != nil {
 		return err
 	}
 
-	if c.FirewallMark == 0 {
-		// without this users need to reset their configs
-		c.FirewallMark = defaultFWMarkValue
-	}
-
-	if c.TokensData == nil {
-		// in order not to crash when a user has an old config
-		c.TokensData = map[int64]TokenData{}
+	// this overrides default values
+	if err := json.Unmarshal(migrated, c); err != nil {
+		return err
 	}
 
-	if c.Features == nil {
-		c.Features = map[Feature]FeatureConfig{}
+	if changed {
+		if err := f.save(*c); err != nil {
+			return err
+		}
 	}
 
 	if c.MachineID == [16]byte{} {
@@ -153,6 +153,21 @@ func (f *Filesystem) load(c *Config) error {
 	return nil
 }
 
+// backup encrypted config data of the given schema version next to the config.
+func (f *Filesystem) backup(version uint32, data []byte, pass string) error {
+	encrypted, err := internal.Encrypt(data, pass)
+	if err != nil {
+		return err
+	}
+	return save(encrypted, BackupFilePath(f.location, version))
+}
+
+// BackupFilePath returns the path where config of the given schema
+// version is backed up before it gets migrated.
+func BackupFilePath(location string, version uint32) string {
+	return fmt.Sprintf("%s.v%d.bak", location, version)
+}
+
 // save data to given location on disk
 func save(data []byte, location string) error {
 	if internal.FileExists(location) {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/NordSecurity/nordvpn-linux/test/category"
@@ -36,15 +37,7 @@ func TestFilesystem(t *testing.T) {
 		{
 			name: "whitelist is saved",
 			f: func(c Config) Config {
-				c.AutoConnectData.Whitelist.Ports.TCP = map[int64]bool{
-					443: true,
-				}
-				c.AutoConnectData.Whitelist.Ports.UDP = map[int64]bool{
-					53: true,
-				}
-				c.AutoConnectData.Whitelist.Subnets = map[string]bool{
-					"1.1.1.1/32": true,
-				}
+				c.AutoConnectData.Whitelist = NewWhitelist([]int64{53}, []int64{443}, []string{"1.1.1.1/32"})
 				return c
 			},
 		},
@@ -101,7 +94,8 @@ func TestConfigIsBackwardsCompatible(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.settingsFile, func(t *testing.T) {
-			fs := NewFilesystem(test.settingsFile, test.installFile, salt)
+			// migrated configs are written back
+			fs := NewFilesystem(copyTestFile(t, test.settingsFile), copyTestFile(t, test.installFile), salt)
 			var cfg Config
 			err := fs.Load(&cfg)
 			require.NoError(t, err)
@@ -148,7 +142,8 @@ func TestConfigDefaultValues(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.settingsFile, func(t *testing.T) {
-			fs := NewFilesystem(test.settingsFile, test.installFile, salt)
+			// migrated configs are written back
+			fs := NewFilesystem(copyTestFile(t, test.settingsFile), copyTestFile(t, test.installFile), salt)
 			var cfg Config
 			err := fs.Load(&cfg)
 			require.NoError(t, err)
@@ -184,3 +179,12 @@ func TestFeaturesMapIsInitialized(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, cfg.Features)
 }
+
+func copyTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	dst := filepath.Join(t.TempDir(), filepath.Base(path))
+	require.NoError(t, os.WriteFile(dst, data, 0600))
+	return dst
+}
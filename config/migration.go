@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ConfigVersion is the schema version of the config written by this build.
+//
+// When the config format changes in an incompatible way, add a migration
+// to the migrations list and bump the version.
+const ConfigVersion uint32 = 3
+
+// migration upgrades a decoded config by a single schema version.
+type migration func(map[string]any) error
+
+// migrations upgrade the config step by step. migrations[i] upgrades
+// the config from version i to version i+1. Configs written before
+// versioning was introduced have version 0.
+var migrations = []migration{
+	migrateDefaults,
+	migrateThreatProtectionLiteKey,
+	migrateWhitelistEntries,
+}
+
+// backupFunc stores the config data of the given version before it gets migrated.
+type backupFunc func(version uint32, data []byte) error
+
+// migrate config data to ConfigVersion. Returns false when the data is
+// already up to date. Configs written by newer versions are not touched.
+func migrate(data []byte, backup backupFunc) ([]byte, bool, error) {
+	raw, err := decodeRaw(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	version, err := rawVersion(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	if version >= ConfigVersion {
+		return data, false, nil
+	}
+
+	for ; version < ConfigVersion; version++ {
+		if err := backup(version, data); err != nil {
+			return nil, false, fmt.Errorf("backing up config version %d: %w", version, err)
+		}
+		if err := migrations[version](raw); err != nil {
+			return nil, false, fmt.Errorf("migrating config to version %d: %w", version+1, err)
+		}
+		raw["version"] = version + 1
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, false, err
+		}
+	}
+	return data, true, nil
+}
+
+func decodeRaw(data []byte) (map[string]any, error) {
+	var raw map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep big integers such as IDs intact
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		raw = map[string]any{}
+	}
+	return raw, nil
+}
+
+func rawVersion(raw map[string]any) (uint32, error) {
+	value, ok := raw["version"]
+	if !ok {
+		return 0, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid config version %v", value)
+	}
+	version, err := strconv.ParseUint(number.String(), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid config version %s", number)
+	}
+	return uint32(version), nil
+}
+
+// rawObject returns a nested object or nil if the key is missing.
+func rawObject(raw map[string]any, key string) (map[string]any, error) {
+	value, ok := raw[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", key)
+	}
+	return object, nil
+}
+
+// migrateDefaults fills values which were missing in configs written before
+// versioning. Until then they were patched every time the config was loaded.
+func migrateDefaults(raw map[string]any) error {
+	if mark, ok := raw["fwmark"].(json.Number); !ok || mark == "0" {
+		raw["fwmark"] = defaultFWMarkValue
+	}
+	for _, key := range []string{"tokens_data", "features"} {
+		object, err := rawObject(raw, key)
+		if err != nil {
+			return err
+		}
+		if object == nil {
+			raw[key] = map[string]any{}
+		}
+	}
+	return nil
+}
+
+// migrateThreatProtectionLiteKey renames the cybersec key to
+// threat_protection_lite in the auto-connect data and in the profiles.
+func migrateThreatProtectionLiteKey(raw map[string]any) error {
+	return forEachAutoConnectData(raw, func(data map[string]any) error {
+		if value, ok := data["cybersec"]; ok {
+			data["threat_protection_lite"] = value
+			delete(data, "cybersec")
+		}
+		return nil
+	})
+}
+
+// migrateWhitelistEntries converts sets of single ports and subnets into
+// whitelist entries in the auto-connect data and in the profiles.
+func migrateWhitelistEntries(raw map[string]any) error {
+	return forEachAutoConnectData(raw, func(data map[string]any) error {
+		whitelist, err := rawObject(data, "whitelist")
+		if err != nil || whitelist == nil {
+			return err
+		}
+		if _, ok := whitelist["entries"]; ok {
+			return nil
+		}
+
+		encoded, err := json.Marshal(whitelist)
+		if err != nil {
+			return err
+		}
+		var legacy struct {
+			Ports struct {
+				TCP []int64 `json:"tcp"`
+				UDP []int64 `json:"udp"`
+			} `json:"ports"`
+			Subnets []string `json:"subnets"`
+		}
+		if err := json.Unmarshal(encoded, &legacy); err != nil {
+			return fmt.Errorf("parsing whitelist: %w", err)
+		}
+
+		encoded, err = json.Marshal(NewWhitelist(legacy.Ports.UDP, legacy.Ports.TCP, legacy.Subnets))
+		if err != nil {
+			return err
+		}
+		data["whitelist"], err = decodeRaw(encoded)
+		return err
+	})
+}
+
+// forEachAutoConnectData calls fn with the auto-connect data of the config
+// and of every profile.
+func forEachAutoConnectData(raw map[string]any, fn func(map[string]any) error) error {
+	objects := []map[string]any{raw}
+	profiles, err := rawObject(raw, "profiles")
+	if err != nil {
+		return err
+	}
+	for name := range profiles {
+		profile, err := rawObject(profiles, name)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, profile)
+	}
+
+	for _, object := range objects {
+		data, err := rawObject(object, "auto_connect_data")
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
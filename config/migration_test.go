@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_Registry(t *testing.T) {
+	category.Set(t, category.Unit)
+	assert.Equal(t, int(ConfigVersion), len(migrations))
+	assert.Equal(t, ConfigVersion, newConfig().Version)
+}
+
+// TestMigrate_Golden migrates every historic config format found in
+// testdata/migrations and compares the result with a golden file.
+// Golden files are regenerated when UPDATE_GOLDEN environment variable is set.
+func TestMigrate_Golden(t *testing.T) {
+	category.Set(t, category.File)
+
+	inputs, err := filepath.Glob("testdata/migrations/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			var backups []uint32
+			migrated, changed, err := migrate(data, func(version uint32, backup []byte) error {
+				backups = append(backups, version)
+				// backup holds the data before the migration to the next version
+				raw, err := decodeRaw(backup)
+				require.NoError(t, err)
+				rawVer, err := rawVersion(raw)
+				require.NoError(t, err)
+				assert.Equal(t, version, rawVer)
+				return nil
+			})
+			require.NoError(t, err)
+
+			var cfg Config
+			require.NoError(t, json.Unmarshal(migrated, &cfg))
+			assert.Equal(t, ConfigVersion, cfg.Version)
+			assert.Equal(t, defaultFWMarkValue, cfg.FirewallMark)
+			assert.NotNil(t, cfg.TokensData)
+			assert.NotNil(t, cfg.Features)
+			assert.NotContains(t, string(migrated), "cybersec")
+
+			golden := strings.TrimSuffix(input, ".json") + ".golden"
+			if _, ok := os.LookupEnv("UPDATE_GOLDEN"); ok {
+				out, err := decodeRaw(migrated)
+				require.NoError(t, err)
+				formatted, err := json.MarshalIndent(out, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(golden, append(formatted, '\n'), internal.PermUserRW))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(migrated))
+
+			if !changed {
+				assert.Empty(t, backups)
+				assert.Equal(t, data, migrated)
+			} else {
+				assert.NotEmpty(t, backups)
+				assert.Equal(t, ConfigVersion-1, backups[len(backups)-1])
+			}
+		})
+	}
+}
+
+func TestMigrate_ThreatProtectionLite(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	data := []byte(`{"version": 1, "auto_connect_data": {"cybersec": true},
+		"profiles": {"work": {"auto_connect_data": {"cybersec": true}}}}`)
+	migrated, changed, err := migrate(data, func(uint32, []byte) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	var cfg Config
+	require.NoError(t, json.Unmarshal(migrated, &cfg))
+	assert.True(t, cfg.AutoConnectData.ThreatProtectionLite)
+	assert.True(t, cfg.Profiles["work"].AutoConnectData.ThreatProtectionLite)
+}
+
+func TestMigrate_Invalid(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	noBackup := func(uint32, []byte) error { return nil }
+	for _, data := range []string{
+		`not json`,
+		`{"version": "one"}`,
+		`{"version": -1}`,
+		`{"tokens_data": []}`,
+		`{"version": 1, "profiles": {"work": 1}}`,
+	} {
+		_, _, err := migrate([]byte(data), noBackup)
+		assert.Error(t, err, data)
+	}
+
+	// newer configs are left for newer versions of the app
+	data := []byte(`{"version": 1000}`)
+	migrated, changed, err := migrate(data, noBackup)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, data, migrated)
+}
+
+func TestFilesystem_Migration(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	location := filepath.Join(dir, "settings.dat")
+	vault := filepath.Join(dir, "install.dat")
+
+	old, err := os.ReadFile("testdata/migrations/3.13.0.json")
+	require.NoError(t, err)
+	pass, err := getPassphrase(vault, "")
+	require.NoError(t, err)
+	encrypted, err := internal.Encrypt(old, pass)
+	require.NoError(t, err)
+	require.NoError(t, save(encrypted, location))
+
+	fs := NewFilesystem(location, vault, "")
+	var cfg Config
+	require.NoError(t, fs.Load(&cfg))
+	assert.Equal(t, ConfigVersion, cfg.Version)
+	assert.True(t, cfg.AutoConnectData.ThreatProtectionLite)
+
+	// every migration step is preceded by a backup
+	for version := uint32(0); version < ConfigVersion; version++ {
+		data, err := load(BackupFilePath(location, version))
+		require.NoError(t, err)
+		decrypted, err := internal.Decrypt(data, pass)
+		require.NoError(t, err)
+		raw, err := decodeRaw(decrypted)
+		require.NoError(t, err)
+		rawVer, err := rawVersion(raw)
+		require.NoError(t, err)
+		assert.Equal(t, version, rawVer)
+	}
+	assert.NoFileExists(t, BackupFilePath(location, ConfigVersion))
+
+	// migrated config is persisted
+	data, err := load(location)
+	require.NoError(t, err)
+	decrypted, err := internal.Decrypt(data, pass)
+	require.NoError(t, err)
+	_, changed, err := migrate(decrypted, func(uint32, []byte) error {
+		t.Fatal("config should not be migrated twice")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"syscall"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFilePath defines path to the administrator policy file
+const PolicyFilePath = "/etc/nordvpn/policy.yaml"
+
+// ErrPolicyPermissions is returned when policy file can be modified by non-root users.
+var ErrPolicyPermissions = errors.New("policy file must be owned by root and writable only by its owner")
+
+// Policy locks settings on managed machines. Settings are named the same
+// way as in the exported settings file, for example:
+//
+//	enforce:
+//	  kill_switch: true
+//	  dns: [103.86.96.100]
+//	read_only:
+//	  - technology
+type Policy struct {
+	// Enforce pins settings to the given values.
+	Enforce yaml.Node `yaml:"enforce"`
+	// ReadOnly settings keep whichever values they have.
+	ReadOnly []string `yaml:"read_only"`
+	// enforced setting names
+	enforced []string
+}
+
+// LoadPolicy from the given path. Missing file means an empty policy.
+func LoadPolicy(path string) (Policy, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Policy{}, nil
+	}
+	if err != nil {
+		return Policy{}, err
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || stat.Uid != 0 || info.Mode().Perm()&0022 != 0 {
+		return Policy{}, ErrPolicyPermissions
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, err
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy from YAML and check whether it names only existing settings.
+func ParsePolicy(data []byte) (Policy, error) {
+	var policy Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && len(bytes.TrimSpace(data)) > 0 {
+		return Policy{}, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	if policy.Enforce.Kind == yaml.MappingNode {
+		for i := 0; i < len(policy.Enforce.Content); i += 2 {
+			policy.enforced = append(policy.enforced, policy.Enforce.Content[i].Value)
+		}
+	} else if policy.Enforce.Kind != 0 {
+		return Policy{}, errors.New("parsing policy: enforce must be a mapping")
+	}
+
+	for _, name := range append(policy.ReadOnly, policy.enforced...) {
+		if !slices.Contains(settingNames(), name) {
+			return Policy{}, fmt.Errorf("parsing policy: unknown setting %q", name)
+		}
+		// meshnet is managed together with the device registration
+		if name == SettingMeshnet {
+			return Policy{}, errors.New("parsing policy: meshnet cannot be locked")
+		}
+	}
+
+	// check value types
+	var settings Settings
+	if _, err := policy.apply(settings); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+// Locked returns names of settings which cannot be changed by users.
+func (p Policy) Locked() []string {
+	var names []string
+	for _, name := range append(p.ReadOnly, p.enforced...) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLocked reports whether any of the given settings cannot be changed by users.
+func (p Policy) IsLocked(names ...string) bool {
+	for _, name := range names {
+		if slices.Contains(p.ReadOnly, name) || slices.Contains(p.enforced, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply enforced values to the given config.
+func (p Policy) Apply(c Config) (Config, error) {
+	if len(p.enforced) == 0 {
+		return c, nil
+	}
+	settings, err := p.apply(NewSettings(c))
+	if err != nil {
+		return c, err
+	}
+	if err := settings.Validate(); err != nil {
+		return c, fmt.Errorf("enforcing policy: %w", err)
+	}
+	return settings.Apply(c), nil
+}
+
+func (p Policy) apply(s Settings) (Settings, error) {
+	if len(p.enforced) == 0 {
+		return s, nil
+	}
+	data, err := yaml.Marshal(&p.Enforce)
+	if err != nil {
+		return s, err
+	}
+	if err := UnmarshalSettings(data, &s); err != nil {
+		return s, fmt.Errorf("parsing policy: %w", err)
+	}
+	return s, nil
+}
+
+// settingNames returns names of the settings in the settings file.
+func settingNames() []string {
+	var names []string
+	settings := reflect.TypeOf(Settings{})
+	for i := 0; i < settings.NumField(); i++ {
+		names = append(names, strings.Split(settings.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return names
+}
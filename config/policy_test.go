@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+enforce:
+  kill_switch: true
+  dns: [103.86.96.100]
+read_only:
+  - technology
+  - dns
+`
+
+func TestParsePolicy(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{SettingDNS, SettingKillSwitch, SettingTechnology}, policy.Locked())
+	assert.True(t, policy.IsLocked(SettingKillSwitch))
+	assert.True(t, policy.IsLocked(SettingFirewall, SettingTechnology))
+	assert.False(t, policy.IsLocked(SettingFirewall, SettingProtocol))
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{name: "unknown section", policy: "allow: [dns]"},
+		{name: "unknown read only setting", policy: "read_only: [tokens]"},
+		{name: "unknown enforced setting", policy: "enforce:\n  tokens: {}"},
+		{name: "invalid value", policy: "enforce:\n  kill_switch: maybe"},
+		{name: "enforce is not a mapping", policy: "enforce: [dns]"},
+		{name: "meshnet", policy: "read_only: [meshnet]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(test.policy))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicy_Apply(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	cfg := *newConfig()
+	cfg.AutoConnectData.DNS = DNS{"1.1.1.1"}
+	cfg.AutoConnectData.Whitelist = NewWhitelist([]int64{53}, nil, nil)
+	applied, err := policy.Apply(cfg)
+	require.NoError(t, err)
+
+	assert.True(t, applied.KillSwitch)
+	assert.Equal(t, DNS{"103.86.96.100"}, applied.AutoConnectData.DNS)
+	assert.Equal(t, cfg.Technology, applied.Technology)
+	assert.Equal(t, cfg.AutoConnectData.Whitelist, applied.AutoConnectData.Whitelist)
+
+	// kill switch cannot be enforced when firewall is disabled
+	cfg.Firewall = false
+	_, err = policy.Apply(cfg)
+	assert.Error(t, err)
+
+	empty, err := ParsePolicy(nil)
+	require.NoError(t, err)
+	applied, err = empty.Apply(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, applied)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	policy, err := LoadPolicy(filepath.Join(dir, "missing.yaml"))
+	assert.NoError(t, err)
+	assert.Empty(t, policy.Locked())
+
+	path := filepath.Join(dir, "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy), 0644))
+	require.NoError(t, os.Chmod(path, 0666))
+	_, err = LoadPolicy(path)
+	assert.ErrorIs(t, err, ErrPolicyPermissions)
+
+	if os.Getuid() != 0 {
+		t.Skip("policy file has to be owned by root")
+	}
+	require.NoError(t, os.Chmod(path, 0644))
+	policy, err = LoadPolicy(path)
+	assert.NoError(t, err)
+	assert.True(t, policy.IsLocked(SettingKillSwitch))
+}
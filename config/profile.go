@@ -0,0 +1,47 @@
+package config
+
+import "regexp"
+
+var profileName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+// Profile is a named snapshot of the connection settings.
+type Profile struct {
+	Technology Technology `json:"technology"`
+	KillSwitch bool       `json:"kill_switch"`
+	IPv6       bool       `json:"ipv6"`
+	// AutoConnectData does not hold the user ID and the pinned server.
+	AutoConnectData AutoConnectData `json:"auto_connect_data"`
+}
+
+// NewProfile takes a snapshot of the connection settings.
+func NewProfile(c Config) Profile {
+	data := c.AutoConnectData
+	data.ID = 0
+	data.PinnedServerID = 0
+	data.PinnedServer = ""
+	return Profile{
+		Technology:      c.Technology,
+		KillSwitch:      c.KillSwitch,
+		IPv6:            c.IPv6,
+		AutoConnectData: data,
+	}
+}
+
+// Apply profile to the given config.
+func (p Profile) Apply(c Config) Config {
+	data := p.AutoConnectData
+	data.ID = c.AutoConnectData.ID
+	data.PinnedServerID = c.AutoConnectData.PinnedServerID
+	data.PinnedServer = c.AutoConnectData.PinnedServer
+
+	c.Technology = p.Technology
+	c.KillSwitch = p.KillSwitch
+	c.IPv6 = p.IPv6
+	c.AutoConnectData = data
+	return c
+}
+
+// IsValidProfileName reports whether name can be used for a profile.
+func IsValidProfileName(name string) bool {
+	return profileName.MatchString(name)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile_Apply(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cfg := testSettingsConfig()
+	cfg.AutoConnectData.ID = 1
+	cfg.AutoConnectData.PinnedServerID = 42
+	cfg.AutoConnectData.PinnedServer = "lt1.nordvpn.com"
+
+	profile := NewProfile(cfg)
+	assert.Zero(t, profile.AutoConnectData.ID)
+	assert.Zero(t, profile.AutoConnectData.PinnedServerID)
+	assert.Empty(t, profile.AutoConnectData.PinnedServer)
+
+	other := *newConfig()
+	other.AutoConnectData.ID = 2
+	applied := profile.Apply(other)
+	assert.Equal(t, NewSettings(cfg), NewSettings(applied))
+	assert.Equal(t, int64(2), applied.AutoConnectData.ID)
+	assert.Zero(t, applied.AutoConnectData.PinnedServerID)
+	assert.Equal(t, other.TokensData, applied.TokensData)
+}
+
+func TestIsValidProfileName(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	for _, name := range []string{"work", "home-2", "LOW_latency"} {
+		assert.True(t, IsValidProfileName(name), name)
+	}
+	for _, name := range []string{"", "with space", "../etc", "a123456789012345678901234567890123"} {
+		assert.False(t, IsValidProfileName(name), name)
+	}
+}
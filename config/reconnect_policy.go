@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReconnectPolicy decides how VPN connection is refreshed after the network
+// changes or the host resumes from suspend.
+type ReconnectPolicy string
+
+// Reconnect policies
+const (
+	// ReconnectPolicyFull re-creates the tunnel. It is the default.
+	ReconnectPolicyFull ReconnectPolicy = "full"
+	// ReconnectPolicyFast lets the VPN implementation roam to the new
+	// network without re-creating the tunnel, if it supports it.
+	ReconnectPolicyFast ReconnectPolicy = "fast"
+	// ReconnectPolicyReselect re-creates the tunnel and picks a new server
+	// if the country of the new network is different.
+	ReconnectPolicyReselect ReconnectPolicy = "reselect"
+)
+
+// ReconnectPolicies lists all of the valid policies.
+var ReconnectPolicies = []ReconnectPolicy{
+	ReconnectPolicyFull,
+	ReconnectPolicyFast,
+	ReconnectPolicyReselect,
+}
+
+// ParseReconnectPolicy case insensitively.
+func ParseReconnectPolicy(s string) (ReconnectPolicy, error) {
+	for _, policy := range ReconnectPolicies {
+		if strings.EqualFold(s, string(policy)) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid reconnect policy %q", s)
+}
+
+// OrDefault returns ReconnectPolicyFull if the policy is not set.
+func (p ReconnectPolicy) OrDefault() ReconnectPolicy {
+	if p == "" {
+		return ReconnectPolicyFull
+	}
+	return p
+}
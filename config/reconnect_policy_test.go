@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReconnectPolicy(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	for _, test := range []struct {
+		input    string
+		expected ReconnectPolicy
+		hasError bool
+	}{
+		{input: "full", expected: ReconnectPolicyFull},
+		{input: "FAST", expected: ReconnectPolicyFast},
+		{input: "Reselect", expected: ReconnectPolicyReselect},
+		{input: "", hasError: true},
+		{input: "slow", hasError: true},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			policy, err := ParseReconnectPolicy(test.input)
+			assert.Equal(t, test.hasError, err != nil)
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
+func TestReconnectPolicy_OrDefault(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	assert.Equal(t, ReconnectPolicyFull, ReconnectPolicy("").OrDefault())
+	assert.Equal(t, ReconnectPolicyFast, ReconnectPolicyFast.OrDefault())
+}
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings formats
+const (
+	SettingsFormatYAML = "yaml"
+	SettingsFormatJSON = "json"
+)
+
+// Setting names used by the settings file and the policy
+const (
+	SettingTechnology           = "technology"
+	SettingProtocol             = "protocol"
+	SettingObfuscate            = "obfuscate"
+	SettingFirewall             = "firewall"
+	SettingFirewallMark         = "fwmark"
+	SettingRouting              = "routing"
+	SettingKillSwitch           = "kill_switch"
+	SettingAutoConnect          = "auto_connect"
+	SettingAutoConnectServer    = "auto_connect_server"
+	SettingThreatProtectionLite = "threat_protection_lite"
+	SettingDNS                  = "dns"
+	SettingIPv6                 = "ipv6"
+	SettingMeshnet              = "meshnet"
+	SettingIsolation            = "isolation"
+	SettingWhitelist            = "whitelist"
+	SettingLanDiscovery         = "lan_discovery"
+	SettingReconnectPolicy      = "reconnect_policy"
+)
+
+// Settings is the user facing part of Config which can be shared between
+// machines. It never contains tokens or machine identifiers.
+type Settings struct {
+	Technology           string            `json:"technology" yaml:"technology"`
+	Protocol             string            `json:"protocol" yaml:"protocol"`
+	Obfuscate            bool              `json:"obfuscate" yaml:"obfuscate"`
+	Firewall             bool              `json:"firewall" yaml:"firewall"`
+	FirewallMark         uint32            `json:"fwmark" yaml:"fwmark"`
+	Routing              bool              `json:"routing" yaml:"routing"`
+	KillSwitch           bool              `json:"kill_switch" yaml:"kill_switch"`
+	AutoConnect          bool              `json:"auto_connect" yaml:"auto_connect"`
+	AutoConnectServer    string            `json:"auto_connect_server" yaml:"auto_connect_server"`
+	ThreatProtectionLite bool              `json:"threat_protection_lite" yaml:"threat_protection_lite"`
+	DNS                  []string          `json:"dns" yaml:"dns"`
+	IPv6                 bool              `json:"ipv6" yaml:"ipv6"`
+	Meshnet              bool              `json:"meshnet" yaml:"meshnet"`
+	Isolation            bool              `json:"isolation" yaml:"isolation"`
+	Whitelist            WhitelistSettings `json:"whitelist" yaml:"whitelist"`
+	LanDiscovery         bool              `json:"lan_discovery" yaml:"lan_discovery"`
+	ReconnectPolicy      string            `json:"reconnect_policy" yaml:"reconnect_policy"`
+}
+
+// WhitelistSettings are whitelist entries in the settings file.
+type WhitelistSettings []WhitelistEntry
+
+// UnmarshalYAML also accepts the format used before whitelist entries were
+// introduced, with lists of single ports and subnets.
+func (w *WhitelistSettings) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		var entries []WhitelistEntry
+		if err := node.Decode(&entries); err != nil {
+			return err
+		}
+		*w = entries
+		return nil
+	}
+
+	var legacy struct {
+		UDPPorts []int64  `yaml:"udp_ports"`
+		TCPPorts []int64  `yaml:"tcp_ports"`
+		Subnets  []string `yaml:"subnets"`
+	}
+	if err := node.Decode(&legacy); err != nil {
+		return err
+	}
+	*w = NewWhitelist(legacy.UDPPorts, legacy.TCPPorts, legacy.Subnets).Entries
+	return nil
+}
+
+// SettingChange describes a single setting which differs between two Settings.
+type SettingChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+func (c SettingChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Name, c.Old, c.New)
+}
+
+// NewSettings extracts Settings from the given Config.
+func NewSettings(c Config) Settings {
+	return Settings{
+		Technology:           strings.ToLower(c.Technology.String()),
+		Protocol:             strings.ToLower(c.AutoConnectData.Protocol.String()),
+		Obfuscate:            c.AutoConnectData.Obfuscate,
+		Firewall:             c.Firewall,
+		FirewallMark:         c.FirewallMark,
+		Routing:              c.Routing.Get(),
+		KillSwitch:           c.KillSwitch,
+		AutoConnect:          c.AutoConnect,
+		AutoConnectServer:    c.AutoConnectData.ServerTag,
+		ThreatProtectionLite: c.AutoConnectData.ThreatProtectionLite,
+		DNS:                  append([]string{}, c.AutoConnectData.DNS...),
+		IPv6:                 c.IPv6,
+		Meshnet:              c.Mesh,
+		Isolation:            c.Isolation,
+		Whitelist:            append(WhitelistSettings{}, c.AutoConnectData.Whitelist.Entries...),
+		LanDiscovery:         c.LanDiscovery,
+		ReconnectPolicy:      string(c.ReconnectPolicy.OrDefault()),
+	}
+}
+
+// Apply settings to the given Config. Settings must be validated beforehand.
+func (s Settings) Apply(c Config) Config {
+	c.Technology = Technology(Technology_value[strings.ToUpper(s.Technology)])
+	c.AutoConnectData.Protocol = Protocol(Protocol_value[strings.ToUpper(s.Protocol)])
+	c.AutoConnectData.Obfuscate = s.Obfuscate
+	c.Firewall = s.Firewall
+	c.FirewallMark = s.FirewallMark
+	c.Routing.Set(s.Routing)
+	c.KillSwitch = s.KillSwitch
+	if !c.KillSwitch {
+		// persistent mode is not part of the settings, but cannot outlive the
+		// kill switch
+		c.KillSwitchPersistent = false
+	}
+	c.AutoConnect = s.AutoConnect
+	c.AutoConnectData.ServerTag = s.AutoConnectServer
+	c.AutoConnectData.ThreatProtectionLite = s.ThreatProtectionLite
+	c.AutoConnectData.DNS = nil
+	if len(s.DNS) > 0 {
+		c.AutoConnectData.DNS = append(DNS{}, s.DNS...)
+	}
+	c.IPv6 = s.IPv6
+	c.Mesh = s.Meshnet
+	c.Isolation = s.Isolation
+	c.AutoConnectData.Whitelist = Whitelist{}
+	if len(s.Whitelist) > 0 {
+		c.AutoConnectData.Whitelist.Entries = append([]WhitelistEntry{}, s.Whitelist...)
+	}
+	c.LanDiscovery = s.LanDiscovery
+	c.ReconnectPolicy, _ = ParseReconnectPolicy(s.ReconnectPolicy)
+	return c
+}
+
+// Validate settings the same way as individual setters do.
+func (s Settings) Validate() error {
+	if err := s.ValidateValues(); err != nil {
+		return err
+	}
+	if s.KillSwitch && !s.Firewall {
+		return errors.New("kill switch requires firewall")
+	}
+	if s.Meshnet && !s.Routing {
+		return errors.New("meshnet requires routing")
+	}
+	if s.Isolation && !strings.EqualFold(s.Technology, Technology_NORDLYNX.String()) {
+		return errors.New("isolation requires nordlynx")
+	}
+	if s.Isolation && s.Meshnet {
+		return errors.New("isolation cannot be used with meshnet")
+	}
+	return nil
+}
+
+// ValidateValues checks each setting on its own, without the dependencies
+// between them.
+func (s Settings) ValidateValues() error {
+	tech := Technology(Technology_value[strings.ToUpper(s.Technology)])
+	if tech == Technology_UNKNOWN_TECHNOLOGY {
+		return fmt.Errorf("unknown technology %q", s.Technology)
+	}
+	if Protocol(Protocol_value[strings.ToUpper(s.Protocol)]) == Protocol_UNKNOWN_PROTOCOL {
+		return fmt.Errorf("unknown protocol %q", s.Protocol)
+	}
+	if tech == Technology_NORDLYNX && s.Obfuscate {
+		return errors.New("obfuscation is not supported by nordlynx")
+	}
+	if len(s.DNS) > 3 {
+		return errors.New("more than 3 DNS servers")
+	}
+	if len(s.DNS) > 0 && s.ThreatProtectionLite {
+		return errors.New("custom DNS cannot be used with threat protection lite")
+	}
+	for _, address := range s.DNS {
+		if _, err := netip.ParseAddr(address); err != nil {
+			return fmt.Errorf("invalid DNS server %q", address)
+		}
+	}
+	if _, err := ParseReconnectPolicy(s.ReconnectPolicy); err != nil {
+		return err
+	}
+	return Whitelist{Entries: s.Whitelist}.Validate()
+}
+
+// Diff returns settings which differ in other. Changes are named by their
+// keys in the settings file.
+func (s Settings) Diff(other Settings) []SettingChange {
+	var changes []SettingChange
+	diffValues("", reflect.ValueOf(s), reflect.ValueOf(other), &changes)
+	return changes
+}
+
+func diffValues(prefix string, old, new reflect.Value, changes *[]SettingChange) {
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		name := prefix + strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Type.Kind() == reflect.Struct {
+			diffValues(name+".", old.Field(i), new.Field(i), changes)
+			continue
+		}
+		oldValue, newValue := formatSetting(old.Field(i)), formatSetting(new.Field(i))
+		if oldValue != newValue {
+			*changes = append(*changes, SettingChange{Name: name, Old: oldValue, New: newValue})
+		}
+	}
+}
+
+func formatSetting(value reflect.Value) string {
+	if value.Kind() != reflect.Slice {
+		return fmt.Sprint(value.Interface())
+	}
+	values := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		values = append(values, fmt.Sprint(value.Index(i).Interface()))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// MarshalSettings into the given format.
+func MarshalSettings(s Settings, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case SettingsFormatJSON:
+		return json.MarshalIndent(s, "", "  ")
+	case SettingsFormatYAML, "":
+		return yaml.Marshal(s)
+	default:
+		return nil, fmt.Errorf("unsupported settings format %q", format)
+	}
+}
+
+// UnmarshalSettings from YAML or JSON into s. Settings missing in data are
+// left untouched, unknown settings are rejected.
+func UnmarshalSettings(data []byte, s *Settings) error {
+	// JSON is a subset of YAML
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil {
+		return fmt.Errorf("parsing settings: %w", err)
+	}
+	// order and case are not significant
+	s.Technology = strings.ToLower(s.Technology)
+	s.Protocol = strings.ToLower(s.Protocol)
+	s.ReconnectPolicy = strings.ToLower(s.ReconnectPolicy)
+	var whitelist Whitelist
+	for _, entry := range s.Whitelist {
+		whitelist, _ = whitelist.Add(entry)
+	}
+	s.Whitelist = append(WhitelistSettings{}, whitelist.Entries...)
+	return nil
+}
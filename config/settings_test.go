@@ -0,0 +1,145 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSettingsConfig() Config {
+	cfg := *newConfig()
+	cfg.KillSwitch = true
+	cfg.AutoConnectData.DNS = DNS{"1.1.1.1"}
+	cfg.AutoConnectData.Whitelist = NewWhitelist([]int64{53, 22}, []int64{443}, []string{"10.0.0.0/8"})
+	cfg.TokensData[1] = TokenData{Token: "secret", RenewToken: "renew-secret"}
+	return cfg
+}
+
+func TestSettings_RoundTrip(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	for _, format := range []string{SettingsFormatYAML, SettingsFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			cfg := testSettingsConfig()
+			cfg.LanDiscovery = true
+			cfg.ReconnectPolicy = ReconnectPolicyReselect
+			cfg.Isolation = true
+			data, err := MarshalSettings(NewSettings(cfg), format)
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "secret")
+
+			var settings Settings
+			require.NoError(t, UnmarshalSettings(data, &settings))
+			assert.NoError(t, settings.Validate())
+			assert.Equal(t, NewSettings(cfg), settings)
+			assert.Empty(t, NewSettings(cfg).Diff(settings))
+
+			applied := settings.Apply(cfg)
+			assert.Equal(t, cfg.TokensData, applied.TokensData)
+			assert.Equal(t, cfg.AutoConnectData.Whitelist, applied.AutoConnectData.Whitelist)
+			assert.True(t, applied.LanDiscovery)
+			assert.Equal(t, ReconnectPolicyReselect, applied.ReconnectPolicy)
+			assert.True(t, applied.Isolation)
+		})
+	}
+}
+
+func TestUnmarshalSettings_Partial(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	settings := NewSettings(testSettingsConfig())
+	data := []byte(`technology: OpenVPN
+obfuscate: true
+whitelist:
+  - ports: 8080-8090
+    protocol: TCP
+    comment: dev server
+  - ports: 8085
+    protocol: tcp
+  - subnet: 192.168.1.0/24
+    direction: inbound
+    interface: eth0
+`)
+	require.NoError(t, UnmarshalSettings(data, &settings))
+	assert.NoError(t, settings.Validate())
+
+	assert.Equal(t, []SettingChange{
+		{Name: "technology", Old: "nordlynx", New: "openvpn"},
+		{Name: "obfuscate", Old: "false", New: "true"},
+		{
+			Name: "whitelist",
+			Old:  "[22 udp both, 53 udp both, 443 tcp both, 10.0.0.0/8 any both]",
+			New:  "[8080-8090 tcp both (dev server), 192.168.1.0/24 any inbound on eth0]",
+		},
+	}, NewSettings(testSettingsConfig()).Diff(settings))
+}
+
+func TestUnmarshalSettings_LegacyWhitelist(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	var settings Settings
+	data := []byte("whitelist:\n  tcp_ports: [8080, 80, 81, 8080]\n  udp_ports: [80, 81]\n  subnets: [10.0.0.0/8]\n")
+	require.NoError(t, UnmarshalSettings(data, &settings))
+	assert.NoError(t, Whitelist{Entries: settings.Whitelist}.Validate())
+	assert.Equal(t, WhitelistSettings{
+		{Ports: NewPortRange(80, 81), Protocol: WhitelistProtocolAny, Direction: WhitelistDirectionBoth},
+		{Ports: NewPortRange(8080, 8080), Protocol: WhitelistProtocolTCP, Direction: WhitelistDirectionBoth},
+		{Subnet: "10.0.0.0/8", Protocol: WhitelistProtocolAny, Direction: WhitelistDirectionBoth},
+	}, settings.Whitelist)
+}
+
+func TestUnmarshalSettings_UnknownField(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	var settings Settings
+	assert.Error(t, UnmarshalSettings([]byte(`{"tokens_data": {}}`), &settings))
+}
+
+func TestSettings_Validate(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name   string
+		modify func(*Settings)
+	}{
+		{name: "unknown technology", modify: func(s *Settings) { s.Technology = "ipsec" }},
+		{name: "unknown protocol", modify: func(s *Settings) { s.Protocol = "sctp" }},
+		{name: "obfuscated nordlynx", modify: func(s *Settings) { s.Obfuscate = true }},
+		{name: "kill switch without firewall", modify: func(s *Settings) { s.Firewall = false }},
+		{name: "meshnet without routing", modify: func(s *Settings) { s.Meshnet = true; s.Routing = false }},
+		{name: "invalid dns", modify: func(s *Settings) { s.DNS = []string{"one.one.one.one"} }},
+		{name: "dns with threat protection lite", modify: func(s *Settings) { s.ThreatProtectionLite = true }},
+		{name: "invalid port", modify: func(s *Settings) { s.Whitelist[0].Ports = NewPortRange(1, 70000) }},
+		{name: "invalid subnet", modify: func(s *Settings) { s.Whitelist[3].Subnet = "10.0.0.0" }},
+		{name: "invalid direction", modify: func(s *Settings) { s.Whitelist[0].Direction = "sideways" }},
+		{name: "invalid interface", modify: func(s *Settings) { s.Whitelist[0].Interface = "eth0 -j DROP" }},
+		{name: "unknown reconnect policy", modify: func(s *Settings) { s.ReconnectPolicy = "slow" }},
+		{name: "isolation with openvpn", modify: func(s *Settings) { s.Isolation = true; s.Technology = "openvpn" }},
+		{name: "isolation with meshnet", modify: func(s *Settings) { s.Isolation = true; s.Meshnet = true }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := NewSettings(testSettingsConfig())
+			require.NoError(t, settings.Validate())
+			test.modify(&settings)
+			assert.Error(t, settings.Validate())
+		})
+	}
+}
+
+func TestSettings_ValidateValues(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	settings := NewSettings(testSettingsConfig())
+	// dependencies are left to the setters
+	settings.Firewall = false
+	assert.NoError(t, settings.ValidateValues())
+	assert.Error(t, settings.Validate())
+
+	settings.DNS = []string{"one.one.one.one"}
+	assert.Error(t, settings.ValidateValues())
+}
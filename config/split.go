@@ -0,0 +1,214 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+const (
+	// PlaintextSettingsFilePath defines path to non-secret settings used by
+	// the plaintext backend
+	PlaintextSettingsFilePath = internal.DatFilesPath + "settings.json"
+	// SecretsFilePath defines path to secrets used by the plaintext backend
+	SecretsFilePath = internal.DatFilesPath + "secrets.json"
+)
+
+// SecretStore keeps the secret part of the config.
+type SecretStore interface {
+	// Load secrets. Returns nil data when nothing is stored yet.
+	Load() ([]byte, error)
+	// Save secrets replacing the previous ones.
+	Save([]byte) error
+}
+
+// secrets are parts of Config which are never written to the plain-text file.
+type secrets struct {
+	TokensData     map[int64]TokenData `json:"tokens_data"`
+	MeshPrivateKey string              `json:"mesh_private_key"`
+}
+
+// secretKeys are JSON keys of secrets in Config.
+var secretKeys = []string{"tokens_data", "mesh_private_key"}
+
+// Split implements config persistence with non-secret settings in a
+// plain-text JSON file, which can be inspected and managed by config
+// management tools, and secrets kept by a SecretStore.
+//
+// Thread-safe.
+type Split struct {
+	location string
+	secrets  SecretStore
+	mu       sync.Mutex
+}
+
+// NewSplit is constructed from a plain-text settings location and a secret store.
+func NewSplit(location string, secrets SecretStore) *Split {
+	return &Split{location: location, secrets: secrets}
+}
+
+// SaveWith modifications provided by fn.
+//
+// Thread-safe.
+func (s *Split) SaveWith(fn SaveFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var c Config
+	if err := s.load(&c); err != nil {
+		return err
+	}
+	return s.save(fn(c))
+}
+
+// Load config into a given struct.
+//
+// Thread-safe.
+func (s *Split) Load(c *Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(c)
+}
+
+// Reset config values to defaults.
+//
+// Thread-safe.
+func (s *Split) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save(*newConfig())
+}
+
+// save writes settings to a temporary file which replaces the old one only
+// after the secrets are saved, so that a failure never leaves settings and
+// secrets out of sync or the settings file half written.
+func (s *Split) save(c Config) error {
+	secretData, err := json.Marshal(secrets{TokensData: c.TokensData, MeshPrivateKey: c.MeshPrivateKey})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	raw, err := decodeRaw(data)
+	if err != nil {
+		return err
+	}
+	for _, key := range secretKeys {
+		delete(raw, key)
+	}
+	data, err = json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.location + ".tmp"
+	if err := internal.FileWrite(tmp, append(data, '\n'), internal.PermUserRW); err != nil {
+		return err
+	}
+
+	if err := s.secrets.Save(secretData); err != nil {
+		// #nosec G104 -- the file is overwritten on the next save anyway
+		os.Remove(tmp)
+		return fmt.Errorf("saving secrets: %w", err)
+	}
+	return os.Rename(tmp, s.location)
+}
+
+func (s *Split) load(c *Config) error {
+	// #nosec G304 -- no input comes from the user
+	data, err := os.ReadFile(s.location)
+	if errors.Is(err, fs.ErrNotExist) {
+		// reasigning value behind the pointer
+		*c = *newConfig()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	migrated, changed, err := migrate(data, func(version uint32, data []byte) error {
+		return internal.FileWrite(BackupFilePath(s.location, version), data, internal.PermUserRW)
+	})
+	if err != nil {
+		return err
+	}
+
+	// this overrides default values
+	if err := json.Unmarshal(migrated, c); err != nil {
+		return err
+	}
+
+	data, err = s.secrets.Load()
+	if err != nil {
+		return fmt.Errorf("loading secrets: %w", err)
+	}
+	var secret secrets
+	if data != nil {
+		if err := json.Unmarshal(data, &secret); err != nil {
+			return fmt.Errorf("loading secrets: %w", err)
+		}
+	}
+	c.TokensData = secret.TokensData
+	if c.TokensData == nil {
+		c.TokensData = map[int64]TokenData{}
+	}
+	c.MeshPrivateKey = secret.MeshPrivateKey
+
+	if changed {
+		if err := s.save(*c); err != nil {
+			return err
+		}
+	}
+
+	if c.MachineID == [16]byte{} {
+		c.MachineID = internal.MachineID()
+	}
+	return nil
+}
+
+// exists reports whether the settings were saved at least once.
+func (s *Split) exists() bool {
+	return internal.FileExists(s.location)
+}
+
+// FileSecretStore keeps secrets in a file readable only by root. Useful
+// for debugging, as everything is stored in plain text.
+type FileSecretStore struct {
+	location string
+}
+
+// NewFileSecretStore stores secrets at the given location.
+func NewFileSecretStore(location string) *FileSecretStore {
+	return &FileSecretStore{location: location}
+}
+
+// Load secrets from the file.
+func (f *FileSecretStore) Load() ([]byte, error) {
+	// #nosec G304 -- no input comes from the user
+	data, err := os.ReadFile(f.location)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save secrets to a temporary file and rename it, so that the secrets are
+// never left half written.
+func (f *FileSecretStore) Save(data []byte) error {
+	tmp := f.location + ".tmp"
+	if err := internal.FileWrite(tmp, data, internal.PermUserRW); err != nil {
+		return err
+	}
+	// permissions of an existing file are not changed by the write
+	if err := os.Chmod(tmp, internal.PermUserRW); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.location)
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Trusted network actions
+const (
+	TrustedNetworkActionDisconnect = "disconnect"
+	TrustedNetworkActionConnect    = "connect"
+)
+
+// TrustedNetwork is a rule deciding whether VPN should be connected on a
+// network. All non empty fields must match the current network. Rule
+// without any fields matches every network, so it can be used as a
+// fallback after the more specific rules.
+type TrustedNetwork struct {
+	Name       string `json:"name"`
+	GatewayIP  string `json:"gateway_ip,omitempty"`
+	GatewayMAC string `json:"gateway_mac,omitempty"`
+	// Subnet matches when it contains the gateway or an address of the host.
+	Subnet     string `json:"subnet,omitempty"`
+	Interface  string `json:"interface,omitempty"`
+	DHCPDomain string `json:"dhcp_domain,omitempty"`
+	// Action is one of TrustedNetworkAction* values.
+	Action string `json:"action"`
+}
+
+// Normalized rule with values in canonical form.
+func (n TrustedNetwork) Normalized() TrustedNetwork {
+	if n.Action == "" {
+		n.Action = TrustedNetworkActionDisconnect
+	}
+	n.Action = strings.ToLower(n.Action)
+	if addr, err := netip.ParseAddr(n.GatewayIP); err == nil {
+		n.GatewayIP = addr.String()
+	}
+	if mac, err := net.ParseMAC(n.GatewayMAC); err == nil {
+		n.GatewayMAC = mac.String()
+	}
+	if prefix, err := netip.ParsePrefix(n.Subnet); err == nil {
+		n.Subnet = prefix.Masked().String()
+	}
+	n.DHCPDomain = strings.ToLower(strings.TrimSuffix(n.DHCPDomain, "."))
+	return n
+}
+
+// Validate rule. Rule must be normalized beforehand.
+func (n TrustedNetwork) Validate() error {
+	if !IsValidProfileName(n.Name) {
+		return fmt.Errorf("invalid trusted network name %q", n.Name)
+	}
+	if n.GatewayIP != "" {
+		if _, err := netip.ParseAddr(n.GatewayIP); err != nil {
+			return fmt.Errorf("invalid gateway IP %q", n.GatewayIP)
+		}
+	}
+	if n.GatewayMAC != "" {
+		if _, err := net.ParseMAC(n.GatewayMAC); err != nil {
+			return fmt.Errorf("invalid gateway MAC %q", n.GatewayMAC)
+		}
+	}
+	if n.Subnet != "" {
+		if _, err := netip.ParsePrefix(n.Subnet); err != nil {
+			return fmt.Errorf("invalid subnet %q", n.Subnet)
+		}
+	}
+	if n.Interface != "" && !interfaceName.MatchString(n.Interface) {
+		return fmt.Errorf("invalid interface %q", n.Interface)
+	}
+	switch n.Action {
+	case TrustedNetworkActionDisconnect, TrustedNetworkActionConnect:
+	default:
+		return fmt.Errorf("invalid trusted network action %q", n.Action)
+	}
+	return nil
+}
+
+// IsFallback reports whether the rule matches every network.
+func (n TrustedNetwork) IsFallback() bool {
+	return n.GatewayIP == "" &&
+		n.GatewayMAC == "" &&
+		n.Subnet == "" &&
+		n.Interface == "" &&
+		n.DHCPDomain == ""
+}
+
+func (n TrustedNetwork) String() string {
+	var match []string
+	if n.GatewayIP != "" {
+		match = append(match, "gateway "+n.GatewayIP)
+	}
+	if n.GatewayMAC != "" {
+		match = append(match, "gateway MAC "+n.GatewayMAC)
+	}
+	if n.Subnet != "" {
+		match = append(match, "subnet "+n.Subnet)
+	}
+	if n.Interface != "" {
+		match = append(match, "interface "+n.Interface)
+	}
+	if n.DHCPDomain != "" {
+		match = append(match, "domain "+n.DHCPDomain)
+	}
+	if len(match) == 0 {
+		match = append(match, "any network")
+	}
+	return fmt.Sprintf("%s: %s on %s", n.Name, n.Action, strings.Join(match, ", "))
+}
+
+// ErrTrustedNetworkExists is returned when adding a rule with a taken name.
+var ErrTrustedNetworkExists = errors.New("trusted network already exists")
+
+// TrustedNetworks are evaluated in order and the first matching one wins.
+type TrustedNetworks []TrustedNetwork
+
+// Add rule to the end. Fallback rules are kept last, so that they do not
+// shadow the more specific ones.
+func (t TrustedNetworks) Add(network TrustedNetwork) (TrustedNetworks, error) {
+	network = network.Normalized()
+	if err := network.Validate(); err != nil {
+		return t, err
+	}
+	if _, ok := t.Get(network.Name); ok {
+		return t, ErrTrustedNetworkExists
+	}
+
+	networks := make(TrustedNetworks, 0, len(t)+1)
+	var fallbacks TrustedNetworks
+	for _, existing := range t {
+		if existing.IsFallback() && !network.IsFallback() {
+			fallbacks = append(fallbacks, existing)
+			continue
+		}
+		networks = append(networks, existing)
+	}
+	networks = append(networks, network)
+	return append(networks, fallbacks...), nil
+}
+
+// Remove rule by name. Returns false if there is no such rule.
+func (t TrustedNetworks) Remove(name string) (TrustedNetworks, bool) {
+	var removed bool
+	var networks TrustedNetworks
+	for _, existing := range t {
+		if existing.Name == name {
+			removed = true
+			continue
+		}
+		networks = append(networks, existing)
+	}
+	return networks, removed
+}
+
+// Get rule by name.
+func (t TrustedNetworks) Get(name string) (TrustedNetwork, bool) {
+	for _, existing := range t {
+		if existing.Name == name {
+			return existing, true
+		}
+	}
+	return TrustedNetwork{}, false
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedNetwork_Validate(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name    string
+		network TrustedNetwork
+		hasErr  bool
+	}{
+		{name: "fallback", network: TrustedNetwork{Name: "other", Action: "connect"}},
+		{name: "office", network: TrustedNetwork{Name: "office", GatewayMAC: "AA:BB:CC:DD:EE:FF", Subnet: "10.1.2.3/16"}},
+		{name: "invalid name", network: TrustedNetwork{Name: "my office"}, hasErr: true},
+		{name: "invalid mac", network: TrustedNetwork{Name: "office", GatewayMAC: "aa:bb"}, hasErr: true},
+		{name: "invalid gateway", network: TrustedNetwork{Name: "office", GatewayIP: "10.0.0"}, hasErr: true},
+		{name: "invalid subnet", network: TrustedNetwork{Name: "office", Subnet: "10.0.0.0"}, hasErr: true},
+		{name: "invalid action", network: TrustedNetwork{Name: "office", Action: "pause"}, hasErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.network.Normalized().Validate()
+			if test.hasErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTrustedNetworks_Add(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	var networks TrustedNetworks
+	networks, err := networks.Add(TrustedNetwork{Name: "other", Action: TrustedNetworkActionConnect})
+	require.NoError(t, err)
+	networks, err = networks.Add(TrustedNetwork{Name: "office", GatewayMAC: "AA:BB:CC:DD:EE:FF", Subnet: "10.1.2.3/16"})
+	require.NoError(t, err)
+	networks, err = networks.Add(TrustedNetwork{Name: "home", DHCPDomain: "Home.Lan."})
+	require.NoError(t, err)
+
+	assert.Equal(t, TrustedNetworks{
+		{Name: "office", GatewayMAC: "aa:bb:cc:dd:ee:ff", Subnet: "10.1.0.0/16", Action: TrustedNetworkActionDisconnect},
+		{Name: "home", DHCPDomain: "home.lan", Action: TrustedNetworkActionDisconnect},
+		{Name: "other", Action: TrustedNetworkActionConnect},
+	}, networks)
+
+	_, err = networks.Add(TrustedNetwork{Name: "home", Interface: "wlan0"})
+	assert.ErrorIs(t, err, ErrTrustedNetworkExists)
+
+	networks, removed := networks.Remove("home")
+	assert.True(t, removed)
+	assert.Len(t, networks, 2)
+	_, removed = networks.Remove("home")
+	assert.False(t, removed)
+}
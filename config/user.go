@@ -3,11 +3,45 @@ package config
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/nstrings"
+
+	"golang.org/x/exp/slices"
 )
 
-// UsersData stores users which will receive notifications.
+// UsersData stores per user settings.
 type UsersData struct {
+	// Notify is a set of users which will receive notifications.
 	Notify Notify `json:"notify"`
+	// Overlays are applied on top of the global settings when the
+	// given user connects.
+	Overlays map[int64]Overlay `json:"overlays,omitempty"`
+}
+
+// Overlay returns settings overridden by the given user.
+func (d *UsersData) Overlay(uid int64) Overlay {
+	if d == nil {
+		return Overlay{}
+	}
+	return d.Overlays[uid]
+}
+
+// SetOverlay replaces settings overridden by the given user. Empty
+// overlays are removed.
+func (d *UsersData) SetOverlay(uid int64, o Overlay) {
+	if o.IsEmpty() {
+		delete(d.Overlays, uid)
+		return
+	}
+	if d.Overlays == nil {
+		d.Overlays = map[int64]Overlay{}
+	}
+	d.Overlays[uid] = o
 }
 
 // Notify is a set of user ids.
@@ -39,3 +73,178 @@ func (n *Notify) UnmarshalJSON(b []byte) error {
 	*n = set
 	return nil
 }
+
+// UserSettings can be overridden by each user. Other settings either
+// affect the whole system, e.g. firewall and fwmark, or select the VPN
+// implementation used by the daemon, e.g. technology, and stay global.
+var UserSettings = []string{
+	SettingProtocol,
+	SettingObfuscate,
+	SettingThreatProtectionLite,
+	SettingDNS,
+}
+
+// ErrGlobalSetting is returned when a setting cannot be overridden per user.
+var ErrGlobalSetting = errors.New("setting can be changed only globally")
+
+// Overlay holds settings overridden by a single user. Nil fields mean
+// that the global value is used.
+type Overlay struct {
+	Protocol             *Protocol `json:"protocol,omitempty"`
+	Obfuscate            *bool     `json:"obfuscate,omitempty"`
+	ThreatProtectionLite *bool     `json:"threat_protection_lite,omitempty"`
+	// DNS is empty when the user wants the default DNS servers.
+	DNS *DNS `json:"dns,omitempty"`
+}
+
+// IsEmpty reports whether nothing is overridden.
+func (o Overlay) IsEmpty() bool {
+	return o == Overlay{}
+}
+
+// Set overrides a setting named the same way as in the settings file.
+// Value "off" for DNS means the default DNS servers.
+func (o Overlay) Set(name string, values []string) (Overlay, error) {
+	if !slices.Contains(UserSettings, name) {
+		return o, fmt.Errorf("%w: %s", ErrGlobalSetting, name)
+	}
+	if name != SettingDNS && len(values) != 1 {
+		return o, fmt.Errorf("%s takes a single value", name)
+	}
+
+	switch name {
+	case SettingProtocol:
+		protocol := Protocol(Protocol_value[strings.ToUpper(values[0])])
+		if protocol == Protocol_UNKNOWN_PROTOCOL {
+			return o, fmt.Errorf("unknown protocol %q", values[0])
+		}
+		o.Protocol = &protocol
+	case SettingObfuscate:
+		obfuscate, err := nstrings.BoolFromString(values[0])
+		if err != nil {
+			return o, err
+		}
+		o.Obfuscate = &obfuscate
+	case SettingThreatProtectionLite:
+		tpl, err := nstrings.BoolFromString(values[0])
+		if err != nil {
+			return o, err
+		}
+		if tpl && o.DNS != nil && len(*o.DNS) > 0 {
+			return o, errors.New("custom DNS cannot be used with threat protection lite")
+		}
+		o.ThreatProtectionLite = &tpl
+	case SettingDNS:
+		dns, err := parseOverlayDNS(values)
+		if err != nil {
+			return o, err
+		}
+		if len(dns) > 0 && o.ThreatProtectionLite != nil && *o.ThreatProtectionLite {
+			return o, errors.New("custom DNS cannot be used with threat protection lite")
+		}
+		o.DNS = &dns
+	}
+	return o, nil
+}
+
+func parseOverlayDNS(values []string) (DNS, error) {
+	if len(values) == 1 && nstrings.CanParseFalseFromString(values[0]) {
+		return DNS{}, nil
+	}
+	if len(values) == 0 || len(values) > 3 {
+		return nil, errors.New("from 1 to 3 DNS servers are required")
+	}
+	for _, address := range values {
+		if _, err := netip.ParseAddr(address); err != nil {
+			return nil, fmt.Errorf("invalid DNS server %q", address)
+		}
+	}
+	return append(DNS{}, values...), nil
+}
+
+// Unset makes the user follow the global value of a setting again.
+func (o Overlay) Unset(name string) (Overlay, error) {
+	switch name {
+	case SettingProtocol:
+		o.Protocol = nil
+	case SettingObfuscate:
+		o.Obfuscate = nil
+	case SettingThreatProtectionLite:
+		o.ThreatProtectionLite = nil
+	case SettingDNS:
+		o.DNS = nil
+	default:
+		return o, fmt.Errorf("%w: %s", ErrGlobalSetting, name)
+	}
+	return o, nil
+}
+
+// Without returns the overlay without the given settings, for example
+// the ones locked by the administrator policy.
+func (o Overlay) Without(names ...string) Overlay {
+	for _, name := range names {
+		// global settings are not in the overlay anyway
+		o, _ = o.Unset(name)
+	}
+	return o
+}
+
+// Values of overridden settings formatted for display.
+func (o Overlay) Values() map[string]string {
+	values := map[string]string{}
+	if o.Protocol != nil {
+		values[SettingProtocol] = strings.ToLower(o.Protocol.String())
+	}
+	if o.Obfuscate != nil {
+		values[SettingObfuscate] = nstrings.GetBoolLabel(*o.Obfuscate)
+	}
+	if o.ThreatProtectionLite != nil {
+		values[SettingThreatProtectionLite] = nstrings.GetBoolLabel(*o.ThreatProtectionLite)
+	}
+	if o.DNS != nil {
+		values[SettingDNS] = nstrings.GetBoolLabel(false)
+		if len(*o.DNS) > 0 {
+			values[SettingDNS] = strings.Join(*o.DNS, ", ")
+		}
+	}
+	return values
+}
+
+// Apply overlay to the given config. Protocol and obfuscation are
+// applied only to OpenVPN, as NordLynx supports neither of them.
+func (o Overlay) Apply(c Config) Config {
+	if c.Technology == Technology_OPENVPN {
+		if o.Protocol != nil {
+			c.AutoConnectData.Protocol = *o.Protocol
+		}
+		if o.Obfuscate != nil {
+			c.AutoConnectData.Obfuscate = *o.Obfuscate
+		}
+	}
+	if o.ThreatProtectionLite != nil {
+		c.AutoConnectData.ThreatProtectionLite = *o.ThreatProtectionLite
+		if *o.ThreatProtectionLite {
+			c.AutoConnectData.DNS = nil
+		}
+	}
+	if o.DNS != nil {
+		c.AutoConnectData.DNS = nil
+		if len(*o.DNS) > 0 {
+			c.AutoConnectData.DNS = append(DNS{}, *o.DNS...)
+			c.AutoConnectData.ThreatProtectionLite = false
+		}
+	}
+	return c
+}
+
+// overlayStrings formats all overlays as sorted uid.setting=value strings.
+func overlayStrings(overlays map[int64]Overlay) []string {
+	var values []string
+	for uid, overlay := range overlays {
+		for name, value := range overlay.Values() {
+			values = append(values, fmt.Sprintf("%d.%s=%s", uid, name, value))
+		}
+	}
+	sort.Strings(values)
+	return values
+}
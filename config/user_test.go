@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlay_Set(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name    string
+		setting string
+		values  []string
+		hasErr  bool
+	}{
+		{name: "protocol", setting: SettingProtocol, values: []string{"tcp"}},
+		{name: "unknown protocol", setting: SettingProtocol, values: []string{"sctp"}, hasErr: true},
+		{name: "obfuscate", setting: SettingObfuscate, values: []string{"on"}},
+		{name: "obfuscate multiple values", setting: SettingObfuscate, values: []string{"on", "off"}, hasErr: true},
+		{name: "dns", setting: SettingDNS, values: []string{"1.1.1.1", "1.0.0.1"}},
+		{name: "dns off", setting: SettingDNS, values: []string{"off"}},
+		{name: "invalid dns", setting: SettingDNS, values: []string{"one"}, hasErr: true},
+		{name: "too many dns", setting: SettingDNS, values: []string{"1.1.1.1", "1.1.1.2", "1.1.1.3", "1.1.1.4"}, hasErr: true},
+		{name: "firewall is global", setting: SettingFirewall, values: []string{"off"}, hasErr: true},
+		{name: "fwmark is global", setting: SettingFirewallMark, values: []string{"1"}, hasErr: true},
+		{name: "technology is global", setting: SettingTechnology, values: []string{"openvpn"}, hasErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			overlay, err := Overlay{}.Set(test.setting, test.values)
+			if test.hasErr {
+				assert.Error(t, err)
+				assert.True(t, overlay.IsEmpty())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, overlay.Values(), test.setting)
+		})
+	}
+}
+
+func TestOverlay_ThreatProtectionLiteConflictsWithDNS(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	overlay, err := Overlay{}.Set(SettingDNS, []string{"1.1.1.1"})
+	require.NoError(t, err)
+	_, err = overlay.Set(SettingThreatProtectionLite, []string{"on"})
+	assert.Error(t, err)
+
+	overlay, err = overlay.Set(SettingDNS, []string{"off"})
+	require.NoError(t, err)
+	overlay, err = overlay.Set(SettingThreatProtectionLite, []string{"on"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{SettingDNS: "disabled", SettingThreatProtectionLite: "enabled"}, overlay.Values())
+}
+
+func TestOverlay_Apply(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	overlay, err := Overlay{}.Set(SettingProtocol, []string{"tcp"})
+	require.NoError(t, err)
+	overlay, err = overlay.Set(SettingObfuscate, []string{"on"})
+	require.NoError(t, err)
+	overlay, err = overlay.Set(SettingDNS, []string{"1.1.1.1"})
+	require.NoError(t, err)
+
+	global := Config{
+		Technology:   Technology_OPENVPN,
+		Firewall:     true,
+		FirewallMark: defaultFWMarkValue,
+		AutoConnectData: AutoConnectData{
+			Protocol:             Protocol_UDP,
+			ThreatProtectionLite: true,
+		},
+	}
+	c := overlay.Apply(global)
+	assert.Equal(t, Protocol_TCP, c.AutoConnectData.Protocol)
+	assert.True(t, c.AutoConnectData.Obfuscate)
+	assert.Equal(t, DNS{"1.1.1.1"}, c.AutoConnectData.DNS)
+	assert.False(t, c.AutoConnectData.ThreatProtectionLite)
+	assert.True(t, c.Firewall)
+	assert.Equal(t, defaultFWMarkValue, c.FirewallMark)
+	// global config is not modified
+	assert.Equal(t, Protocol_UDP, global.AutoConnectData.Protocol)
+
+	// nordlynx supports neither protocol nor obfuscation
+	global.Technology = Technology_NORDLYNX
+	c = overlay.Apply(global)
+	assert.Equal(t, Protocol_UDP, c.AutoConnectData.Protocol)
+	assert.False(t, c.AutoConnectData.Obfuscate)
+
+	c = overlay.Without(SettingDNS).Apply(global)
+	assert.Empty(t, c.AutoConnectData.DNS)
+	assert.True(t, c.AutoConnectData.ThreatProtectionLite)
+}
+
+func TestUsersData_JSON(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	overlay, err := Overlay{}.Set(SettingDNS, []string{"off"})
+	require.NoError(t, err)
+	data := UsersData{Notify: Notify{1000: true}}
+	data.SetOverlay(1000, overlay)
+	data.SetOverlay(1001, Overlay{})
+
+	encoded, err := json.Marshal(&data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"notify":[1000],"overlays":{"1000":{"dns":[]}}}`, string(encoded))
+
+	var decoded UsersData
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, data, decoded)
+	assert.True(t, decoded.Overlay(1001).IsEmpty())
+	assert.True(t, (*UsersData)(nil).Overlay(1000).IsEmpty())
+}
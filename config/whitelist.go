@@ -1,103 +1,505 @@
 package config
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// NewWhitelist ready to use
-func NewWhitelist(udpPorts []int64, tcpPorts []int64, subnets []string) Whitelist {
-	udp := map[int64]bool{}
-	for _, port := range udpPorts {
-		udp[port] = true
-	}
+// Whitelist entry protocols
+const (
+	WhitelistProtocolAny = "any"
+	WhitelistProtocolTCP = "tcp"
+	WhitelistProtocolUDP = "udp"
+)
+
+// Whitelist entry directions
+const (
+	WhitelistDirectionBoth     = "both"
+	WhitelistDirectionInbound  = "inbound"
+	WhitelistDirectionOutbound = "outbound"
+)
+
+// Port limits
+const (
+	MinPort int64 = 1
+	MaxPort int64 = 65535
+)
+
+var interfaceName = regexp.MustCompile(`^[a-zA-Z0-9_.:@-]{1,15}$`)
+
+// Whitelist is a collection of traffic which bypasses the VPN tunnel.
+type Whitelist struct {
+	// Entries are kept sorted, so that equal whitelists are equal values.
+	Entries []WhitelistEntry `json:"entries"`
+}
+
+// WhitelistEntry allows either a port range or a subnet.
+type WhitelistEntry struct {
+	// Ports are nil for subnet entries.
+	Ports  *PortRange `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Subnet string     `json:"subnet,omitempty" yaml:"subnet,omitempty"`
+	// Protocol is one of WhitelistProtocol* values.
+	Protocol string `json:"protocol" yaml:"protocol"`
+	// Direction is one of WhitelistDirection* values.
+	Direction string `json:"direction" yaml:"direction"`
+	// Interface limits the entry to a single network interface. Empty
+	// means all interfaces.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Expiry is nil for permanent entries.
+	Expiry *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+}
+
+// PortRange is an inclusive range of ports written as "22" or "3000-3100".
+type PortRange struct {
+	Min int64
+	Max int64
+}
 
-	tcp := map[int64]bool{}
-	for _, port := range tcpPorts {
-		tcp[port] = true
+// NewPortRange from min to max inclusive.
+func NewPortRange(min int64, max int64) *PortRange {
+	if min > max {
+		min, max = max, min
 	}
+	return &PortRange{Min: min, Max: max}
+}
 
-	subs := map[string]bool{}
-	for _, sub := range subnets {
-		subs[sub] = true
+// ParsePortRange from "22" or "3000-3100".
+func ParsePortRange(s string) (*PortRange, error) {
+	from, to, isRange := strings.Cut(s, "-")
+	min, err := strconv.ParseInt(strings.TrimSpace(from), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", s)
+	}
+	max := min
+	if isRange {
+		max, err = strconv.ParseInt(strings.TrimSpace(to), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port range %q", s)
+		}
 	}
+	return NewPortRange(min, max), nil
+}
 
-	return Whitelist{
-		Ports: Ports{
-			UDP: udp,
-			TCP: tcp,
-		},
-		Subnets: subs,
+func (r PortRange) String() string {
+	if r.Min == r.Max {
+		return strconv.FormatInt(r.Min, 10)
 	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
 }
 
-// Whitelist is a collection of ports and subnets
-type Whitelist struct {
-	Ports   Ports   `json:"ports"`
-	Subnets Subnets `json:"subnets"`
+// Len is the number of ports in the range.
+func (r PortRange) Len() int {
+	return int(r.Max - r.Min + 1)
 }
 
-// Subnets is a set of subnets.
-type Subnets map[string]bool
+// MarshalText into "22" or "3000-3100".
+func (r PortRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
 
-// MarshalJSON into []string.
-func (s Subnets) MarshalJSON() ([]byte, error) {
-	var subnets []string
-	for subnet := range s {
-		subnets = append(subnets, subnet)
+// UnmarshalText from "22" or "3000-3100".
+func (r *PortRange) UnmarshalText(text []byte) error {
+	parsed, err := ParsePortRange(string(text))
+	if err != nil {
+		return err
 	}
+	*r = *parsed
+	return nil
+}
+
+// NewWhitelist from single ports and subnets. Consecutive ports are
+// merged into ranges and ports whitelisted for both protocols become a
+// single entry.
+func NewWhitelist(udpPorts []int64, tcpPorts []int64, subnets []string) Whitelist {
+	udp := portRanges(udpPorts)
+	tcp := portRanges(tcpPorts)
 
-	return json.Marshal(subnets)
+	var w Whitelist
+	for r := range udp {
+		protocol := WhitelistProtocolUDP
+		if _, ok := tcp[r]; ok {
+			protocol = WhitelistProtocolAny
+			delete(tcp, r)
+		}
+		w.Entries = append(w.Entries, WhitelistEntry{
+			Ports:     NewPortRange(r.Min, r.Max),
+			Protocol:  protocol,
+			Direction: WhitelistDirectionBoth,
+		})
+	}
+	for r := range tcp {
+		w.Entries = append(w.Entries, WhitelistEntry{
+			Ports:     NewPortRange(r.Min, r.Max),
+			Protocol:  WhitelistProtocolTCP,
+			Direction: WhitelistDirectionBoth,
+		})
+	}
+	for _, subnet := range subnets {
+		w, _ = w.Add(WhitelistEntry{Subnet: subnet})
+	}
+	w.sort()
+	return w
 }
 
-// UnmarshalJSON into map[string]bool.
-func (s *Subnets) UnmarshalJSON(b []byte) error {
-	var i []string
-	err := json.Unmarshal(b, &i)
-	if err != nil {
-		return err
+// portRanges merges consecutive ports.
+func portRanges(ports []int64) map[PortRange]struct{} {
+	sorted := append([]int64{}, ports...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ranges := map[PortRange]struct{}{}
+	for i := 0; i < len(sorted); {
+		r := PortRange{Min: sorted[i], Max: sorted[i]}
+		for i++; i < len(sorted) && sorted[i] <= r.Max+1; i++ {
+			r.Max = sorted[i]
+		}
+		ranges[r] = struct{}{}
 	}
+	return ranges
+}
 
-	subnets := map[string]bool{}
-	for _, subnet := range i {
-		subnets[subnet] = true
+// Normalized entry with defaults filled in and values in canonical form.
+func (e WhitelistEntry) Normalized() WhitelistEntry {
+	e.Protocol = strings.ToLower(e.Protocol)
+	if e.Protocol == "" {
+		e.Protocol = WhitelistProtocolAny
+	}
+	e.Direction = strings.ToLower(e.Direction)
+	if e.Direction == "" {
+		e.Direction = WhitelistDirectionBoth
+	}
+	if e.Ports != nil {
+		e.Ports = NewPortRange(e.Ports.Min, e.Ports.Max)
 	}
+	if prefix, err := netip.ParsePrefix(e.Subnet); err == nil {
+		e.Subnet = prefix.Masked().String()
+	}
+	if e.Expiry != nil {
+		expiry := e.Expiry.UTC().Truncate(time.Second)
+		e.Expiry = &expiry
+	}
+	return e
+}
 
-	*s = subnets
+// Validate entry. Entry must be normalized beforehand.
+func (e WhitelistEntry) Validate() error {
+	if (e.Ports == nil) == (e.Subnet == "") {
+		return errors.New("whitelist entry must have either ports or a subnet")
+	}
+	if e.Ports != nil && (e.Ports.Min < MinPort || e.Ports.Max > MaxPort) {
+		return fmt.Errorf("invalid whitelisted port %s", e.Ports)
+	}
+	if e.Subnet != "" {
+		if _, err := netip.ParsePrefix(e.Subnet); err != nil {
+			return fmt.Errorf("invalid whitelisted subnet %q", e.Subnet)
+		}
+	}
+	switch e.Protocol {
+	case WhitelistProtocolAny, WhitelistProtocolTCP, WhitelistProtocolUDP:
+	default:
+		return fmt.Errorf("invalid whitelisted protocol %q", e.Protocol)
+	}
+	switch e.Direction {
+	case WhitelistDirectionBoth, WhitelistDirectionInbound, WhitelistDirectionOutbound:
+	default:
+		return fmt.Errorf("invalid whitelisted direction %q", e.Direction)
+	}
+	if e.Interface != "" && !interfaceName.MatchString(e.Interface) {
+		return fmt.Errorf("invalid whitelisted interface %q", e.Interface)
+	}
 	return nil
 }
 
-// Ports is a collection of TCP and UDP ports.
-type Ports struct {
-	TCP PortSet `json:"tcp"`
-	UDP PortSet `json:"udp"`
+// IsTemporary reports whether the entry expires.
+func (e WhitelistEntry) IsTemporary() bool {
+	return e.Expiry != nil
+}
+
+func (e WhitelistEntry) String() string {
+	target := e.Subnet
+	if e.Ports != nil {
+		target = e.Ports.String()
+	}
+	s := fmt.Sprintf("%s %s %s", target, e.Protocol, e.Direction)
+	if e.Interface != "" {
+		s += " on " + e.Interface
+	}
+	if e.Comment != "" {
+		s += fmt.Sprintf(" (%s)", e.Comment)
+	}
+	if e.Expiry != nil {
+		s += " until " + e.Expiry.Format(time.RFC3339)
+	}
+	return s
+}
+
+// sameTarget reports whether entries allow the same traffic.
+func (e WhitelistEntry) sameTarget(other WhitelistEntry) bool {
+	return e.Subnet == other.Subnet &&
+		(e.Ports == nil) == (other.Ports == nil) &&
+		(e.Ports == nil || *e.Ports == *other.Ports) &&
+		e.Protocol == other.Protocol &&
+		e.Direction == other.Direction &&
+		e.Interface == other.Interface
 }
 
-// PortSet is a set of ports.
-type PortSet map[int64]bool
+// covers reports whether all traffic allowed by other is also allowed by e.
+func (e WhitelistEntry) covers(other WhitelistEntry) bool {
+	if e.Subnet != other.Subnet || (e.Ports == nil) != (other.Ports == nil) {
+		return false
+	}
+	if e.Ports != nil && (e.Ports.Min > other.Ports.Min || e.Ports.Max < other.Ports.Max) {
+		return false
+	}
+	return (e.Protocol == WhitelistProtocolAny || e.Protocol == other.Protocol) &&
+		(e.Direction == WhitelistDirectionBoth || e.Direction == other.Direction) &&
+		(e.Interface == "" || e.Interface == other.Interface)
+}
 
-// MarshalJSON into []float64.
-func (p PortSet) MarshalJSON() ([]byte, error) {
-	var ports []float64
-	for port := range p {
-		ports = append(ports, float64(port))
+// Add entry to the whitelist. Comment and expiry of an existing entry
+// allowing the same traffic are updated, but a permanent entry is never
+// made temporary, otherwise it would be removed once the expiry passes.
+// Returns false when nothing has changed, because the traffic is already
+// allowed.
+func (w Whitelist) Add(entry WhitelistEntry) (Whitelist, bool) {
+	entry = entry.Normalized()
+	for i, existing := range w.Entries {
+		if !existing.sameTarget(entry) {
+			continue
+		}
+		if !existing.IsTemporary() && entry.IsTemporary() {
+			return w, false
+		}
+		if existing.Comment == entry.Comment && equalExpiry(existing.Expiry, entry.Expiry) {
+			return w, false
+		}
+		w.Entries = append([]WhitelistEntry{}, w.Entries...)
+		w.Entries[i] = entry
+		return w, true
+	}
+	for _, existing := range w.Entries {
+		if existing.covers(entry) && !existing.IsTemporary() {
+			return w, false
+		}
 	}
 
-	return json.Marshal(ports)
+	w.Entries = append(append([]WhitelistEntry{}, w.Entries...), entry)
+	w.sort()
+	return w, true
 }
 
-// UnmarshalJSON into map[int64]bool.
-func (p *PortSet) UnmarshalJSON(b []byte) error {
-	var i []float64
-	err := json.Unmarshal(b, &i)
-	if err != nil {
-		return err
+func equalExpiry(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// RemovePorts removes ports of the given entry from all entries which match
+// its protocol, direction and interface, splitting the ranges if needed.
+// Direction both and an empty interface match any entry. Returns false if
+// none of the ports were whitelisted.
+func (w Whitelist) RemovePorts(removal WhitelistEntry) (Whitelist, bool) {
+	removal = removal.Normalized()
+	ports := *removal.Ports
+
+	var removed bool
+	var entries []WhitelistEntry
+	for _, entry := range w.Entries {
+		if entry.Ports == nil ||
+			entry.Ports.Max < ports.Min || entry.Ports.Min > ports.Max ||
+			!protocolsOverlap(entry.Protocol, removal.Protocol) ||
+			!directionsOverlap(entry.Direction, removal.Direction) ||
+			(removal.Interface != "" && entry.Interface != removal.Interface) {
+			entries = append(entries, entry)
+			continue
+		}
+		removed = true
+
+		piece := func(min int64, max int64, protocol string, direction string) {
+			e := entry
+			e.Ports = NewPortRange(min, max)
+			e.Protocol = protocol
+			e.Direction = direction
+			entries = append(entries, e)
+		}
+		if entry.Ports.Min < ports.Min {
+			piece(entry.Ports.Min, ports.Min-1, entry.Protocol, entry.Direction)
+		}
+		if entry.Ports.Max > ports.Max {
+			piece(ports.Max+1, entry.Ports.Max, entry.Protocol, entry.Direction)
+		}
+		from, to := entry.Ports.Min, entry.Ports.Max
+		if ports.Min > from {
+			from = ports.Min
+		}
+		if ports.Max < to {
+			to = ports.Max
+		}
+		if remaining := subtractProtocol(entry.Protocol, removal.Protocol); remaining != "" {
+			piece(from, to, remaining, entry.Direction)
+		}
+		if remaining := subtractDirection(entry.Direction, removal.Direction); remaining != "" {
+			piece(from, to, intersectProtocol(entry.Protocol, removal.Protocol), remaining)
+		}
+	}
+	if !removed {
+		return w, false
+	}
+	w.Entries = entries
+	w.sort()
+	return w, true
+}
+
+func protocolsOverlap(a string, b string) bool {
+	return a == b || a == WhitelistProtocolAny || b == WhitelistProtocolAny
+}
+
+// intersectProtocol returns the protocol shared by both of the overlapping
+// protocols.
+func intersectProtocol(a string, b string) string {
+	if a == WhitelistProtocolAny {
+		return b
+	}
+	return a
+}
+
+// subtractProtocol returns the protocol left after removing other or an
+// empty string if nothing is left.
+func subtractProtocol(protocol string, other string) string {
+	switch {
+	case other == WhitelistProtocolAny, protocol == other:
+		return ""
+	case protocol == WhitelistProtocolAny && other == WhitelistProtocolTCP:
+		return WhitelistProtocolUDP
+	case protocol == WhitelistProtocolAny && other == WhitelistProtocolUDP:
+		return WhitelistProtocolTCP
+	default:
+		return protocol
+	}
+}
+
+func directionsOverlap(a string, b string) bool {
+	return a == b || a == WhitelistDirectionBoth || b == WhitelistDirectionBoth
+}
+
+// subtractDirection returns the direction left after removing other or an
+// empty string if nothing is left.
+func subtractDirection(direction string, other string) string {
+	switch {
+	case other == WhitelistDirectionBoth, direction == other:
+		return ""
+	case direction == WhitelistDirectionBoth && other == WhitelistDirectionInbound:
+		return WhitelistDirectionOutbound
+	case direction == WhitelistDirectionBoth && other == WhitelistDirectionOutbound:
+		return WhitelistDirectionInbound
+	default:
+		return direction
 	}
+}
+
+// RemoveSubnet removes all entries of the subnet. Returns false if the
+// subnet was not whitelisted.
+func (w Whitelist) RemoveSubnet(subnet string) (Whitelist, bool) {
+	subnet = WhitelistEntry{Subnet: subnet}.Normalized().Subnet
+
+	var removed bool
+	var entries []WhitelistEntry
+	for _, entry := range w.Entries {
+		if entry.Subnet == subnet {
+			removed = true
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	w.Entries = entries
+	return w, removed
+}
 
-	ports := map[int64]bool{}
-	for _, port := range i {
-		ports[int64(port)] = true
+// RemoveExpired removes temporary entries which have expired by now.
+// Returns false if nothing has expired.
+func (w Whitelist) RemoveExpired(now time.Time) (Whitelist, bool) {
+	var removed bool
+	var entries []WhitelistEntry
+	for _, entry := range w.Entries {
+		if entry.IsTemporary() && !entry.Expiry.After(now) {
+			removed = true
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if !removed {
+		return w, false
 	}
+	w.Entries = entries
+	return w, true
+}
 
-	*p = ports
+// Validate all entries.
+func (w Whitelist) Validate() error {
+	for _, entry := range w.Entries {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// PortCount returns the number of ports whitelisted for the protocol.
+func (w Whitelist) PortCount(protocol string) int {
+	var count int
+	for _, entry := range w.Entries {
+		if entry.Ports != nil && protocolsOverlap(entry.Protocol, protocol) {
+			count += entry.Ports.Len()
+		}
+	}
+	return count
+}
+
+// Subnets returns whitelisted subnets without duplicates.
+func (w Whitelist) Subnets() []string {
+	subnets := []string{}
+	for _, entry := range w.Entries {
+		if entry.Subnet != "" && (len(subnets) == 0 || subnets[len(subnets)-1] != entry.Subnet) {
+			subnets = append(subnets, entry.Subnet)
+		}
+	}
+	return subnets
+}
+
+// IsEmpty reports whether nothing is whitelisted.
+func (w Whitelist) IsEmpty() bool {
+	return len(w.Entries) == 0
+}
+
+// sort port entries before subnets.
+func (w Whitelist) sort() {
+	sort.SliceStable(w.Entries, func(i, j int) bool {
+		a, b := w.Entries[i], w.Entries[j]
+		if (a.Ports == nil) != (b.Ports == nil) {
+			return a.Ports != nil
+		}
+		if a.Ports != nil && *a.Ports != *b.Ports {
+			if a.Ports.Min != b.Ports.Min {
+				return a.Ports.Min < b.Ports.Min
+			}
+			return a.Ports.Max < b.Ports.Max
+		}
+		if a.Subnet != b.Subnet {
+			return a.Subnet < b.Subnet
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return a.Interface < b.Interface
+	})
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func portEntry(min int64, max int64, protocol string) WhitelistEntry {
+	return WhitelistEntry{Ports: NewPortRange(min, max), Protocol: protocol, Direction: WhitelistDirectionBoth}
+}
+
+func subnetEntry(subnet string) WhitelistEntry {
+	return WhitelistEntry{Subnet: subnet, Protocol: WhitelistProtocolAny, Direction: WhitelistDirectionBoth}
+}
+
+func TestParsePortRange(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		input    string
+		expected *PortRange
+		hasErr   bool
+	}{
+		{input: "22", expected: &PortRange{Min: 22, Max: 22}},
+		{input: "3000-3100", expected: &PortRange{Min: 3000, Max: 3100}},
+		{input: "3100-3000", expected: &PortRange{Min: 3000, Max: 3100}},
+		{input: "ssh", hasErr: true},
+		{input: "22-", hasErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ports, err := ParsePortRange(test.input)
+			if test.hasErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ports)
+		})
+	}
+}
+
+func TestPortRange_JSON(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	data, err := json.Marshal(portEntry(3000, 3100, WhitelistProtocolTCP))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ports":"3000-3100","protocol":"tcp","direction":"both"}`, string(data))
+
+	var entry WhitelistEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, portEntry(3000, 3100, WhitelistProtocolTCP), entry)
+}
+
+func TestNewWhitelist(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	whitelist := NewWhitelist(
+		[]int64{53, 22, 23, 24},
+		[]int64{22, 23, 24, 443},
+		[]string{"10.0.0.0/8", "10.0.0.1/8"},
+	)
+	assert.Equal(t, []WhitelistEntry{
+		portEntry(22, 24, WhitelistProtocolAny),
+		portEntry(53, 53, WhitelistProtocolUDP),
+		portEntry(443, 443, WhitelistProtocolTCP),
+		subnetEntry("10.0.0.0/8"),
+	}, whitelist.Entries)
+	assert.Equal(t, 4, whitelist.PortCount(WhitelistProtocolUDP))
+	assert.Equal(t, 4, whitelist.PortCount(WhitelistProtocolTCP))
+	assert.Equal(t, []string{"10.0.0.0/8"}, whitelist.Subnets())
+}
+
+func TestWhitelist_Add(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	whitelist := NewWhitelist(nil, []int64{3000, 3001, 3002}, nil)
+
+	_, added := whitelist.Add(WhitelistEntry{Ports: NewPortRange(3001, 3001), Protocol: "TCP"})
+	assert.False(t, added, "covered port should not be added")
+
+	_, added = whitelist.Add(WhitelistEntry{Ports: NewPortRange(3001, 3001), Protocol: WhitelistProtocolUDP})
+	assert.True(t, added)
+
+	commented, added := whitelist.Add(WhitelistEntry{Ports: NewPortRange(3000, 3002), Protocol: WhitelistProtocolTCP, Comment: "dev"})
+	assert.True(t, added)
+	require.Len(t, commented.Entries, 1)
+	assert.Equal(t, "dev", commented.Entries[0].Comment)
+	assert.Empty(t, whitelist.Entries[0].Comment, "original whitelist must not be modified")
+
+	expiry := time.Now().Add(time.Hour)
+	temporary, added := whitelist.Add(WhitelistEntry{Subnet: "192.168.1.1/24", Expiry: &expiry})
+	assert.True(t, added)
+	require.Len(t, temporary.Entries, 2)
+	assert.Equal(t, "192.168.1.0/24", temporary.Entries[1].Subnet)
+	assert.True(t, temporary.Entries[1].IsTemporary())
+
+	_, added = commented.Add(WhitelistEntry{Ports: NewPortRange(3000, 3002), Protocol: WhitelistProtocolTCP, Expiry: &expiry})
+	assert.False(t, added, "permanent entry should not be made temporary")
+
+	permanent, added := temporary.Add(WhitelistEntry{Subnet: "192.168.1.0/24"})
+	assert.True(t, added)
+	require.Len(t, permanent.Entries, 2)
+	assert.False(t, permanent.Entries[1].IsTemporary())
+}
+
+func TestWhitelist_RemovePorts(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name     string
+		removal  WhitelistEntry
+		expected []WhitelistEntry
+		removed  bool
+	}{
+		{
+			name:    "split range",
+			removal: portEntry(3050, 3059, WhitelistProtocolAny),
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3049, WhitelistProtocolAny),
+				portEntry(3060, 3100, WhitelistProtocolAny),
+			},
+			removed: true,
+		},
+		{
+			name:    "subtract protocol",
+			removal: portEntry(3100, 3100, WhitelistProtocolTCP),
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3099, WhitelistProtocolAny),
+				portEntry(3100, 3100, WhitelistProtocolUDP),
+			},
+			removed: true,
+		},
+		{
+			name:    "other protocol",
+			removal: portEntry(22, 22, WhitelistProtocolTCP),
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3100, WhitelistProtocolAny),
+			},
+		},
+		{
+			name:    "subtract direction",
+			removal: WhitelistEntry{Ports: NewPortRange(22, 22), Direction: WhitelistDirectionInbound},
+			expected: []WhitelistEntry{
+				{Ports: NewPortRange(22, 22), Protocol: WhitelistProtocolUDP, Direction: WhitelistDirectionOutbound},
+				portEntry(3000, 3100, WhitelistProtocolAny),
+			},
+			removed: true,
+		},
+		{
+			name: "subtract protocol and direction",
+			removal: WhitelistEntry{
+				Ports:     NewPortRange(3100, 3100),
+				Protocol:  WhitelistProtocolTCP,
+				Direction: WhitelistDirectionOutbound,
+			},
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3099, WhitelistProtocolAny),
+				{Ports: NewPortRange(3100, 3100), Protocol: WhitelistProtocolTCP, Direction: WhitelistDirectionInbound},
+				portEntry(3100, 3100, WhitelistProtocolUDP),
+			},
+			removed: true,
+		},
+		{
+			name:    "other interface",
+			removal: WhitelistEntry{Ports: NewPortRange(22, 22), Interface: "eth0"},
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3100, WhitelistProtocolAny),
+			},
+		},
+		{
+			name:    "not whitelisted",
+			removal: portEntry(80, 443, WhitelistProtocolAny),
+			expected: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3100, WhitelistProtocolAny),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			whitelist := Whitelist{Entries: []WhitelistEntry{
+				portEntry(22, 22, WhitelistProtocolUDP),
+				portEntry(3000, 3100, WhitelistProtocolAny),
+			}}
+			whitelist, removed := whitelist.RemovePorts(test.removal)
+			assert.Equal(t, test.removed, removed)
+			assert.Equal(t, test.expected, whitelist.Entries)
+		})
+	}
+}
+
+func TestWhitelist_RemoveSubnet(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	whitelist := NewWhitelist([]int64{22}, nil, []string{"10.0.0.0/8"})
+
+	_, removed := whitelist.RemoveSubnet("192.168.0.0/16")
+	assert.False(t, removed)
+
+	whitelist, removed = whitelist.RemoveSubnet("10.1.2.3/8")
+	assert.True(t, removed)
+	assert.Equal(t, []WhitelistEntry{portEntry(22, 22, WhitelistProtocolUDP)}, whitelist.Entries)
+}
+
+func TestWhitelist_RemoveExpired(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	whitelist := NewWhitelist([]int64{22}, nil, nil)
+	whitelist, _ = whitelist.Add(WhitelistEntry{Ports: NewPortRange(8080, 8080), Expiry: &past})
+	whitelist, _ = whitelist.Add(WhitelistEntry{Subnet: "10.0.0.0/8", Expiry: &future})
+
+	whitelist, removed := whitelist.RemoveExpired(now)
+	assert.True(t, removed)
+	require.Len(t, whitelist.Entries, 2)
+	assert.Equal(t, "10.0.0.0/8", whitelist.Entries[1].Subnet)
+
+	_, removed = whitelist.RemoveExpired(now)
+	assert.False(t, removed)
+}
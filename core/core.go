@@ -11,6 +11,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
@@ -39,10 +40,14 @@ type InsightsAPI interface {
 }
 
 type ServersAPI interface {
-	Servers() (Servers, http.Header, error)
+	// Servers returns all servers or only the ones changed since the list
+	// identified by tag. ErrNotModified is returned if nothing has changed.
+	Servers(tag string) (Servers, http.Header, error)
 	RecommendedServers(filter ServersFilter, longitude, latitude float64) (Servers, http.Header, error)
 	Server(id int64) (*Server, error)
-	ServersCountries() (Countries, http.Header, error)
+	// ServersCountries returns countries list or ErrNotModified if
+	// it did not change since the list identified by tag.
+	ServersCountries(tag string) (Countries, http.Header, error)
 }
 
 type DefaultAPI struct {
@@ -121,6 +126,11 @@ func (api *DefaultAPI) do(req *http.Request, endpoint string) (*http.Response, e
 	defer resp.Body.Close()
 
 	resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+	if resp.StatusCode == http.StatusNotModified {
+		// there is no content to match against the digest, only the
+		// signature is verified
+		body = nil
+	}
 	if err := api.validatorFunc(resp.Header, body, api.pkVault); err != nil {
 		return nil, fmt.Errorf("validating headers: %w", err)
 	}
@@ -304,17 +314,24 @@ func (api *DefaultAPI) TokenRenew(token string) (*TokenRenewResponse, error) {
 }
 
 // Servers returns servers list
-func (api *DefaultAPI) Servers() (Servers, http.Header, error) {
+func (api *DefaultAPI) Servers(tag string) (Servers, http.Header, error) {
 	req, err := request.NewRequest(http.MethodGet, api.agent, api.Client.BaseURL, ServersURL+ServersURLConnectQuery, "application/json", "", "gzip, deflate", nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	if tag != "" {
+		setIfNoneMatch(req, tag)
+		req.Header.Set("A-IM", DeltaEncoding)
+	}
 
 	resp, err := api.do(req, ServersURL)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header, ErrNotModified
+	}
 
 	var ret Servers
 	if err = json.NewDecoder(resp.Body).Decode(&ret); err != nil {
@@ -324,17 +341,23 @@ func (api *DefaultAPI) Servers() (Servers, http.Header, error) {
 }
 
 // ServersCountries returns server countries list
-func (api *DefaultAPI) ServersCountries() (Countries, http.Header, error) {
+func (api *DefaultAPI) ServersCountries(tag string) (Countries, http.Header, error) {
 	req, err := request.NewRequest(http.MethodGet, api.agent, api.Client.BaseURL, ServersCountriesURL, "application/json", "", "gzip, deflate", nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	if tag != "" {
+		setIfNoneMatch(req, tag)
+	}
 
 	resp, err := api.do(req, ServersCountriesURL)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header, ErrNotModified
+	}
 
 	var ret Countries
 	if err = json.NewDecoder(resp.Body).Decode(&ret); err != nil {
@@ -343,6 +366,26 @@ func (api *DefaultAPI) ServersCountries() (Countries, http.Header, error) {
 	return ret, resp.Header, nil
 }
 
+// setIfNoneMatch makes the request conditional. Tag is quoted unless it is
+// already a valid entity tag.
+func setIfNoneMatch(req *http.Request, tag string) {
+	if !strings.HasSuffix(tag, `"`) {
+		tag = fmt.Sprintf(`"%s"`, tag)
+	}
+	req.Header.Set("If-None-Match", tag)
+}
+
+// IsDeltaEncoded reports whether the response contains only changes
+// since the version given in If-None-Match.
+func IsDeltaEncoded(headers http.Header) bool {
+	for _, im := range strings.Split(headers.Get(HeaderIM), ",") {
+		if strings.TrimSpace(im) == DeltaEncoding {
+			return true
+		}
+	}
+	return false
+}
+
 // RecommendedServers returns recommended servers list
 func (api *DefaultAPI) RecommendedServers(filter ServersFilter, longitude, latitude float64) (Servers, http.Header, error) {
 	var filterQuery string
@@ -14,8 +14,10 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/internal"
 	"github.com/NordSecurity/nordvpn-linux/request"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testresponse "github.com/NordSecurity/nordvpn-linux/test/response"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -218,12 +220,80 @@ func TestDefaultAPI_Servers(t *testing.T) {
 				mockValidator,
 				&subs.Subject[events.DataRequestAPI]{},
 			)
-			_, _, err := api.Servers()
+			_, _, err := api.Servers("")
 			assert.True(t, errors.Is(err, test.err))
 		})
 	}
 }
 
+func TestDefaultAPI_ServersConditional(t *testing.T) {
+	category.Set(t, category.Integration)
+
+	full := []byte(`[{"id":1},{"id":2}]`)
+	delta := []byte(`[{"id":2}]`)
+	tests := []struct {
+		name   string
+		tag    string
+		status int
+		body   []byte
+		// signed is the content X-Digest is computed over
+		signed  []byte
+		delta   bool
+		servers int
+		err     error
+		invalid bool
+	}{
+		{name: "unconditional", status: http.StatusOK, body: full, signed: full, servers: 2},
+		{name: "not modified", tag: "digest", status: http.StatusNotModified, signed: full, err: ErrNotModified},
+		{name: "delta", tag: `"v1"`, status: http.StatusIMUsed, body: delta, signed: delta, delta: true, servers: 1},
+		{name: "delta signed as full list", tag: `"v1"`, status: http.StatusIMUsed, body: delta, signed: full, delta: true, invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				switch test.tag {
+				case "":
+					assert.Empty(t, r.Header.Get("If-None-Match"))
+				case "digest":
+					assert.Equal(t, `"digest"`, r.Header.Get("If-None-Match"))
+				default:
+					assert.Equal(t, test.tag, r.Header.Get("If-None-Match"))
+				}
+				headers, err := testresponse.GenerateValidHeaders(privateKey, test.signed)
+				require.NoError(t, err)
+				for key := range headers {
+					rw.Header().Set(key, headers.Get(key))
+				}
+				if test.delta {
+					rw.Header().Set(HeaderIM, DeltaEncoding)
+				}
+				rw.WriteHeader(test.status)
+				rw.Write(test.body)
+			}))
+			defer server.Close()
+
+			api := NewDefaultAPI(
+				"",
+				"",
+				internal.Development,
+				testresponse.PKVault{PublicKey: publicKey},
+				request.NewHTTPClient(&http.Client{}, server.URL, nil, nil),
+				response.ValidateResponseHeaders,
+				&subs.Subject[events.DataRequestAPI]{},
+			)
+			servers, headers, err := api.Servers(test.tag)
+			if test.invalid {
+				assert.ErrorContains(t, err, "validating headers")
+				return
+			}
+			assert.ErrorIs(t, err, test.err)
+			assert.Len(t, servers, test.servers)
+			assert.Equal(t, test.delta, IsDeltaEncoded(headers))
+		})
+	}
+}
+
 func TestDefaultAPI_Services(t *testing.T) {
 	category.Set(t, category.Integration)
 
@@ -10,6 +10,8 @@ import (
 )
 
 var (
+	// ErrNotModified is returned for 304 HTTP responses to conditional requests.
+	ErrNotModified = errors.New(http.StatusText(http.StatusNotModified))
 	// ErrBadRequest is returned for 400 HTTP responses.
 	ErrBadRequest = errors.New(http.StatusText(http.StatusBadRequest))
 	// ErrMaximumDeviceCount is returned for some of the 400 HTTP responses.
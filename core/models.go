@@ -391,6 +391,16 @@ func (s *Server) IPv4() (netip.Addr, error) {
 	return netip.ParseAddr(s.Station)
 }
 
+// IPv6 returns the first globally routable IPv6 address of the server
+func (s *Server) IPv6() (netip.Addr, error) {
+	for _, ip := range s.IPs() {
+		if ip.Is6() && ip.IsGlobalUnicast() {
+			return ip, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("server %s does not have an IPv6 address", s.Hostname)
+}
+
 func (s *Server) UnmarshalJSON(b []byte) error {
 	// https://stackoverflow.com/questions/52433467/how-to-call-json-unmarshal-inside-unmarshaljson-without-causing-stack-overflow
 	type Hack Server
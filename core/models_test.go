@@ -644,6 +644,20 @@ func TestServer_SupportsIPv6(t *testing.T) {
 	assert.True(t, server.SupportsIPv6())
 }
 
+func TestServer_IPv6(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	var server Server
+	err := json.Unmarshal([]byte(inputTest), &server)
+	assert.NoError(t, err)
+	ip, err := server.IPv6()
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("2a02:5740:1:9::11"), ip)
+
+	_, err = (&Server{Station: "1.1.1.1"}).IPv6()
+	assert.Error(t, err)
+}
+
 func TestServer_IPs(t *testing.T) {
 	category.Set(t, category.Unit)
 
@@ -2,6 +2,11 @@ package core
 
 const (
 	HeaderDigest = "x-digest"
+	HeaderETag   = "ETag"
+	// HeaderIM lists instance manipulations applied to the response (RFC 3229).
+	// Equal to DeltaEncoding when only changes since If-None-Match are returned.
+	HeaderIM      = "IM"
+	DeltaEncoding = "delta"
 
 	// CDNURL is the url for NordCDN
 	CDNURL = "https://downloads.nordcdn.com"
@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"context"
+	"path"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// CallerUnaryInterceptor stores the calling user and method in the
+// request context, so that config changes can be attributed to them.
+func CallerUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(withCaller(ctx, info.FullMethod), req)
+}
+
+// CallerStreamInterceptor is CallerUnaryInterceptor for streaming methods.
+func CallerStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, callerStream{ServerStream: ss, ctx: withCaller(ss.Context(), info.FullMethod)})
+}
+
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s callerStream) Context() context.Context { return s.ctx }
+
+func withCaller(ctx context.Context, method string) context.Context {
+	caller := config.Caller{RPC: path.Base(method), UnknownUID: true}
+	if p, ok := peer.FromContext(ctx); ok && p.AuthInfo != nil {
+		if ucred, err := internal.StringToUcred(p.AuthInfo.AuthType()); err == nil {
+			caller.UID = ucred.Uid
+			caller.UnknownUID = false
+		}
+	}
+	return config.WithCaller(ctx, caller)
+}
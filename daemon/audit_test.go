@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+)
+
+func TestWithCaller_UnknownUID(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	for name, ctx := range map[string]context.Context{
+		"no peer":        context.Background(),
+		"no credentials": peer.NewContext(context.Background(), &peer.Peer{}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			caller, ok := config.CallerFromContext(withCaller(ctx, "/pb.Daemon/SetKillSwitch"))
+			assert.True(t, ok)
+			assert.Equal(t, config.Caller{RPC: "SetKillSwitch", UnknownUID: true}, caller)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/netip"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+const (
+	// captivePortalLoginWindow is how long the user has to log in to the captive portal
+	captivePortalLoginWindow = 5 * time.Minute
+	// captivePortalPollInterval is how often the portal is probed during the login window
+	captivePortalPollInterval = 5 * time.Second
+)
+
+var errCaptivePortalWindowExpired = errors.New("captive portal login window expired")
+
+// CaptivePortalDetector checks whether the network is behind a captive portal
+type CaptivePortalDetector interface {
+	// Detect returns the login page of the captive portal or an empty string if
+	// there is none
+	Detect(context.Context) (string, error)
+	// Hosts resolves the host of the login page
+	Hosts(ctx context.Context, portal string) ([]netip.Addr, error)
+	// Nameservers used to resolve the portal
+	Nameservers() ([]netip.Addr, error)
+}
+
+// passCaptivePortal gives the user time to log in to the captive portal before
+// connecting, because connecting through the portal fails and the kill switch
+// would block the login page anyway.
+func (r *RPC) passCaptivePortal(srv pb.Daemon_ConnectServer) error {
+	if r.captivePortal == nil || r.netw.IsVPNActive() {
+		return nil
+	}
+
+	ctx := srv.Context()
+	portal, err := r.captivePortal.Detect(ctx)
+	if err != nil {
+		// network might be unreachable for other reasons, connect reports them
+		log.Println(internal.WarningPrefix, err)
+		return nil
+	}
+	if portal == "" {
+		return nil
+	}
+
+	log.Println(internal.InfoPrefix, "captive portal detected:", portal)
+	hosts, err := r.captivePortal.Hosts(ctx, portal)
+	if err != nil {
+		log.Println(internal.WarningPrefix, err)
+	}
+	nameservers, err := r.captivePortal.Nameservers()
+	if err != nil {
+		log.Println(internal.WarningPrefix, err)
+	}
+	if err := r.netw.AllowCaptivePortal(hosts, nameservers); err != nil {
+		log.Println(internal.ErrorPrefix, "allowing captive portal login:", err)
+	}
+	defer func() {
+		if err := r.netw.BlockCaptivePortal(); err != nil {
+			log.Println(internal.DeferPrefix, "blocking captive portal:", err)
+		}
+	}()
+
+	data := []string{portal}
+	if err := srv.Send(&pb.Payload{Type: internal.CodeCaptivePortal, Data: data}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return internal.ErrUnhandled
+	}
+	if err := Notify(r.cm, internal.NotificationCaptivePortal, data); err != nil {
+		log.Println(internal.WarningPrefix, err)
+	}
+
+	err = waitForCaptivePortal(ctx, r.captivePortal, captivePortalPollInterval, captivePortalLoginWindow)
+	if errors.Is(err, errCaptivePortalWindowExpired) {
+		// connecting is still attempted, network might have been changed meanwhile
+		log.Println(internal.WarningPrefix, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Println(internal.InfoPrefix, "captive portal passed")
+	return nil
+}
+
+// waitForCaptivePortal probes the network every interval until the portal lets
+// traffic through or the window expires.
+func waitForCaptivePortal(
+	ctx context.Context,
+	detector CaptivePortalDetector,
+	interval time.Duration,
+	window time.Duration,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	expired := time.NewTimer(window)
+	defer expired.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired.C:
+			return errCaptivePortalWindowExpired
+		case <-ticker.C:
+			portal, err := detector.Detect(ctx)
+			if err == nil && portal == "" {
+				return nil
+			}
+		}
+	}
+}
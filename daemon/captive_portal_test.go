@@ -0,0 +1,70 @@
+package daemon
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCaptivePortal reports no captive portal
+type mockCaptivePortal struct{}
+
+func (mockCaptivePortal) Detect(context.Context) (string, error) { return "", nil }
+
+func (mockCaptivePortal) Hosts(context.Context, string) ([]netip.Addr, error) { return nil, nil }
+
+func (mockCaptivePortal) Nameservers() ([]netip.Addr, error) { return nil, nil }
+
+// loginCaptivePortal reports the portal until the user logs in after the given
+// number of probes
+type loginCaptivePortal struct {
+	mockCaptivePortal
+	probes int
+}
+
+func (p *loginCaptivePortal) Detect(context.Context) (string, error) {
+	if p.probes <= 0 {
+		return "", nil
+	}
+	p.probes--
+	return "http://login.example.com", nil
+}
+
+func TestWaitForCaptivePortal(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name   string
+		probes int
+		err    error
+	}{
+		{name: "logged in", probes: 2},
+		{name: "window expired", probes: 1000, err: errCaptivePortalWindowExpired},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := waitForCaptivePortal(
+				context.Background(),
+				&loginCaptivePortal{probes: test.probes},
+				time.Millisecond,
+				100*time.Millisecond,
+			)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestWaitForCaptivePortal_Canceled(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitForCaptivePortal(ctx, &loginCaptivePortal{probes: 1000}, time.Millisecond, time.Minute)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -120,24 +120,31 @@ func (workingNetworker) ConnectionStatus() (networker.ConnectionStatus, error) {
 	return networker.ConnectionStatus{}, nil
 }
 
-func (workingNetworker) EnableFirewall() error                { return nil }
-func (workingNetworker) DisableFirewall() error               { return nil }
-func (workingNetworker) EnableRouting()                       {}
-func (workingNetworker) DisableRouting()                      {}
-func (workingNetworker) PermitIPv6() error                    { return nil }
-func (workingNetworker) DenyIPv6() error                      { return nil }
-func (workingNetworker) SetWhitelist(config.Whitelist) error  { return nil }
-func (workingNetworker) UnsetWhitelist() error                { return nil }
-func (workingNetworker) IsNetworkSet() bool                   { return false }
-func (workingNetworker) SetKillSwitch(config.Whitelist) error { return nil }
-func (workingNetworker) UnsetKillSwitch() error               { return nil }
-func (workingNetworker) Connect(netip.Addr, string) error     { return nil }
-func (workingNetworker) Disconnect() error                    { return nil }
-func (workingNetworker) Refresh(mesh.MachineMap) error        { return nil }
-func (workingNetworker) Allow(mesh.Machine) error             { return nil }
-func (workingNetworker) Block(mesh.Machine) error             { return nil }
-func (workingNetworker) SetVPN(vpn.VPN)                       {}
-func (workingNetworker) LastServerName() string               { return "" }
+func (workingNetworker) EnableFirewall() error                     { return nil }
+func (workingNetworker) DisableFirewall() error                    { return nil }
+func (workingNetworker) EnableRouting()                            {}
+func (workingNetworker) DisableRouting()                           {}
+func (workingNetworker) PermitIPv6() error                         { return nil }
+func (workingNetworker) DenyIPv6() error                           { return nil }
+func (workingNetworker) SetWhitelist(config.Whitelist) error       { return nil }
+func (workingNetworker) UnsetWhitelist() error                     { return nil }
+func (workingNetworker) IsNetworkSet() bool                        { return false }
+func (workingNetworker) SetKillSwitch(config.Whitelist) error      { return nil }
+func (workingNetworker) UnsetKillSwitch() error                    { return nil }
+func (workingNetworker) Connect(netip.Addr, string) error          { return nil }
+func (workingNetworker) Disconnect() error                         { return nil }
+func (workingNetworker) Refresh(mesh.MachineMap) error             { return nil }
+func (workingNetworker) Allow(mesh.Machine) error                  { return nil }
+func (workingNetworker) Block(mesh.Machine) error                  { return nil }
+func (workingNetworker) SetVPN(vpn.VPN)                            {}
+func (workingNetworker) SetIsolation(bool)                         {}
+func (workingNetworker) SetLanDiscovery([]netip.Prefix) error      { return nil }
+func (workingNetworker) UnsetLanDiscovery() error                  { return nil }
+func (workingNetworker) SetReconnectPolicy(config.ReconnectPolicy) {}
+func (workingNetworker) LastServerName() string                    { return "" }
+func (workingNetworker) BlockCaptivePortal() error                 { return nil }
+
+func (workingNetworker) AllowCaptivePortal([]netip.Addr, []netip.Addr) error { return nil }
 
 type UniqueAddress struct{}
 
@@ -160,24 +167,31 @@ func (failingNetworker) ConnectionStatus() (networker.ConnectionStatus, error) {
 	return networker.ConnectionStatus{}, nil
 }
 
-func (failingNetworker) EnableFirewall() error                { return errOnPurpose }
-func (failingNetworker) DisableFirewall() error               { return errOnPurpose }
-func (failingNetworker) EnableRouting()                       {}
-func (failingNetworker) DisableRouting()                      {}
-func (failingNetworker) PermitIPv6() error                    { return errOnPurpose }
-func (failingNetworker) DenyIPv6() error                      { return errOnPurpose }
-func (failingNetworker) SetWhitelist(config.Whitelist) error  { return errOnPurpose }
-func (failingNetworker) UnsetWhitelist() error                { return errOnPurpose }
-func (failingNetworker) IsNetworkSet() bool                   { return false }
-func (failingNetworker) SetKillSwitch(config.Whitelist) error { return errOnPurpose }
-func (failingNetworker) UnsetKillSwitch() error               { return errOnPurpose }
-func (failingNetworker) Connect(netip.Addr, string) error     { return errOnPurpose }
-func (failingNetworker) Disconnect() error                    { return errOnPurpose }
-func (failingNetworker) Refresh(mesh.MachineMap) error        { return errOnPurpose }
-func (failingNetworker) Allow(mesh.Machine) error             { return errOnPurpose }
-func (failingNetworker) Block(mesh.Machine) error             { return errOnPurpose }
-func (failingNetworker) SetVPN(vpn.VPN)                       {}
-func (failingNetworker) LastServerName() string               { return "" }
+func (failingNetworker) EnableFirewall() error                     { return errOnPurpose }
+func (failingNetworker) DisableFirewall() error                    { return errOnPurpose }
+func (failingNetworker) EnableRouting()                            {}
+func (failingNetworker) DisableRouting()                           {}
+func (failingNetworker) PermitIPv6() error                         { return errOnPurpose }
+func (failingNetworker) DenyIPv6() error                           { return errOnPurpose }
+func (failingNetworker) SetWhitelist(config.Whitelist) error       { return errOnPurpose }
+func (failingNetworker) UnsetWhitelist() error                     { return errOnPurpose }
+func (failingNetworker) IsNetworkSet() bool                        { return false }
+func (failingNetworker) SetKillSwitch(config.Whitelist) error      { return errOnPurpose }
+func (failingNetworker) UnsetKillSwitch() error                    { return errOnPurpose }
+func (failingNetworker) Connect(netip.Addr, string) error          { return errOnPurpose }
+func (failingNetworker) Disconnect() error                         { return errOnPurpose }
+func (failingNetworker) Refresh(mesh.MachineMap) error             { return errOnPurpose }
+func (failingNetworker) Allow(mesh.Machine) error                  { return errOnPurpose }
+func (failingNetworker) Block(mesh.Machine) error                  { return errOnPurpose }
+func (failingNetworker) SetVPN(vpn.VPN)                            {}
+func (failingNetworker) SetIsolation(bool)                         {}
+func (failingNetworker) SetLanDiscovery([]netip.Prefix) error      { return errOnPurpose }
+func (failingNetworker) UnsetLanDiscovery() error                  { return errOnPurpose }
+func (failingNetworker) SetReconnectPolicy(config.ReconnectPolicy) {}
+func (failingNetworker) LastServerName() string                    { return "" }
+func (failingNetworker) BlockCaptivePortal() error                 { return errOnPurpose }
+
+func (failingNetworker) AllowCaptivePortal([]netip.Addr, []netip.Addr) error { return errOnPurpose }
 
 func TestConnect(t *testing.T) {
 	category.Set(t, category.Route)
@@ -27,6 +27,9 @@ const (
 	// InsightsFilePath defines filename of insights file
 	InsightsFilePath = internal.DatFilesPath + "insights.dat"
 
+	// ServerHistoryFilePath defines path to past connection outcomes file
+	ServerHistoryFilePath = internal.DatFilesPath + "history.dat"
+
 	// VersionFilePath defines filename of latest available version file
 	VersionFilePath = internal.DatFilesPath + "version.dat"
 
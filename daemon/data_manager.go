@@ -23,15 +23,26 @@ type InsightsDataManager interface {
 type DataManager struct {
 	appData      AppData
 	countryData  CountryData
+	historyData  ServerHistoryData
 	insightsData InsightsData
 	serversData  ServersData
 	versionData  VersionData
 	mu           sync.Mutex
 }
 
-func NewDataManager(insightsFilePath, serversFilePath, countryFilePath, versionFilePath string) *DataManager {
+func NewDataManager(
+	insightsFilePath,
+	serversFilePath,
+	countryFilePath,
+	versionFilePath,
+	historyFilePath string,
+) *DataManager {
 	return &DataManager{
-		countryData:  CountryData{filePath: countryFilePath},
+		countryData: CountryData{filePath: countryFilePath},
+		historyData: ServerHistoryData{
+			filePath: historyFilePath,
+			Servers:  map[string]ServerHistory{},
+		},
 		insightsData: InsightsData{filePath: insightsFilePath},
 		serversData:  ServersData{filePath: serversFilePath},
 		versionData:  VersionData{filePath: versionFilePath},
@@ -54,6 +65,12 @@ func (dm *DataManager) LoadData() error {
 	if err := dm.versionData.load(); err != nil {
 		return fmt.Errorf("loading version data: %w", err)
 	}
+	// history is created on the first connection
+	if dm.historyData.exists() {
+		if err := dm.historyData.load(); err != nil {
+			return fmt.Errorf("loading server history data: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -109,10 +126,33 @@ func (dm *DataManager) IsServersDataValid() bool {
 	return dm.serversData.isValid()
 }
 
+// GetServersData reads the full servers list from disk. Prefer GetServersSummary
+// or GetServer when details needed to connect are not used.
 func (dm *DataManager) GetServersData() ServersData {
 	dm.mu.Lock()
 	defer dm.mu.Unlock()
-	return dm.serversData
+	data := dm.serversData
+	servers, err := data.all()
+	if err != nil {
+		log.Println(internal.WarningPrefix, "reading servers data:", err)
+	}
+	data.Servers = servers
+	return data
+}
+
+// GetServersSummary returns servers with only their IDs, hostnames, statuses,
+// locations, groups and technologies.
+func (dm *DataManager) GetServersSummary() core.Servers {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.serversData.summaries()
+}
+
+// GetServer with the given hostname without reading the whole servers list.
+func (dm *DataManager) GetServer(hostname string) (core.Server, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.serversData.byHostname(hostname)
 }
 
 func (dm *DataManager) SetServersData(updatedAt time.Time, servers core.Servers, hash string) error {
@@ -127,7 +167,10 @@ func (dm *DataManager) SetServersData(updatedAt time.Time, servers core.Servers,
 func (dm *DataManager) UpdateServerPenalty(s core.Server) error {
 	dm.mu.Lock()
 	defer dm.mu.Unlock()
-	servers := dm.serversData.Servers
+	servers, err := dm.serversData.all()
+	if err != nil {
+		return err
+	}
 	for idx, server := range servers {
 		if s.ID == server.ID {
 			servers[idx].Status = s.Status
@@ -145,7 +188,10 @@ func (dm *DataManager) UpdateServerPenalty(s core.Server) error {
 func (dm *DataManager) SetServerStatus(s core.Server, status core.Status) error {
 	dm.mu.Lock()
 	defer dm.mu.Unlock()
-	servers := dm.serversData.Servers
+	servers, err := dm.serversData.all()
+	if err != nil {
+		return err
+	}
 	for idx, server := range servers {
 		if s.ID == server.ID {
 			servers[idx].Status = status
@@ -189,3 +235,105 @@ func (dm *DataManager) SetVersionData(version semver.Version, newerAvailable boo
 		log.Println(internal.WarningPrefix, err)
 	}
 }
+
+// GetServerHistoryData returns a copy of connection outcomes of all servers.
+func (dm *DataManager) GetServerHistoryData() ServerHistoryData {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	servers := make(map[string]ServerHistory, len(dm.historyData.Servers))
+	for hostname, history := range dm.historyData.Servers {
+		servers[hostname] = history
+	}
+	return ServerHistoryData{filePath: dm.historyData.filePath, Servers: servers}
+}
+
+// AddConnectionOutcome records a connection attempt to the server and adjusts
+// its penalty accordingly.
+func (dm *DataManager) AddConnectionOutcome(hostname string, success bool, connectTime time.Duration) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	before := dm.historyData.Servers[hostname]
+	after := before
+	if success {
+		after.Successes++
+		after.ConnectTime += connectTime
+		after.ConnectedAt = time.Now()
+	} else {
+		after.Failures++
+	}
+	if err := dm.setServerHistory(hostname, after); err != nil {
+		return err
+	}
+	return dm.updateHistoryPenalty(hostname, before, after)
+}
+
+// AddDisconnectOutcome records statistics of a finished connection to the
+// server. Connections which ended in failure, e.g. a dropped tunnel, are
+// counted as failures and adjust the server penalty.
+func (dm *DataManager) AddDisconnectOutcome(
+	hostname string,
+	uptime time.Duration,
+	transferred uint64,
+	reason string,
+	failed bool,
+) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	before := dm.historyData.Servers[hostname]
+	after := before
+	after.Uptime += uptime
+	after.Transferred += transferred
+	after.DisconnectReason = reason
+	if failed {
+		after.Failures++
+	}
+	if err := dm.setServerHistory(hostname, after); err != nil {
+		return err
+	}
+	return dm.updateHistoryPenalty(hostname, before, after)
+}
+
+func (dm *DataManager) setServerHistory(hostname string, history ServerHistory) error {
+	if dm.historyData.Servers == nil {
+		dm.historyData.Servers = map[string]ServerHistory{}
+	}
+	dm.historyData.Servers[hostname] = history
+	return dm.historyData.save()
+}
+
+// updateHistoryPenalty replaces history component of the server penalty so that
+// new outcomes are taken into account without waiting for the servers job.
+func (dm *DataManager) updateHistoryPenalty(hostname string, before, after ServerHistory) error {
+	diff := historyPenalty(after.Successes, after.Failures) -
+		historyPenalty(before.Successes, before.Failures)
+	if diff == 0 {
+		return nil
+	}
+	if index := dm.serversData.index; index != nil {
+		// avoid reading the whole list for servers which are no longer there
+		if _, ok := index.hostnames[hostname]; !ok {
+			return nil
+		}
+	}
+	servers, err := dm.serversData.all()
+	if err != nil {
+		return err
+	}
+	found := false
+	for idx, server := range servers {
+		if server.Hostname == hostname {
+			servers[idx].PartialPenalty += diff
+			servers[idx].Penalty += diff
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		return servers[i].Penalty < servers[j].Penalty
+	})
+	dm.serversData.Servers = servers
+	return dm.serversData.save()
+}
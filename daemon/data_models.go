@@ -93,24 +93,53 @@ func (data *CountryData) isValid() bool {
 	return data.UpdatedAt.Add(6 * time.Hour).After(time.Now())
 }
 
-type ServersData struct {
-	filePath  string
-	UpdatedAt time.Time
-	Servers   core.Servers
-	Hash      string
+// ServerHistory holds outcomes of past connections to a single server.
+type ServerHistory struct {
+	Successes int
+	Failures  int
+	// ConnectTime is the total time spent establishing successful connections
+	ConnectTime time.Duration
+	// Uptime is the total time spent connected
+	Uptime time.Duration
+	// Transferred is the total amount of received and sent bytes
+	Transferred      uint64
+	DisconnectReason string
+	ConnectedAt      time.Time
+}
+
+// AverageConnectTime returns the mean time it took to connect to the server.
+func (h ServerHistory) AverageConnectTime() time.Duration {
+	if h.Successes == 0 {
+		return 0
+	}
+	return h.ConnectTime / time.Duration(h.Successes)
+}
+
+// AverageThroughput returns the mean amount of bytes transferred per second.
+func (h ServerHistory) AverageThroughput() uint64 {
+	seconds := uint64(h.Uptime / time.Second)
+	if seconds == 0 {
+		return 0
+	}
+	return h.Transferred / seconds
 }
 
-func (data *ServersData) load() error {
+type ServerHistoryData struct {
+	filePath string
+	// Servers are mapped by their hostnames
+	Servers map[string]ServerHistory
+}
+
+func (data *ServerHistoryData) load() error {
 	content, err := internal.FileRead(data.filePath)
 	if err != nil {
 		return err
 	}
-
 	decoder := gob.NewDecoder(bytes.NewReader(content))
 	return decoder.Decode(data)
 }
 
-func (data *ServersData) save() error {
+func (data *ServerHistoryData) save() error {
 	buffer := &bytes.Buffer{}
 	encoder := gob.NewEncoder(buffer)
 	err := encoder.Encode(data)
@@ -125,16 +154,10 @@ func (data *ServersData) save() error {
 	return nil
 }
 
-func (data *ServersData) exists() bool {
+func (data *ServerHistoryData) exists() bool {
 	return internal.FileExists(data.filePath)
 }
 
-func (data *ServersData) isValid() bool {
-	// in order not to override servers.dat - uncomment
-	// return true
-	return data.UpdatedAt.Add(1 * time.Hour).After(time.Now())
-}
-
 func (data *VersionData) load() error {
 	content, err := internal.FileRead(data.filePath)
 	if err != nil {
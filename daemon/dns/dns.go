@@ -5,6 +5,7 @@ package dns
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -14,6 +15,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/events"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 )
@@ -67,11 +69,13 @@ backed up and modified directly by NordVPN.
 */
 type DefaultSetter struct {
 	publisher events.Publisher[string]
+	journal   journal.Recorder
 }
 
-func NewSetter(publisher events.Publisher[string]) *DefaultSetter {
+func NewSetter(publisher events.Publisher[string], journal journal.Recorder) *DefaultSetter {
 	return &DefaultSetter{
 		publisher: publisher,
+		journal:   journal,
 	}
 }
 
@@ -90,6 +94,7 @@ func (d *DefaultSetter) Set(iface string, nameservers []string) error {
 	if len(nameservers) == 0 {
 		return errors.New("nameservers not provided")
 	}
+	d.journal.Record(journal.KindDNS, iface, nil)
 
 	if internal.IsServiceActive(serviceSystemdResolved) {
 		d.publisher.Publish("using systemd-resolved")
@@ -113,6 +118,19 @@ func (d *DefaultSetter) Set(iface string, nameservers []string) error {
 
 // Unset DNS from a backup and remove the backup on success.
 func (d *DefaultSetter) Unset(iface string) error {
+	if err := d.unset(iface); err != nil {
+		return err
+	}
+	d.journal.Forget(journal.KindDNS, iface)
+	return nil
+}
+
+// Revert DNS of a journaled interface
+func (d *DefaultSetter) Revert(iface string, _ json.RawMessage) error {
+	return d.Unset(iface)
+}
+
+func (d *DefaultSetter) unset(iface string) error {
 	d.publisher.Publish("unsetting DNS")
 	if err := internal.FileUnlock(resolvconfFilePath); err != nil {
 		log.Println(internal.WarningPrefix, err)
@@ -0,0 +1,26 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultSetter_Journal(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	recorder := &testjournal.Memory{}
+	setter := NewSetter(&subs.Subject[string]{}, recorder)
+	assert.Error(t, setter.Set("nordlynx", nil))
+	assert.Empty(t, recorder.Entries, "failed change must not be journaled")
+
+	recorder.Record(journal.KindDNS, "nordlynx", nil)
+	recorder.Record(journal.KindDNS, "tun0", nil)
+	assert.NoError(t, setter.Revert("nordlynx", nil))
+	assert.Equal(t, []string{"tun0"}, recorder.Keys(journal.KindDNS))
+}
@@ -2,12 +2,14 @@ package dns
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/netip"
 	"os"
 	"strings"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 )
 
@@ -33,11 +35,13 @@ type HostnameSetter interface {
 // HostsFileSetter modifies the hosts file in order to add custom DNS
 type HostsFileSetter struct {
 	filePath string
+	journal  journal.Recorder
 }
 
-func NewHostsFileSetter(filePath string) *HostsFileSetter {
+func NewHostsFileSetter(filePath string, journal journal.Recorder) *HostsFileSetter {
 	return &HostsFileSetter{
 		filePath: filePath,
+		journal:  journal,
 	}
 }
 
@@ -46,6 +50,7 @@ func (h Host) String() string {
 }
 
 func (s *HostsFileSetter) SetHosts(hosts Hosts) error {
+	s.journal.Record(journal.KindHosts, s.filePath, nil)
 	file, content, err := openReadAndTruncateFile(s.filePath)
 	if err != nil {
 		return err
@@ -75,7 +80,16 @@ func (s *HostsFileSetter) UnsetHosts() error {
 		return fmt.Errorf("writing to the hosts file: %w", err)
 	}
 
-	return file.Close()
+	if err := file.Close(); err != nil {
+		return err
+	}
+	s.journal.Forget(journal.KindHosts, s.filePath)
+	return nil
+}
+
+// Revert removes journaled hosts
+func (s *HostsFileSetter) Revert(string, json.RawMessage) error {
+	return s.UnsetHosts()
 }
 
 func openReadAndTruncateFile(filePath string) (*os.File, []byte, error) {
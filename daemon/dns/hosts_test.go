@@ -6,7 +6,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -210,7 +212,7 @@ func TestHostFileSetter_SetHosts(t *testing.T) {
 			require.NoError(t, err)
 			defer os.Remove(filename)
 
-			setter := NewHostsFileSetter(filename)
+			setter := NewHostsFileSetter(filename, &testjournal.Memory{})
 			err = setter.SetHosts(test.hosts)
 			assert.NoError(t, err)
 
@@ -237,7 +239,7 @@ func TestHostFileSetter_UnsetHosts(t *testing.T) {
 			require.NoError(t, err)
 			defer os.Remove(filename)
 
-			setter := NewHostsFileSetter(filename)
+			setter := NewHostsFileSetter(filename, &testjournal.Memory{})
 			err = setter.UnsetHosts()
 			assert.NoError(t, err)
 
@@ -247,3 +249,27 @@ func TestHostFileSetter_UnsetHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestHostFileSetter_Journal(t *testing.T) {
+	category.Set(t, category.File)
+
+	filename := "TestHostFileSetter_Journal.hosts"
+	require.NoError(t, os.WriteFile(filename, []byte("127.0.0.1\tlocalhost\n"), 0644))
+	defer os.Remove(filename)
+
+	recorder := &testjournal.Memory{}
+	setter := NewHostsFileSetter(filename, recorder)
+	require.NoError(t, setter.SetHosts(Hosts{{
+		IP:         netip.MustParseAddr("100.64.0.2"),
+		FQDN:       "peer.nord",
+		DomainName: "peer",
+	}}))
+	assert.Equal(t, []string{filename}, recorder.Keys(journal.KindHosts))
+
+	// simulate a crash by reverting with a new setter
+	require.NoError(t, NewHostsFileSetter(filename, recorder).Revert(filename, nil))
+	actual, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.NotContains(t, string(actual), "peer.nord")
+	assert.Empty(t, recorder.Entries)
+}
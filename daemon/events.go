@@ -154,9 +154,9 @@ func (s *SettingsEvents) Publish(cfg config.Config) {
 	s.ThreatProtectionLite.Publish(cfg.AutoConnectData.ThreatProtectionLite)
 	s.Protocol.Publish(cfg.AutoConnectData.Protocol)
 	s.Whitelist.Publish(events.DataWhitelist{
-		TCPPorts: len(cfg.AutoConnectData.Whitelist.Ports.TCP),
-		UDPPorts: len(cfg.AutoConnectData.Whitelist.Ports.UDP),
-		Subnets:  len(cfg.AutoConnectData.Whitelist.Subnets),
+		TCPPorts: cfg.AutoConnectData.Whitelist.PortCount(config.WhitelistProtocolTCP),
+		UDPPorts: cfg.AutoConnectData.Whitelist.PortCount(config.WhitelistProtocolUDP),
+		Subnets:  len(cfg.AutoConnectData.Whitelist.Subnets()),
 	})
 	s.Meshnet.Publish(cfg.Mesh)
 	s.Ipv6.Publish(cfg.IPv6)
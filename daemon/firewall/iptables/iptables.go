@@ -141,7 +141,7 @@ func ruleToIPTables(rule firewall.Rule, module string, stateFlag string, chainPr
 	for _, iface := range rule.Interfaces {
 		for _, remoteNetwork := range rule.RemoteNetworks {
 			for _, localNetwork := range rule.LocalNetworks {
-				for _, pRange := range portsToPortRanges(rule.Ports) {
+				for _, pRange := range rulePortRanges(rule) {
 					for _, protocol := range rule.Protocols {
 						for _, input := range toInputSlice(rule.Direction) {
 							for _, icmpv6Type := range defaultIcmpv6(rule.Icmpv6Types) {
@@ -200,6 +200,15 @@ func defaultIcmpv6(icmp6Types []int) []int {
 	return []int{0}
 }
 
+// rulePortRanges merges single ports and port ranges of the rule
+func rulePortRanges(rule firewall.Rule) []portRange {
+	ranges := portsToPortRanges(rule.Ports)
+	for _, r := range rule.PortRanges {
+		ranges = append(ranges, portRange{min: r.Min, max: r.Max})
+	}
+	return ranges
+}
+
 func portsToPortRanges(ports []int) []portRange {
 	if len(ports) == 0 {
 		return nil
@@ -266,7 +275,7 @@ func generateNonEmptyRule(rule firewall.Rule) firewall.Rule {
 	if rule.Interfaces == nil {
 		rule.Interfaces = append(rule.Interfaces, net.Interface{})
 	}
-	if rule.Ports == nil {
+	if rule.Ports == nil && rule.PortRanges == nil {
 		rule.Ports = append(rule.Ports, 0)
 	}
 	if rule.Marks == nil {
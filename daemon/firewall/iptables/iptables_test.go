@@ -406,6 +406,29 @@ func TestRuleToIPTables(t *testing.T) {
 				"OUTPUT -o eth0 -d 2606:4700:4700::1111/128 -m comment --comment nordvpn -j DROP",
 			},
 		},
+		{
+			name: "port ranges",
+			rule: firewall.Rule{
+				Direction:  firewall.Inbound,
+				Interfaces: []net.Interface{{Name: "eth0"}},
+				Protocols:  []string{"tcp"},
+				Ports:      []int{22},
+				PortRanges: []firewall.PortRange{{Min: 3000, Max: 3100}},
+				Allow:      true,
+			},
+			ipv4TablesRules: []string{
+				"INPUT -i eth0 -p tcp --sport 22:22 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --dport 22:22 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --sport 3000:3100 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --dport 3000:3100 -m comment --comment nordvpn -j ACCEPT",
+			},
+			ipv6TablesRules: []string{
+				"INPUT -i eth0 -p tcp --sport 22:22 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --dport 22:22 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --sport 3000:3100 -m comment --comment nordvpn -j ACCEPT",
+				"INPUT -i eth0 -p tcp --dport 3000:3100 -m comment --comment nordvpn -j ACCEPT",
+			},
+		},
 		{
 			name: "multiple interfaces, protocols, ports and networks",
 			rule: firewall.Rule{
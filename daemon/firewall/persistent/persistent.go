@@ -0,0 +1,278 @@
+// Package persistent implements the kill switch which is installed at boot, before
+// the daemon starts, and keeps blocking traffic when the daemon is not running.
+package persistent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+const (
+	// Chain holds the persistent kill switch rules. It is not managed by the firewall
+	// agents, so rules are not removed when the daemon cleans up or dies.
+	Chain = "NORDVPN-PERSISTENT"
+	// RulesDir stores rulesets restored by nordvpn-killswitch.service at boot
+	RulesDir = internal.AppDataPath + "killswitch/"
+)
+
+// hooks are the built-in chains which jump to Chain
+var hooks = []string{"INPUT", "OUTPUT"}
+
+// Ruleset describes the traffic allowed while everything else is blocked.
+type Ruleset struct {
+	// Interfaces are VPN tunnels
+	Interfaces []string
+	// Endpoints are VPN servers which can be reached outside of the tunnel
+	Endpoints []netip.Addr
+	// Fwmark is set on the packets of the daemon and VPN implementations
+	Fwmark uint32
+}
+
+// Restore returns iptables-restore input for IPv4 or IPv6 rules. Jumps to the chain
+// are inserted at the top, so that traffic is blocked before any other rule applies.
+func (r Ruleset) Restore(ipv6 bool) string {
+	var b strings.Builder
+	add := func(format string, args ...any) {
+		fmt.Fprintf(&b, "-A %s "+format+"\n", append([]any{Chain}, args...)...)
+	}
+
+	b.WriteString("*filter\n")
+	// declared chain is flushed by iptables-restore even with --noflush
+	fmt.Fprintf(&b, ":%s - [0:0]\n", Chain)
+	add("-i lo -j RETURN")
+	add("-o lo -j RETURN")
+	for _, iface := range r.Interfaces {
+		add("-i %s -j RETURN", iface)
+		add("-o %s -j RETURN", iface)
+	}
+	if r.Fwmark != 0 {
+		add("-m mark --mark %#x -j RETURN", r.Fwmark)
+		add("-m connmark --mark %#x -j RETURN", r.Fwmark)
+	}
+	if ipv6 {
+		// neighbor discovery and DHCPv6 keep the link usable
+		for _, icmpType := range []int{133, 134, 135, 136} {
+			add("-p ipv6-icmp --icmpv6-type %d -j RETURN", icmpType)
+		}
+		add("-p udp --sport 546 --dport 547 -j RETURN")
+		add("-p udp --sport 547 --dport 546 -j RETURN")
+	} else {
+		add("-p udp --sport 68 --dport 67 -j RETURN")
+		add("-p udp --sport 67 --dport 68 -j RETURN")
+	}
+	for _, endpoint := range r.Endpoints {
+		if endpoint.Is6() != ipv6 {
+			continue
+		}
+		add("-s %s -j RETURN", endpoint)
+		add("-d %s -j RETURN", endpoint)
+	}
+	add("-j DROP")
+	for _, hook := range hooks {
+		fmt.Fprintf(&b, "-I %s 1 -j %s\n", hook, Chain)
+	}
+	b.WriteString("COMMIT\n")
+	return b.String()
+}
+
+// KillSwitch installs the persistent ruleset into the system and stores it, so that
+// it is restored at boot.
+type KillSwitch struct {
+	dir        string
+	iptables   []string
+	interfaces []string
+	fwmark     uint32
+	run        func(stdin []byte, name string, args ...string) error
+	mu         sync.Mutex
+}
+
+// New returns KillSwitch managing rules for the given iptables commands.
+func New(dir string, iptables []string, interfaces []string, fwmark uint32) *KillSwitch {
+	return &KillSwitch{
+		dir:        dir,
+		iptables:   iptables,
+		interfaces: interfaces,
+		fwmark:     fwmark,
+		run:        run,
+	}
+}
+
+func run(stdin []byte, name string, args ...string) error {
+	// #nosec G204 -- only iptables commands are executed
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (k *KillSwitch) rulesPath(iptables string) string {
+	return filepath.Join(k.dir, iptables+".rules")
+}
+
+// Enable stores the ruleset allowing the given endpoints and installs it. Calling it
+// again replaces the allowed endpoints without unblocking the traffic.
+func (k *KillSwitch) Enable(endpoints []netip.Addr) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.enable(endpoints)
+}
+
+func (k *KillSwitch) enable(endpoints []netip.Addr) error {
+	ruleset := Ruleset{Interfaces: k.interfaces, Endpoints: endpoints, Fwmark: k.fwmark}
+	if err := os.MkdirAll(k.dir, 0700); err != nil {
+		return fmt.Errorf("creating rules directory: %w", err)
+	}
+	for _, iptables := range k.iptables {
+		rules := ruleset.Restore(iptables == "ip6tables")
+		if err := internal.FileWrite(k.rulesPath(iptables), []byte(rules), internal.PermUserRW); err != nil {
+			return fmt.Errorf("storing %s rules: %w", iptables, err)
+		}
+	}
+	return k.install()
+}
+
+// Install applies stored rules to the system. It is used when the daemon starts,
+// because the daemon might have been started without rebooting. Rules are
+// regenerated for the stored endpoints, as fwmark or interfaces might have
+// changed since they were stored.
+func (k *KillSwitch) Install() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var enabled bool
+	var endpoints []netip.Addr
+	for _, iptables := range k.iptables {
+		rules, err := os.ReadFile(k.rulesPath(iptables))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s rules: %w", iptables, err)
+		}
+		enabled = true
+		endpoints = append(endpoints, storedEndpoints(rules)...)
+	}
+	if !enabled {
+		return nil
+	}
+	return k.enable(endpoints)
+}
+
+// storedEndpoints returns endpoints allowed by the stored rules.
+func storedEndpoints(rules []byte) []netip.Addr {
+	var endpoints []netip.Addr
+	for _, line := range strings.Split(string(rules), "\n") {
+		// each endpoint is allowed in both directions, destination is enough
+		fields := strings.Fields(line)
+		if len(fields) != 6 || fields[0] != "-A" || fields[1] != Chain || fields[2] != "-d" {
+			continue
+		}
+		if endpoint, err := netip.ParseAddr(fields[3]); err == nil {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func (k *KillSwitch) install() error {
+	for _, iptables := range k.iptables {
+		rules, err := os.ReadFile(k.rulesPath(iptables))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s rules: %w", iptables, err)
+		}
+		// when installed at boot, the chain is the first one and the daemon
+		// inserts its rules in front of it later. Jumps are appended at runtime to
+		// keep the same order with the daemon rules which are already present.
+		// Chain is flushed in place if hooked, so traffic is not unblocked meanwhile.
+		rules = rewriteJumps(rules, !k.isHooked(iptables))
+		if err := k.run(rules, iptables+"-restore", "--noflush"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *KillSwitch) isHooked(iptables string) bool {
+	for _, hook := range hooks {
+		if k.run(nil, iptables, "-w", "-C", hook, "-j", Chain) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// rewriteJumps changes jump insertions into appends or removes them.
+func rewriteJumps(rules []byte, appendJumps bool) []byte {
+	var lines []string
+	for _, line := range strings.Split(string(rules), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "-I" {
+			lines = append(lines, line)
+			continue
+		}
+		if appendJumps {
+			lines = append(lines, fmt.Sprintf("-A %s -j %s", fields[1], Chain))
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// Release removes the rules from the system and from the boot configuration.
+func (k *KillSwitch) Release() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, iptables := range k.iptables {
+		for _, hook := range hooks {
+			// jump is duplicated when the boot rules are restored more than once
+			for {
+				if err := k.run(nil, iptables, "-w", "-D", hook, "-j", Chain); err != nil {
+					break
+				}
+			}
+		}
+		if k.run(nil, iptables, "-w", "-L", Chain, "-n") == nil {
+			if err := k.run(nil, iptables, "-w", "-F", Chain); err != nil {
+				return err
+			}
+			if err := k.run(nil, iptables, "-w", "-X", Chain); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(k.rulesPath(iptables)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing %s rules: %w", iptables, err)
+		}
+	}
+	return nil
+}
+
+// IsEnabled reports whether rules are stored for the boot. Rules removed by the
+// user while the daemon was not running are treated as released.
+func (k *KillSwitch) IsEnabled() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, iptables := range k.iptables {
+		if _, err := os.Stat(k.rulesPath(iptables)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package persistent
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleset_Restore(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	ruleset := Ruleset{
+		Interfaces: []string{"nordlynx"},
+		Endpoints:  []netip.Addr{netip.MustParseAddr("1.2.3.4"), netip.MustParseAddr("2001:db8::1")},
+		Fwmark:     0xe1f1,
+	}
+
+	expected := `*filter
+:NORDVPN-PERSISTENT - [0:0]
+-A NORDVPN-PERSISTENT -i lo -j RETURN
+-A NORDVPN-PERSISTENT -o lo -j RETURN
+-A NORDVPN-PERSISTENT -i nordlynx -j RETURN
+-A NORDVPN-PERSISTENT -o nordlynx -j RETURN
+-A NORDVPN-PERSISTENT -m mark --mark 0xe1f1 -j RETURN
+-A NORDVPN-PERSISTENT -m connmark --mark 0xe1f1 -j RETURN
+-A NORDVPN-PERSISTENT -p udp --sport 68 --dport 67 -j RETURN
+-A NORDVPN-PERSISTENT -p udp --sport 67 --dport 68 -j RETURN
+-A NORDVPN-PERSISTENT -s 1.2.3.4 -j RETURN
+-A NORDVPN-PERSISTENT -d 1.2.3.4 -j RETURN
+-A NORDVPN-PERSISTENT -j DROP
+-I INPUT 1 -j NORDVPN-PERSISTENT
+-I OUTPUT 1 -j NORDVPN-PERSISTENT
+COMMIT
+`
+	assert.Equal(t, expected, ruleset.Restore(false))
+
+	ipv6 := ruleset.Restore(true)
+	assert.Contains(t, ipv6, "-A NORDVPN-PERSISTENT -d 2001:db8::1 -j RETURN\n")
+	assert.Contains(t, ipv6, "--icmpv6-type 135")
+	assert.NotContains(t, ipv6, "1.2.3.4")
+}
+
+// fakeIPTables records executed commands and keeps track of the chain jumps
+type fakeIPTables struct {
+	commands []string
+	restored []string
+	jumps    int
+}
+
+func (f *fakeIPTables) run(stdin []byte, name string, args ...string) error {
+	command := name + " " + strings.Join(args, " ")
+	f.commands = append(f.commands, command)
+	switch {
+	case strings.HasSuffix(name, "-restore"):
+		f.restored = append(f.restored, string(stdin))
+		f.jumps += strings.Count(string(stdin), "-j "+Chain)
+	case strings.Contains(command, " -C "):
+		if f.jumps == 0 {
+			return errors.New("no such rule")
+		}
+	case strings.Contains(command, " -D "):
+		if f.jumps == 0 {
+			return errors.New("no such rule")
+		}
+		f.jumps--
+	case strings.Contains(command, " -L "):
+		if len(f.restored) == 0 {
+			return errors.New("no such chain")
+		}
+	}
+	return nil
+}
+
+func TestKillSwitch(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	dir := t.TempDir()
+	fake := &fakeIPTables{}
+	ks := New(dir, []string{"iptables"}, []string{"nordlynx"}, 0xe1f1)
+	ks.run = fake.run
+	assert.False(t, ks.IsEnabled())
+
+	require.NoError(t, ks.Enable([]netip.Addr{netip.MustParseAddr("1.2.3.4")}))
+	assert.True(t, ks.IsEnabled())
+	stored, err := os.ReadFile(filepath.Join(dir, "iptables.rules"))
+	require.NoError(t, err)
+	assert.Contains(t, string(stored), "-d 1.2.3.4 -j RETURN")
+	assert.Contains(t, string(stored), "-I OUTPUT 1 -j NORDVPN-PERSISTENT")
+	// rules installed by the daemon stay in front of the chain
+	assert.Contains(t, fake.restored[0], "-A OUTPUT -j NORDVPN-PERSISTENT")
+	assert.NotContains(t, fake.restored[0], "-I ")
+	assert.Equal(t, 2, fake.jumps)
+
+	// updating endpoints keeps the jumps as they are
+	require.NoError(t, ks.Enable([]netip.Addr{netip.MustParseAddr("5.6.7.8")}))
+	require.Len(t, fake.restored, 2)
+	assert.Contains(t, fake.restored[1], "-d 5.6.7.8 -j RETURN")
+	assert.NotContains(t, fake.restored[1], "-j "+Chain)
+	assert.Equal(t, 2, fake.jumps)
+
+	require.NoError(t, ks.Release())
+	assert.False(t, ks.IsEnabled())
+	assert.Equal(t, 0, fake.jumps)
+	assert.Contains(t, fake.commands, "iptables -w -X NORDVPN-PERSISTENT")
+}
+
+func TestKillSwitch_InstallWithChangedFwmark(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	dir := t.TempDir()
+	fake := &fakeIPTables{}
+	ks := New(dir, []string{"iptables", "ip6tables"}, []string{"nordlynx"}, 0xe1f1)
+	ks.run = fake.run
+	require.NoError(t, ks.Enable([]netip.Addr{
+		netip.MustParseAddr("1.2.3.4"),
+		netip.MustParseAddr("2001:db8::1"),
+	}))
+
+	// daemon is restarted after the fwmark was changed
+	ks = New(dir, []string{"iptables", "ip6tables"}, []string{"nordlynx"}, 0x1234)
+	ks.run = fake.run
+	require.NoError(t, ks.Install())
+
+	for _, iptables := range []string{"iptables", "ip6tables"} {
+		stored, err := os.ReadFile(filepath.Join(dir, iptables+".rules"))
+		require.NoError(t, err)
+		assert.Contains(t, string(stored), "-m mark --mark 0x1234 -j RETURN")
+		assert.NotContains(t, string(stored), "0xe1f1")
+	}
+	require.Len(t, fake.restored, 4)
+	assert.Contains(t, fake.restored[2], "-m mark --mark 0x1234 -j RETURN")
+	assert.Contains(t, fake.restored[2], "-d 1.2.3.4 -j RETURN")
+	assert.Contains(t, fake.restored[3], "-d 2001:db8::1 -j RETURN")
+}
+
+func TestKillSwitch_InstallReleased(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	fake := &fakeIPTables{}
+	ks := New(t.TempDir(), []string{"iptables"}, []string{"nordlynx"}, 0xe1f1)
+	ks.run = fake.run
+	require.NoError(t, ks.Install())
+	assert.False(t, ks.IsEnabled())
+	assert.Empty(t, fake.restored)
+}
@@ -19,6 +19,8 @@ type Rule struct {
 
 	// Ports is a list of ports to which rule is applicable
 	Ports []int `json:"ports"`
+	// PortRanges is a list of inclusive port ranges to which rule is applicable
+	PortRanges []PortRange `json:"port_ranges"`
 	// Protocols is a list of protocol string values to which rule is applicable
 	Protocols []string `json:"protocols"`
 	// Direction defines to which packets rule is applicable
@@ -39,6 +41,12 @@ type Rule struct {
 	Comment          string `json:"comment"`
 }
 
+// PortRange is an inclusive range of ports
+type PortRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
 // OrderedRules stores rules in an order they were added.
 type OrderedRules struct {
 	// rules is unexported in order to prevent direct appends
@@ -29,6 +29,7 @@ const (
 	TestCountryFile         = "tempcntr.dat"
 	TestCyberSecFile        = "tempcyber.dat"
 	TestVersionFile         = "tempversion.dat"
+	TestHistoryFile         = "temphistory.dat"
 	TestTokenRenewJSON      = "tokenrenew.json"
 	MixedServersJSON        = "mixed.json"
 	CountryDataJSON         = "country.json"
@@ -129,6 +130,7 @@ func testsCleanup() {
 	internal.FileDelete(TestdataPath + TestCyberSecFile)
 	internal.FileDelete(TestdataPath + TestInsightsFile)
 	internal.FileDelete(TestdataPath + TestVersionFile)
+	internal.FileDelete(TestdataPath + TestHistoryFile)
 }
 
 func waitPortForListener(port int, timeoutSec int) (net.Listener, error) {
@@ -206,6 +208,7 @@ func testNewDataManager() *DataManager {
 		TestdataPath+TestServersFile,
 		TestdataPath+TestCountryFile,
 		TestdataPath+TestVersionFile,
+		TestdataPath+TestHistoryFile,
 	)
 }
 
@@ -0,0 +1,97 @@
+package isolation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"golang.org/x/sys/unix"
+)
+
+const resolvConfPath = "/etc/resolv.conf"
+
+// Exec replaces the current process with the command running in the namespace.
+// Same as `ip netns exec`, namespace DNS configuration is bind mounted over
+// /etc/resolv.conf in a private mount namespace. Must be run as root. If
+// started by sudo, privileges are dropped back to the invoking user.
+//
+// Returns only on failure.
+func Exec(args []string) error {
+	if len(args) == 0 {
+		return errors.New("command is empty")
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotIsolated
+		}
+		return err
+	}
+	defer file.Close()
+
+	// namespaces are switched only for the current thread, which is then
+	// replaced by the command
+	runtime.LockOSThread()
+	if err := unix.Setns(int(file.Fd()), unix.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("entering network namespace: %w", err)
+	}
+	if err := unix.Unshare(unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("creating mount namespace: %w", err)
+	}
+	// mounts must not propagate back to the host
+	if err := unix.Mount("", "/", "none", unix.MS_SLAVE|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+	if internal.FileExists(ResolvConfPath) {
+		if err := unix.Mount(ResolvConfPath, resolvConfPath, "none", unix.MS_BIND, ""); err != nil {
+			return fmt.Errorf("mounting %s: %w", ResolvConfPath, err)
+		}
+	}
+
+	if err := dropPrivileges(); err != nil {
+		return fmt.Errorf("dropping privileges: %w", err)
+	}
+	// #nosec G204 -- command is provided by the user and run with their privileges
+	return syscall.Exec(path, args, os.Environ())
+}
+
+// dropPrivileges to the user which invoked sudo
+func dropPrivileges() error {
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		// not started by sudo
+		return nil
+	}
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return fmt.Errorf("parsing SUDO_GID: %w", err)
+	}
+	groups := []int{gid}
+	if u, err := user.LookupId(strconv.Itoa(uid)); err == nil {
+		ids, _ := u.GroupIds()
+		for _, id := range ids {
+			if group, err := strconv.Atoi(id); err == nil {
+				groups = append(groups, group)
+			}
+		}
+	}
+	if err := syscall.Setgroups(groups); err != nil {
+		return err
+	}
+	if err := syscall.Setgid(gid); err != nil {
+		return err
+	}
+	return syscall.Setuid(uid)
+}
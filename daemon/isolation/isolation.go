@@ -0,0 +1,351 @@
+/*
+Package isolation runs VPN connections inside a dedicated network namespace,
+whose only uplink is the VPN interface, leaving the host network untouched.
+
+The namespace is compatible with `ip netns`, so that it could be inspected
+and used by the standard tools as well.
+*/
+package isolation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/tunnel"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Name of the network namespace
+	Name = "nordvpn"
+	// Path where the namespace is mounted, the same as used by `ip netns`
+	Path = "/run/netns/" + Name
+	// ResolvConfPath is bind mounted over /etc/resolv.conf for the processes
+	// in the namespace, the same as done by `ip netns exec`
+	ResolvConfPath = "/etc/netns/" + Name + "/resolv.conf"
+)
+
+var (
+	// ErrAlreadyIsolated is returned when the namespace is already in use
+	ErrAlreadyIsolated = errors.New("vpn interface is already isolated")
+	// ErrNotIsolated is returned when the namespace does not exist
+	ErrNotIsolated = errors.New("vpn interface is not isolated")
+)
+
+// Isolator moves the VPN interface between the host and the namespace
+type Isolator interface {
+	// Isolate moves the interface into the namespace and routes all of the
+	// namespace traffic through it
+	Isolate(iface net.Interface, ips []netip.Addr, nameservers []string) error
+	// Release moves the interface back to the host and removes the namespace
+	Release() error
+	// SetDNS used by the processes in the namespace
+	SetDNS(nameservers []string) error
+	// TransferRates of the isolated interface
+	TransferRates() (tunnel.Statistics, error)
+}
+
+// Namespace is an Isolator which creates a named network namespace.
+//
+// Thread-safe.
+type Namespace struct {
+	host       netns.NsHandle
+	hostHandle *netlink.Handle
+	path       string
+	resolvConf string
+	journal    journal.Recorder
+	ns         netns.NsHandle
+	handle     *netlink.Handle // operates in the namespace, nil if not isolated
+	iface      string
+	mu         sync.Mutex
+}
+
+// New creates a Namespace isolating interfaces from the host namespace
+func New(host netns.NsHandle, journal journal.Recorder) (*Namespace, error) {
+	return newNamespace(host, Path, ResolvConfPath, journal)
+}
+
+func newNamespace(
+	host netns.NsHandle,
+	path string,
+	resolvConf string,
+	journal journal.Recorder,
+) (*Namespace, error) {
+	hostHandle, err := netlink.NewHandleAt(host)
+	if err != nil {
+		return nil, fmt.Errorf("opening host netlink handle: %w", err)
+	}
+	return &Namespace{
+		host:       host,
+		hostHandle: hostHandle,
+		path:       path,
+		resolvConf: resolvConf,
+		journal:    journal,
+		ns:         netns.None(),
+	}, nil
+}
+
+// Isolate moves the interface into the namespace. Interface addresses are lost
+// when moving it, so they are added again. WireGuard keeps its socket in the
+// namespace where the interface was created, so the encrypted traffic still
+// goes through the host network.
+func (n *Namespace) Isolate(iface net.Interface, ips []netip.Addr, nameservers []string) (err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.handle != nil {
+		return ErrAlreadyIsolated
+	}
+
+	link, err := n.hostHandle.LinkByName(iface.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving link %s: %w", iface.Name, err)
+	}
+
+	n.journal.Record(journal.KindNetns, n.path, n.resolvConf)
+	ns, err := createNamespace(n.path)
+	if err != nil {
+		n.journal.Forget(journal.KindNetns, n.path)
+		return fmt.Errorf("creating network namespace: %w", err)
+	}
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		ns.Close()
+		if err := removeNamespace(n.path, n.resolvConf); err != nil {
+			log.Println(internal.DeferPrefix, err)
+		}
+		n.journal.Forget(journal.KindNetns, n.path)
+		return fmt.Errorf("opening namespace netlink handle: %w", err)
+	}
+	n.ns, n.handle, n.iface = ns, handle, iface.Name
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err := n.release(); err != nil {
+			log.Println(internal.DeferPrefix, err)
+		}
+	}()
+
+	if err := n.hostHandle.LinkSetNsFd(link, int(ns)); err != nil {
+		return fmt.Errorf("moving %s into the namespace: %w", iface.Name, err)
+	}
+	if err := setUp(handle, iface.Name, ips); err != nil {
+		return err
+	}
+	return writeResolvConf(n.resolvConf, nameservers)
+}
+
+// Release moves the interface back to the host, so that it could be removed by
+// the VPN implementation, and removes the namespace
+func (n *Namespace) Release() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.handle == nil {
+		return ErrNotIsolated
+	}
+	return n.release()
+}
+
+func (n *Namespace) release() error {
+	var errs []error
+	link, err := n.handle.LinkByName(n.iface)
+	if err == nil {
+		err = n.handle.LinkSetNsFd(link, int(n.host))
+	}
+	// interface may be already removed together with the tunnel
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		errs = append(errs, fmt.Errorf("moving %s back to the host: %w", n.iface, err))
+	}
+
+	n.handle.Delete()
+	n.ns.Close()
+	n.handle, n.ns, n.iface = nil, netns.None(), ""
+	errs = append(errs, removeNamespace(n.path, n.resolvConf))
+	n.journal.Forget(journal.KindNetns, n.path)
+	return errors.Join(errs...)
+}
+
+// SetDNS overrides nameservers used in the namespace
+func (n *Namespace) SetDNS(nameservers []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.handle == nil {
+		return ErrNotIsolated
+	}
+	return writeResolvConf(n.resolvConf, nameservers)
+}
+
+// TransferRates of the isolated interface. Interface is not visible from the
+// host, so tunnel.T cannot be used for that.
+func (n *Namespace) TransferRates() (tunnel.Statistics, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.handle == nil {
+		return tunnel.Statistics{}, ErrNotIsolated
+	}
+	link, err := n.handle.LinkByName(n.iface)
+	if err != nil {
+		return tunnel.Statistics{}, fmt.Errorf("retrieving link %s: %w", n.iface, err)
+	}
+	stats := link.Attrs().Statistics
+	if stats == nil {
+		return tunnel.Statistics{}, fmt.Errorf("no statistics for %s", n.iface)
+	}
+	return tunnel.Statistics{Tx: stats.TxBytes, Rx: stats.RxBytes}, nil
+}
+
+// Revert removes the namespace left behind after a crash. Isolated interface
+// is removed together with it.
+func Revert(path string, value json.RawMessage) error {
+	var resolvConf string
+	if err := json.Unmarshal(value, &resolvConf); err != nil {
+		return fmt.Errorf("parsing journaled resolv.conf path: %w", err)
+	}
+	return removeNamespace(path, resolvConf)
+}
+
+// setUp addresses and default routes of the isolated interface
+func setUp(handle *netlink.Handle, name string, ips []netip.Addr) error {
+	lo, err := handle.LinkByName("lo")
+	if err != nil {
+		return fmt.Errorf("retrieving loopback: %w", err)
+	}
+	if err := handle.LinkSetUp(lo); err != nil {
+		return fmt.Errorf("setting loopback up: %w", err)
+	}
+
+	link, err := handle.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("retrieving isolated link %s: %w", name, err)
+	}
+	for _, ip := range ips {
+		addr := &netlink.Addr{IPNet: &net.IPNet{
+			IP:   ip.AsSlice(),
+			Mask: net.CIDRMask(ip.BitLen(), ip.BitLen()),
+		}}
+		if err := handle.AddrAdd(link, addr); err != nil {
+			return fmt.Errorf("adding address %s: %w", ip, err)
+		}
+	}
+	if err := handle.LinkSetUp(link); err != nil {
+		return fmt.Errorf("setting %s up: %w", name, err)
+	}
+
+	// IPv6 is routed only if the tunnel has an IPv6 address, otherwise there
+	// is no route and IPv6 traffic cannot leak
+	defaults := map[int]*net.IPNet{}
+	for _, ip := range ips {
+		if ip.Is4() {
+			defaults[unix.AF_INET] = &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+		} else {
+			defaults[unix.AF_INET6] = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+		}
+	}
+	for _, dst := range defaults {
+		if err := handle.RouteAdd(&netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       dst,
+			Scope:     netlink.SCOPE_LINK,
+		}); err != nil {
+			return fmt.Errorf("adding default route %s: %w", dst, err)
+		}
+	}
+	return nil
+}
+
+// createNamespace creates a new network namespace and bind mounts it at the
+// path, so that it would exist without any processes in it
+func createNamespace(path string) (netns.NsHandle, error) {
+	if err := os.MkdirAll(filepath.Dir(path), internal.PermUserRWXGroupRXOthersRX); err != nil {
+		return netns.None(), err
+	}
+	// mount point has to exist
+	file, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE|os.O_EXCL, internal.PermUserRW)
+	if err != nil {
+		return netns.None(), err
+	}
+	file.Close()
+
+	ns, err := newUnmounted()
+	if err != nil {
+		os.Remove(path)
+		return netns.None(), err
+	}
+	source := fmt.Sprintf("/proc/self/fd/%d", int(ns))
+	if err := unix.Mount(source, path, "none", unix.MS_BIND, ""); err != nil {
+		ns.Close()
+		os.Remove(path)
+		return netns.None(), fmt.Errorf("mounting %s: %w", path, err)
+	}
+	return ns, nil
+}
+
+// newUnmounted creates a network namespace without switching the calling
+// goroutine into it
+func newUnmounted() (netns.NsHandle, error) {
+	// namespaces are switched per thread
+	runtime.LockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return netns.None(), err
+	}
+	defer origin.Close()
+
+	ns, err := netns.New()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return netns.None(), err
+	}
+	if err := netns.Set(origin); err != nil {
+		// thread is left locked, so that it would be terminated together with
+		// the goroutine instead of being reused in the wrong namespace
+		ns.Close()
+		return netns.None(), fmt.Errorf("switching back to the host namespace: %w", err)
+	}
+	runtime.UnlockOSThread()
+	return ns, nil
+}
+
+func removeNamespace(path string, resolvConf string) error {
+	var errs []error
+	if err := unix.Unmount(path, unix.MNT_DETACH); err != nil &&
+		!errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+		errs = append(errs, fmt.Errorf("unmounting %s: %w", path, err))
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, err)
+	}
+	if err := os.Remove(resolvConf); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, err)
+	}
+	// directory is left if something else was put there
+	_ = os.Remove(filepath.Dir(resolvConf))
+	return errors.Join(errs...)
+}
+
+func writeResolvConf(path string, nameservers []string) error {
+	lines := []string{"# Generated by NordVPN"}
+	for _, nameserver := range nameservers {
+		lines = append(lines, "nameserver "+nameserver)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), internal.PermUserRWXGroupRXOthersRX); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	content := strings.Join(lines, "\n") + "\n"
+	return internal.FileWrite(path, []byte(content), internal.PermUserRWGroupROthersR)
+}
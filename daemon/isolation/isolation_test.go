@@ -0,0 +1,147 @@
+package isolation
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
+	testns "github.com/NordSecurity/nordvpn-linux/test/netns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+func newTestNamespace(t *testing.T, changes journal.Recorder) (*Namespace, *netlink.Handle) {
+	t.Helper()
+	host, hostHandle := testns.New(t)
+	dir := t.TempDir()
+	namespace, err := newNamespace(
+		host,
+		filepath.Join(dir, "run", Name),
+		filepath.Join(dir, "etc", Name, "resolv.conf"),
+		changes,
+	)
+	require.NoError(t, err)
+	return namespace, hostHandle
+}
+
+func TestNamespace_IsolateAndRelease(t *testing.T) {
+	category.Set(t, category.Root)
+
+	changes := &testjournal.Memory{}
+	namespace, hostHandle := newTestNamespace(t, changes)
+	ips := []netip.Addr{netip.MustParseAddr("10.5.0.2"), netip.MustParseAddr("fd00::2")}
+
+	require.NoError(t, namespace.Isolate(net.Interface{Name: testns.Device}, ips, []string{"103.86.96.100"}))
+	assert.Equal(t, []string{namespace.path}, changes.Keys(journal.KindNetns))
+	assert.ErrorIs(t, namespace.Isolate(net.Interface{Name: testns.Device}, ips, nil), ErrAlreadyIsolated)
+
+	_, err := hostHandle.LinkByName(testns.Device)
+	assert.Error(t, err, "isolated interface must not be visible from the host")
+
+	ns, err := netns.GetFromPath(namespace.path)
+	require.NoError(t, err)
+	defer ns.Close()
+	handle, err := netlink.NewHandleAt(ns)
+	require.NoError(t, err)
+	defer handle.Delete()
+
+	link, err := handle.LinkByName(testns.Device)
+	require.NoError(t, err)
+	addrs, err := handle.AddrList(link, unix.AF_UNSPEC)
+	require.NoError(t, err)
+	var isolatedIPs []string
+	for _, addr := range addrs {
+		if !addr.IP.IsLinkLocalUnicast() {
+			isolatedIPs = append(isolatedIPs, addr.IPNet.String())
+		}
+	}
+	assert.ElementsMatch(t, []string{"10.5.0.2/32", "fd00::2/128"}, isolatedIPs)
+	for _, family := range []int{unix.AF_INET, unix.AF_INET6} {
+		list, err := handle.RouteListFiltered(family, &netlink.Route{Dst: nil}, netlink.RT_FILTER_DST)
+		require.NoError(t, err)
+		require.Len(t, list, 1, "only default route must exist")
+		assert.Equal(t, link.Attrs().Index, list[0].LinkIndex)
+	}
+
+	content, err := os.ReadFile(namespace.resolvConf)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "nameserver 103.86.96.100\n")
+
+	require.NoError(t, namespace.SetDNS([]string{"103.86.99.100"}))
+	content, err = os.ReadFile(namespace.resolvConf)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "nameserver 103.86.99.100\n")
+	assert.NotContains(t, string(content), "103.86.96.100")
+
+	_, err = namespace.TransferRates()
+	assert.NoError(t, err)
+
+	require.NoError(t, namespace.Release())
+	_, err = hostHandle.LinkByName(testns.Device)
+	assert.NoError(t, err, "interface must be moved back to the host")
+	assert.NoFileExists(t, namespace.path)
+	assert.NoFileExists(t, namespace.resolvConf)
+	assert.Empty(t, changes.Entries)
+	assert.ErrorIs(t, namespace.Release(), ErrNotIsolated)
+}
+
+func TestNamespace_IsolateIPv4Only(t *testing.T) {
+	category.Set(t, category.Root)
+
+	namespace, _ := newTestNamespace(t, &testjournal.Memory{})
+	require.NoError(t, namespace.Isolate(
+		net.Interface{Name: testns.Device},
+		[]netip.Addr{netip.MustParseAddr("10.5.0.2")},
+		nil,
+	))
+	defer namespace.Release()
+
+	list, err := namespace.handle.RouteList(nil, unix.AF_INET6)
+	require.NoError(t, err)
+	for _, route := range list {
+		assert.NotNil(t, route.Dst, "ipv6 default route must not exist")
+	}
+}
+
+func TestNamespace_IsolateFailure(t *testing.T) {
+	category.Set(t, category.Root)
+
+	changes := &testjournal.Memory{}
+	namespace, _ := newTestNamespace(t, changes)
+
+	assert.Error(t, namespace.Isolate(net.Interface{Name: "nonexistent"}, nil, nil))
+	assert.NoFileExists(t, namespace.path)
+	assert.Empty(t, changes.Entries)
+
+	// addresses are invalid, so the setup fails after the interface is moved
+	assert.Error(t, namespace.Isolate(net.Interface{Name: testns.Device}, []netip.Addr{{}}, nil))
+	assert.NoFileExists(t, namespace.path)
+	assert.Empty(t, changes.Entries)
+	assert.Nil(t, namespace.handle)
+}
+
+func TestRevert(t *testing.T) {
+	category.Set(t, category.Root)
+
+	changes := &testjournal.Memory{}
+	namespace, _ := newTestNamespace(t, changes)
+	require.NoError(t, namespace.Isolate(net.Interface{Name: testns.Device}, nil, nil))
+
+	entry := changes.Entries[0]
+	require.NoError(t, Revert(entry.Key, entry.Value))
+	assert.NoFileExists(t, namespace.path)
+	assert.NoFileExists(t, namespace.resolvConf)
+
+	assert.NoError(t, Revert(entry.Key, entry.Value), "reverting must be idempotent")
+	assert.Error(t, Revert(entry.Key, json.RawMessage("{")))
+}
@@ -18,7 +18,16 @@ func JobCountries(dm *DataManager, api core.ServersAPI) func() error {
 
 		// save execution start time
 		currentTime := time.Now()
-		countries, headers, err := api.ServersCountries()
+		current := dm.GetCountryData()
+		var tag string
+		if len(current.Countries) > 0 {
+			tag = current.Hash
+		}
+		countries, headers, err := api.ServersCountries(tag)
+		full := err == nil
+		if errors.Is(err, core.ErrNotModified) {
+			countries, err = current.Countries, nil
+		}
 		if err != nil {
 			return err
 		}
@@ -27,7 +36,7 @@ func JobCountries(dm *DataManager, api core.ServersAPI) func() error {
 			return errors.New("empty country list")
 		}
 
-		err = dm.SetCountryData(currentTime, countries, headers.Get(core.HeaderDigest))
+		err = dm.SetCountryData(currentTime, countries, listTag(headers, current.Hash, full))
 		if err != nil {
 			return err
 		}
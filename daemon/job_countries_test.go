@@ -18,7 +18,7 @@ type mockCountriesAPI struct{}
 
 func (mockCountriesAPI) SetTransport(request.MetaTransport) {}
 
-func (mockCountriesAPI) Servers() (core.Servers, http.Header, error) {
+func (mockCountriesAPI) Servers(string) (core.Servers, http.Header, error) {
 	return nil, nil, nil
 }
 
@@ -30,7 +30,7 @@ func (mockCountriesAPI) Server(int64) (*core.Server, error) {
 	return nil, nil
 }
 
-func (m mockCountriesAPI) ServersCountries() (core.Countries, http.Header, error) {
+func (m mockCountriesAPI) ServersCountries(string) (core.Countries, http.Header, error) {
 	countries := core.Countries{
 		{Name: "Latvia", Cities: []core.City{
 			{Name: "Riga"},
@@ -51,7 +51,7 @@ type mockFailingCountriesAPI struct{}
 
 func (mockFailingCountriesAPI) SetTransport(request.MetaTransport) {}
 
-func (mockFailingCountriesAPI) Servers() (core.Servers, http.Header, error) {
+func (mockFailingCountriesAPI) Servers(string) (core.Servers, http.Header, error) {
 	return nil, nil, nil
 }
 
@@ -63,7 +63,7 @@ func (mockFailingCountriesAPI) Server(int64) (*core.Server, error) {
 	return nil, nil
 }
 
-func (mockFailingCountriesAPI) ServersCountries() (core.Countries, http.Header, error) {
+func (mockFailingCountriesAPI) ServersCountries(string) (core.Countries, http.Header, error) {
 	return nil, nil, fmt.Errorf("500")
 }
 
@@ -146,7 +146,7 @@ func TestJobCountries_Expired(t *testing.T) {
 	internal.FileCopy(TestdataPath+"c2.dat", TestdataPath+TestCountryFile)
 
 	dm := testNewDataManager()
-	original, _, _ := mockCountriesAPI{}.ServersCountries() // do not use filesystem
+	original, _, _ := mockCountriesAPI{}.ServersCountries("") // do not use filesystem
 	dm.SetCountryData(time.Now().Add(time.Duration(-7)*time.Hour), original, "")
 
 	err := JobCountries(dm, &mockCountriesAPI{})()
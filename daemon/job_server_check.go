@@ -1,19 +1,48 @@
 package daemon
 
 import (
+	"log"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/internal"
 	"github.com/NordSecurity/nordvpn-linux/networker"
 )
 
-// JobServerCheck marks servers as offline if connection to them drops
+// JobServerCheck marks servers as offline if connection to them drops.
+// Pinned server is never replaced, users are warned when it goes offline instead.
 func JobServerCheck(
 	dm *DataManager,
-	api *core.DefaultAPI,
+	cm config.Manager,
+	api core.ServersAPI,
 	netw networker.Networker,
-	server core.Server,
 ) func() {
 	return func() {
+		var cfg config.Config
+		if err := cm.Load(&cfg); err != nil {
+			log.Println(internal.ErrorPrefix, err)
+		}
+
+		if cfg.AutoConnectData.IsServerPinned() {
+			srv, err := api.Server(cfg.AutoConnectData.PinnedServerID)
+			if err == nil && srv != nil && srv.Status != core.Online {
+				log.Println(internal.WarningPrefix, "pinned server", srv.Hostname, "is", srv.Status)
+				if err := Notify(
+					cm,
+					internal.NotificationPinnedServerOffline,
+					[]string{srv.Hostname, string(srv.Status)},
+				); err != nil {
+					log.Println(internal.WarningPrefix, err)
+				}
+			}
+		}
+
 		if netw.IsVPNActive() {
+			server, err := dm.GetServer(netw.LastServerName())
+			if err != nil {
+				return
+			}
+
 			srv, err := api.Server(server.ID)
 			if err != nil || srv == nil {
 				return
@@ -24,7 +53,7 @@ func JobServerCheck(
 				return
 			}
 
-			err = dm.SetServerStatus(server, server.Status)
+			err = dm.SetServerStatus(*srv, srv.Status)
 			if err != nil {
 				return
 			}
@@ -3,6 +3,7 @@ package daemon
 import (
 	"errors"
 	"log"
+	"net/http"
 	"regexp"
 	"sort"
 	"strings"
@@ -28,7 +29,7 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 		}
 		if validate && dm.ServerDataExists() {
 			// always fill app data even if db file is outdated
-			SetAppData(dm, cfg.Technology, dm.GetServersData().Servers)
+			SetAppData(dm, cfg.Technology, dm.GetServersSummary())
 
 			// if db is still valid, make sure it's locked and do nothing
 			if dm.IsServersDataValid() {
@@ -38,16 +39,28 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 
 		// save execution start time
 		currentTime := time.Now()
-		servers, headers, err := api.Servers()
-		if err != nil {
+		current := dm.GetServersData()
+		var tag string
+		if len(current.Servers) > 0 {
+			tag = current.Hash
+		}
+		servers, headers, err := api.Servers(tag)
+		full := err == nil && !core.IsDeltaEncoded(headers)
+		switch {
+		case errors.Is(err, core.ErrNotModified):
+			// penalties still have to be refreshed as user location may have changed
+			servers = append(core.Servers{}, current.Servers...)
+		case err != nil:
 			return err
+		case core.IsDeltaEncoded(headers):
+			servers = mergeServers(current.Servers, servers)
 		}
 
 		if len(servers) == 0 {
 			return errors.New("empty servers list")
 		}
 
-		geoInfoData := dm.GetInsightsData()
+		insights := userLocation(cfg.Location, dm.GetInsightsData().Insights)
 		randomComponent := randFloat(time.Now().UnixNano(), RandomComponentMin, RandomComponentMax)
 
 		// format first server beforehand to create initial values
@@ -58,8 +71,8 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 		}
 		timestamp := parsedTime.Unix()
 		dist := distance(
-			geoInfoData.Insights.Latitude,
-			geoInfoData.Insights.Longitude,
+			insights.Latitude,
+			insights.Longitude,
 			servers[0].Locations[0].Country.City.Latitude,
 			servers[0].Locations[0].Country.City.Longitude,
 		)
@@ -106,8 +119,8 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 			}
 			timestamp := parsedTime.Unix()
 			dist := distance(
-				geoInfoData.Insights.Latitude,
-				geoInfoData.Insights.Longitude,
+				insights.Latitude,
+				insights.Longitude,
 				server.Locations[0].Country.City.Latitude,
 				server.Locations[0].Country.City.Longitude,
 			)
@@ -132,15 +145,17 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 		servers = filteredServers
 
 		// second iteration to calculate penalty scores
+		history := dm.GetServerHistoryData()
 		for idx, server := range servers {
 			penal, partialPenalty := penalty(
 				core.IsObfuscated()(server),
 				server.Distance, distanceMin, distanceMax,
 				server.Timestamp, timestampMin, timestampMax,
 				server.Load,
-				geoInfoData.Insights.CountryCode, server.Locations[0].Country.Code,
+				insights.CountryCode, server.Locations[0].Country.Code,
 				server.Locations[0].Country.City.HubScore,
 				randomComponent,
+				history.Servers[server.Hostname],
 			)
 			servers[idx].Penalty = penal
 			servers[idx].PartialPenalty = partialPenalty
@@ -152,7 +167,7 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 		})
 
 		SetAppData(dm, cfg.Technology, servers)
-		err = dm.SetServersData(currentTime, servers, headers.Get(core.HeaderDigest))
+		err = dm.SetServersData(currentTime, servers, listTag(headers, current.Hash, full))
 		if err != nil {
 			return err
 		}
@@ -160,6 +175,48 @@ func JobServers(dm *DataManager, cm config.Manager, api core.ServersAPI, validat
 	}
 }
 
+// mergeServers applies partial update to the servers list. Servers which are
+// no longer online are removed, the rest are either replaced or added.
+func mergeServers(servers core.Servers, changed core.Servers) core.Servers {
+	updates := make(map[int64]core.Server, len(changed))
+	for _, server := range changed {
+		updates[server.ID] = server
+	}
+
+	merged := make(core.Servers, 0, len(servers)+len(changed))
+	for _, server := range servers {
+		update, ok := updates[server.ID]
+		if !ok {
+			merged = append(merged, server)
+			continue
+		}
+		delete(updates, server.ID)
+		if update.Status == core.Online {
+			merged = append(merged, update)
+		}
+	}
+	// keep order of the response for newly added servers
+	for _, server := range changed {
+		if _, ok := updates[server.ID]; ok && server.Status == core.Online {
+			merged = append(merged, server)
+		}
+	}
+	return merged
+}
+
+// listTag returns an identifier of the list version to be sent with the next
+// conditional request. Digest of the body identifies the version only when
+// the full list was received.
+func listTag(headers http.Header, previous string, full bool) string {
+	if tag := headers.Get(core.HeaderETag); tag != "" {
+		return tag
+	}
+	if full {
+		return headers.Get(core.HeaderDigest)
+	}
+	return previous
+}
+
 func SetAppData(dm *DataManager, tech config.Technology, servers core.Servers) {
 	countryNames := map[bool]map[config.Protocol]mapset.Set{
 		false: {
@@ -1,14 +1,20 @@
 package daemon
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/response"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 	"github.com/NordSecurity/nordvpn-linux/request"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
@@ -20,7 +26,7 @@ type mockServersAPI struct{}
 
 func (mockServersAPI) SetTransport(request.MetaTransport) {}
 
-func (mockServersAPI) Servers() (core.Servers, http.Header, error) {
+func (mockServersAPI) Servers(string) (core.Servers, http.Header, error) {
 	return core.Servers{
 		{
 			Name:      "fake",
@@ -120,7 +126,7 @@ func (mockServersAPI) Server(int64) (*core.Server, error) {
 	return nil, nil
 }
 
-func (mockServersAPI) ServersCountries() (core.Countries, http.Header, error) {
+func (mockServersAPI) ServersCountries(string) (core.Countries, http.Header, error) {
 	return nil, nil, nil
 }
 
@@ -132,7 +138,7 @@ type mockFailingServersAPI struct{}
 
 func (mockFailingServersAPI) SetTransport(request.MetaTransport) {}
 
-func (mockFailingServersAPI) Servers() (core.Servers, http.Header, error) {
+func (mockFailingServersAPI) Servers(string) (core.Servers, http.Header, error) {
 	return nil, nil, fmt.Errorf("500")
 }
 
@@ -144,7 +150,7 @@ func (mockFailingServersAPI) Server(int64) (*core.Server, error) {
 	return nil, nil
 }
 
-func (mockFailingServersAPI) ServersCountries() (core.Countries, http.Header, error) {
+func (mockFailingServersAPI) ServersCountries(string) (core.Countries, http.Header, error) {
 	return nil, nil, nil
 }
 
@@ -190,6 +196,7 @@ func (m *mockConfigManager) Load(c *config.Config) error {
 	c.Firewall = m.c.Firewall
 	c.Routing = m.c.Routing
 	c.KillSwitch = m.c.KillSwitch
+	c.KillSwitchPersistent = m.c.KillSwitchPersistent
 	c.AutoConnect = m.c.AutoConnect
 	c.IPv6 = m.c.IPv6
 	c.AutoConnectData = m.c.AutoConnectData
@@ -197,6 +204,11 @@ func (m *mockConfigManager) Load(c *config.Config) error {
 	c.TokensData = m.c.TokensData
 	c.MachineID = m.c.MachineID
 	c.Meshnet = m.c.Meshnet
+	c.TrustedNetworks = m.c.TrustedNetworks
+	c.Mesh = m.c.Mesh
+	c.Isolation = m.c.Isolation
+	c.LanDiscovery = m.c.LanDiscovery
+	c.ReconnectPolicy = m.c.ReconnectPolicy
 	return nil
 }
 
@@ -313,9 +325,138 @@ func TestJobServers_Expired(t *testing.T) {
 	internal.FileCopy(TestdataPath+"s2.dat", TestdataPath+TestServersFile)
 
 	dm := testNewDataManager()
-	original, _, _ := mockServersAPI{}.Servers() // do not use filesystem
+	original, _, _ := mockServersAPI{}.Servers("") // do not use filesystem
 	dm.SetServersData(time.Now().Add(time.Duration(-300)*time.Minute), original, "")
 	err := JobServers(dm, newMockConfigManager(), &mockServersAPI{}, true)()
 	assert.NoError(t, err)
 	assert.False(t, reflect.DeepEqual(dm.GetServersData().Servers, original))
 }
+
+func testServer(id int64, hostname string, status core.Status) core.Server {
+	return core.Server{
+		ID:        id,
+		Hostname:  hostname,
+		Status:    status,
+		CreatedAt: "2006-01-02 15:04:05",
+		Locations: core.Locations{
+			{Country: core.Country{
+				Name: "Lithuania",
+				Code: "LT",
+				City: core.City{Name: "Vilnius", Latitude: float64(id), Longitude: float64(id)},
+			}},
+		},
+	}
+}
+
+func TestMergeServers(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	servers := core.Servers{
+		testServer(1, "lt1.nordvpn.com", core.Online),
+		testServer(2, "lt2.nordvpn.com", core.Online),
+		testServer(3, "lt3.nordvpn.com", core.Online),
+	}
+	changed := core.Servers{
+		testServer(2, "lt2.nordvpn.com", core.Offline),
+		testServer(3, "lt33.nordvpn.com", core.Online),
+		testServer(4, "lt4.nordvpn.com", core.Online),
+		testServer(5, "lt5.nordvpn.com", core.Maintenance),
+	}
+
+	var hostnames []string
+	for _, server := range mergeServers(servers, changed) {
+		hostnames = append(hostnames, server.Hostname)
+	}
+	assert.Equal(t, []string{"lt1.nordvpn.com", "lt33.nordvpn.com", "lt4.nordvpn.com"}, hostnames)
+}
+
+// TestJobServers_ConditionalRequests checks that only changes are applied to the stored list
+func TestJobServers_ConditionalRequests(t *testing.T) {
+	category.Set(t, category.Integration)
+
+	stored := core.Servers{
+		testServer(1, "lt1.nordvpn.com", core.Online),
+		testServer(2, "lt2.nordvpn.com", core.Online),
+	}
+
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		body      core.Servers
+		hostnames []string
+		tag       string
+	}{
+		{
+			name:      "not modified",
+			status:    http.StatusNotModified,
+			hostnames: []string{"lt1.nordvpn.com", "lt2.nordvpn.com"},
+			tag:       `"v1"`,
+		},
+		{
+			name:    "partial",
+			status:  http.StatusIMUsed,
+			headers: map[string]string{core.HeaderIM: core.DeltaEncoding, core.HeaderETag: `"v2"`},
+			body: core.Servers{
+				testServer(2, "lt2.nordvpn.com", core.Offline),
+				testServer(3, "lt3.nordvpn.com", core.Online),
+			},
+			hostnames: []string{"lt1.nordvpn.com", "lt3.nordvpn.com"},
+			tag:       `"v2"`,
+		},
+		{
+			name:      "full",
+			status:    http.StatusOK,
+			headers:   map[string]string{core.HeaderETag: `"v3"`},
+			body:      core.Servers{testServer(4, "lt4.nordvpn.com", core.Online)},
+			hostnames: []string{"lt4.nordvpn.com"},
+			tag:       `"v3"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+				for key, value := range test.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(test.status)
+				if test.body != nil {
+					assert.NoError(t, json.NewEncoder(w).Encode(test.body))
+				}
+			}))
+			defer server.Close()
+
+			dir := t.TempDir()
+			dm := NewDataManager(
+				filepath.Join(dir, TestInsightsFile),
+				filepath.Join(dir, TestServersFile),
+				filepath.Join(dir, TestCountryFile),
+				filepath.Join(dir, TestVersionFile),
+				filepath.Join(dir, TestHistoryFile),
+			)
+			assert.NoError(t, dm.SetServersData(time.Now().Add(-2*time.Hour), stored, `"v1"`))
+
+			api := core.NewDefaultAPI(
+				"",
+				"",
+				internal.Development,
+				&mockVault{},
+				request.NewHTTPClient(&http.Client{}, server.URL, nil, nil),
+				func(http.Header, []byte, response.PKVault) error { return nil },
+				&subs.Subject[events.DataRequestAPI]{},
+			)
+			assert.NoError(t, JobServers(dm, newMockConfigManager(), api, true)())
+
+			data := dm.GetServersData()
+			var hostnames []string
+			for _, server := range data.Servers {
+				hostnames = append(hostnames, server.Hostname)
+			}
+			assert.ElementsMatch(t, test.hostnames, hostnames)
+			assert.Equal(t, test.tag, data.Hash)
+			assert.True(t, dm.IsServersDataValid())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/events"
+)
+
+type whitelistNetworker interface {
+	IsVPNActive() bool
+	SetWhitelist(config.Whitelist) error
+	UnsetWhitelist() error
+}
+
+// JobWhitelistExpiry removes temporary whitelist entries once they expire.
+// Expiry is stored in the config, so entries added before a restart are
+// still removed on time. mu is held by the whitelist setter, so that its
+// changes are not overwritten.
+func JobWhitelistExpiry(
+	cm config.Manager,
+	netw whitelistNetworker,
+	publisher events.Publisher[events.DataWhitelist],
+	mu sync.Locker,
+) func() error {
+	return func() error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var whitelist config.Whitelist
+		var expired, killSwitch bool
+		if err := cm.SaveWith(func(c config.Config) config.Config {
+			whitelist, expired = c.AutoConnectData.Whitelist.RemoveExpired(time.Now())
+			killSwitch = c.KillSwitch
+			c.AutoConnectData.Whitelist = whitelist
+			return c
+		}); err != nil {
+			return fmt.Errorf("saving config: %w", err)
+		}
+		if !expired {
+			return nil
+		}
+
+		if netw.IsVPNActive() || killSwitch {
+			if err := netw.UnsetWhitelist(); err != nil {
+				return fmt.Errorf("unsetting whitelist: %w", err)
+			}
+			if err := netw.SetWhitelist(whitelist); err != nil {
+				return fmt.Errorf("setting whitelist: %w", err)
+			}
+		}
+
+		publisher.Publish(events.DataWhitelist{
+			TCPPorts: whitelist.PortCount(config.WhitelistProtocolTCP),
+			UDPPorts: whitelist.PortCount(config.WhitelistProtocolUDP),
+			Subnets:  len(whitelist.Subnets()),
+		})
+		return nil
+	}
+}
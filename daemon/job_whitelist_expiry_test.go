@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWhitelistNetworker struct {
+	vpnActive bool
+	whitelist *config.Whitelist
+}
+
+func (m *mockWhitelistNetworker) IsVPNActive() bool { return m.vpnActive }
+
+func (m *mockWhitelistNetworker) SetWhitelist(whitelist config.Whitelist) error {
+	m.whitelist = &whitelist
+	return nil
+}
+
+func (m *mockWhitelistNetworker) UnsetWhitelist() error {
+	m.whitelist = nil
+	return nil
+}
+
+type mockWhitelistPublisher struct {
+	published []events.DataWhitelist
+}
+
+func (m *mockWhitelistPublisher) Publish(data events.DataWhitelist) {
+	m.published = append(m.published, data)
+}
+
+func TestJobWhitelistExpiry(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	permanent := config.NewWhitelist([]int64{22}, nil, nil)
+	withExpired, _ := permanent.Add(config.WhitelistEntry{Ports: config.NewPortRange(8080, 8080), Expiry: &past})
+	withTemporary, _ := permanent.Add(config.WhitelistEntry{Ports: config.NewPortRange(8080, 8080), Expiry: &future})
+
+	tests := []struct {
+		name      string
+		whitelist config.Whitelist
+		vpnActive bool
+		expected  config.Whitelist
+		reapplied bool
+		publishes int
+	}{
+		{
+			name:      "expired entry is removed",
+			whitelist: withExpired,
+			vpnActive: true,
+			expected:  permanent,
+			reapplied: true,
+			publishes: 1,
+		},
+		{
+			name:      "firewall is untouched while disconnected",
+			whitelist: withExpired,
+			expected:  permanent,
+			publishes: 1,
+		},
+		{
+			name:      "temporary entry is kept until expiry",
+			whitelist: withTemporary,
+			vpnActive: true,
+			expected:  withTemporary,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cm := newMockConfigManager()
+			cm.c.AutoConnectData.Whitelist = test.whitelist
+			netw := &mockWhitelistNetworker{vpnActive: test.vpnActive}
+			publisher := &mockWhitelistPublisher{}
+
+			require.NoError(t, JobWhitelistExpiry(cm, netw, publisher, &sync.Mutex{})())
+
+			assert.Equal(t, test.expected, cm.c.AutoConnectData.Whitelist)
+			assert.Len(t, publisher.published, test.publishes)
+			if test.reapplied {
+				require.NotNil(t, netw.whitelist)
+				assert.Equal(t, test.expected, *netw.whitelist)
+			} else {
+				assert.Nil(t, netw.whitelist)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
@@ -30,10 +31,14 @@ func (r *RPC) StartJobs() {
 	}
 	// TODO if autoconnect runs before servers job, it will return zero servers list
 
-	if _, err := r.scheduler.Every(15).Minutes().Do(JobServerCheck(r.dm, r.api, r.netw, r.lastServer)); err != nil {
+	if _, err := r.scheduler.Every(15).Minutes().Do(JobServerCheck(r.dm, r.cm, r.api, r.netw)); err != nil {
 		log.Println(internal.WarningPrefix, "job servers", err)
 	}
 
+	if _, err := r.scheduler.Every(1).Minute().Do(JobWhitelistExpiry(r.cm, r.netw, r.events.Settings.Whitelist, &r.whitelistMu)); err != nil {
+		log.Println(internal.WarningPrefix, "job whitelist expiry", err)
+	}
+
 	if _, err := r.scheduler.Every(1).Day().Do(JobTemplates(r.cdn)); err != nil {
 		log.Println(internal.WarningPrefix, "job templates", err)
 	}
@@ -53,8 +58,29 @@ func (r *RPC) StartKillSwitch() {
 		return
 	}
 
+	if cfg.KillSwitchPersistent && r.persistentKillSwitch != nil {
+		if !r.persistentKillSwitch.IsEnabled() {
+			// rules were released while the daemon was not running
+			log.Println(internal.InfoPrefix, "persistent killswitch was released")
+			if err := r.cm.SaveWith(func(c config.Config) config.Config {
+				c.KillSwitch = false
+				c.KillSwitchPersistent = false
+				return c
+			}); err != nil {
+				log.Println(internal.ErrorPrefix, err)
+			}
+			return
+		}
+		if err := r.persistentKillSwitch.Install(); err != nil {
+			log.Println(internal.ErrorPrefix, "installing persistent killswitch:", err)
+		}
+	}
+
 	if cfg.KillSwitch {
-		if err := r.netw.SetKillSwitch(cfg.AutoConnectData.Whitelist); err != nil {
+		// expired entries are removed from the config by the whitelist
+		// expiry job which may not have run yet
+		whitelist, _ := cfg.AutoConnectData.Whitelist.RemoveExpired(time.Now())
+		if err := r.netw.SetKillSwitch(whitelist); err != nil {
 			log.Println(internal.ErrorPrefix, "starting killswitch:", err)
 			return
 		}
@@ -85,7 +111,7 @@ type autoconnectServer struct {
 func (autoconnectServer) SetHeader(metadata.MD) error  { return nil }
 func (autoconnectServer) SendHeader(metadata.MD) error { return nil }
 func (autoconnectServer) SetTrailer(metadata.MD)       {}
-func (autoconnectServer) Context() context.Context     { return nil }
+func (autoconnectServer) Context() context.Context     { return context.Background() }
 func (autoconnectServer) SendMsg(m interface{}) error  { return nil }
 func (autoconnectServer) RecvMsg(m interface{}) error  { return nil }
 func (a *autoconnectServer) Send(data *pb.Payload) error {
@@ -103,34 +129,30 @@ func (r *RPC) StartAutoConnect() {
 		return
 	}
 
-	if !cfg.AutoConnect {
+	// trusted network rules take precedence over the auto-connect setting
+	if rule, ok := r.matchTrustedNetwork(cfg.TrustedNetworks); ok {
+		log.Println(internal.InfoPrefix, "trusted network", rule.Name, "matched on startup")
+		if rule.Action == config.TrustedNetworkActionConnect {
+			r.connectAutomatically(cfg)
+		}
 		return
 	}
 
-	udp := []int64{}
-	for port := range cfg.AutoConnectData.Whitelist.Ports.UDP {
-		udp = append(udp, port)
-	}
-
-	tcp := []int64{}
-	for port := range cfg.AutoConnectData.Whitelist.Ports.TCP {
-		tcp = append(tcp, port)
-	}
-
-	subnets := []string{}
-	for subnet := range cfg.AutoConnectData.Whitelist.Subnets {
-		subnets = append(subnets, subnet)
+	if !cfg.AutoConnect {
+		return
 	}
+	r.connectAutomatically(cfg)
+}
 
+// connectAutomatically connects to the auto-connect server or the
+// recommended one if auto-connect is not set up.
+func (r *RPC) connectAutomatically(cfg config.Config) {
 	server := autoconnectServer{}
-	if err := r.Connect(&pb.ConnectRequest{
-		ServerTag: cfg.AutoConnectData.ServerTag,
-	}, &server); err != nil {
+	if err := r.Connect(autoConnectRequest(cfg.AutoConnectData), &server); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 	}
 
 	if server.err != nil {
 		log.Println(internal.ErrorPrefix, server.err)
 	}
-	return
 }
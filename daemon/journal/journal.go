@@ -0,0 +1,158 @@
+/*
+Package journal persists system changes made by the daemon, so that they can
+be reverted on the next start if the daemon was not stopped gracefully.
+*/
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// FilePath defines where the journal is stored
+const FilePath = internal.AppDataPath + "journal.json"
+
+// Kind of the system change
+type Kind string
+
+const (
+	KindSysctl Kind = "sysctl"
+	KindDNS    Kind = "dns"
+	KindHosts  Kind = "hosts"
+	KindRoute  Kind = "route"
+	KindRule   Kind = "rule"
+	KindNetns  Kind = "netns"
+)
+
+// Entry describes a single system change which was not reverted yet
+type Entry struct {
+	Kind  Kind            `json:"kind"`
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Recorder is used by the agents to record system changes before making them
+// and forget them after they are reverted.
+type Recorder interface {
+	Record(kind Kind, key string, value any)
+	Forget(kind Kind, key string)
+}
+
+// Reverter reverts a change described by a key and a value of the entry
+type Reverter func(key string, value json.RawMessage) error
+
+// Journal is a Recorder which stores entries in a file.
+//
+// Thread-safe.
+type Journal struct {
+	path    string
+	entries []Entry
+	mu      sync.Mutex
+}
+
+// New loads existing journal entries from a given path
+func New(path string) *Journal {
+	j := &Journal{path: path}
+	data, err := internal.FileRead(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Println(internal.WarningPrefix, "reading journal:", err)
+		}
+		return j
+	}
+	if err := json.Unmarshal(data, &j.entries); err != nil {
+		log.Println(internal.WarningPrefix, "parsing journal:", err)
+	}
+	return j
+}
+
+// Record a system change. Recording the same kind and key again replaces the
+// value and moves the entry to the end, so that Replay reverts it before the
+// changes recorded earlier.
+func (j *Journal) Record(kind Kind, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Println(internal.WarningPrefix, "journaling", kind, key, err)
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if i := j.index(kind, key); i >= 0 {
+		j.entries = append(j.entries[:i], j.entries[i+1:]...)
+	}
+	j.entries = append(j.entries, Entry{Kind: kind, Key: key, Value: data})
+	if err := j.save(); err != nil {
+		log.Println(internal.WarningPrefix, "journaling", kind, key, err)
+	}
+}
+
+// Forget a reverted system change
+func (j *Journal) Forget(kind Kind, key string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	i := j.index(kind, key)
+	if i < 0 {
+		return
+	}
+	j.entries = append(j.entries[:i], j.entries[i+1:]...)
+	if err := j.save(); err != nil {
+		log.Println(internal.WarningPrefix, "forgetting", kind, key, err)
+	}
+}
+
+// Entries returns a copy of not reverted entries in the order of recording
+func (j *Journal) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]Entry{}, j.entries...)
+}
+
+// Replay reverts journaled changes in reverse order using reverters
+// of the matching kind. Entries are removed from the journal even if reverting
+// fails, so that a broken entry would not be replayed on every start.
+func (j *Journal) Replay(reverters map[Kind]Reverter) error {
+	entries := j.Entries()
+	var errs []error
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if revert, ok := reverters[entry.Kind]; ok {
+			if err := revert(entry.Key, entry.Value); err != nil {
+				errs = append(errs, fmt.Errorf("reverting %s %s: %w", entry.Kind, entry.Key, err))
+			}
+		} else {
+			errs = append(errs, fmt.Errorf("no reverter for %s %s", entry.Kind, entry.Key))
+		}
+		j.Forget(entry.Kind, entry.Key)
+	}
+	return errors.Join(errs...)
+}
+
+func (j *Journal) index(kind Kind, key string) int {
+	for i, entry := range j.entries {
+		if entry.Kind == kind && entry.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes entries to a temporary file first and renames it, so that the
+// journal is never left half written
+func (j *Journal) save() error {
+	data, err := json.Marshal(j.entries)
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := internal.FileWrite(tmp, data, internal.PermUserRW); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
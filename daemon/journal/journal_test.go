@@ -0,0 +1,82 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_RecordAndForget(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal := New(path)
+	journal.Record(KindSysctl, "net.ipv4.ip_forward", 0)
+	journal.Record(KindDNS, "nordlynx", nil)
+	journal.Record(KindSysctl, "net.ipv4.ip_forward", 1)
+
+	// journal must survive restarts
+	reloaded := New(path)
+	// re-recorded change is made after the other ones
+	assert.Equal(t, []Entry{
+		{Kind: KindDNS, Key: "nordlynx", Value: json.RawMessage("null")},
+		{Kind: KindSysctl, Key: "net.ipv4.ip_forward", Value: json.RawMessage("1")},
+	}, reloaded.Entries())
+
+	journal.Forget(KindSysctl, "net.ipv4.ip_forward")
+	journal.Forget(KindRoute, "nonexistent")
+	assert.Equal(t, []Entry{
+		{Kind: KindDNS, Key: "nordlynx", Value: json.RawMessage("null")},
+	}, New(path).Entries())
+}
+
+func TestJournal_Corrupted(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	journal := New(path)
+	assert.Empty(t, journal.Entries())
+	journal.Record(KindHosts, "/etc/hosts", nil)
+	assert.Len(t, New(path).Entries(), 1)
+}
+
+func TestJournal_Replay(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal := New(path)
+	journal.Record(KindSysctl, "net.ipv4.conf.all.rp_filter", 1)
+	journal.Record(KindRule, "fwmark 0xe1f1", 0xe1f1)
+	journal.Record(KindRoute, "0.0.0.0/0 dev nordlynx", nil)
+	journal.Record(KindHosts, "/etc/hosts", nil)
+
+	var reverted []string
+	reverter := func(key string, _ json.RawMessage) error {
+		reverted = append(reverted, key)
+		return nil
+	}
+	err := New(path).Replay(map[Kind]Reverter{
+		KindSysctl: reverter,
+		KindRule:   reverter,
+		KindRoute: func(key string, value json.RawMessage) error {
+			reverted = append(reverted, key)
+			return errors.New("route")
+		},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"0.0.0.0/0 dev nordlynx",
+		"fwmark 0xe1f1",
+		"net.ipv4.conf.all.rp_filter",
+	}, reverted, "changes must be reverted in the reverse order")
+	assert.Empty(t, New(path).Entries(), "replayed entries must be removed even on failure")
+}
@@ -0,0 +1,164 @@
+package netstate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// CaptivePortalProbeURL responds with 204 No Content when the network does not
+	// intercept HTTP traffic
+	CaptivePortalProbeURL = "http://connectivity-check.ubuntu.com/"
+	captivePortalTimeout  = 5 * time.Second
+	// resolvedConfPath lists upstream nameservers when systemd-resolved stub
+	// is used in resolv.conf
+	resolvedConfPath = "/run/systemd/resolve/resolv.conf"
+)
+
+// CaptivePortalProbe detects captive portals by requesting an endpoint with a known
+// response over plain HTTP. Portals either redirect such requests to the login page
+// or respond with the login page themselves.
+type CaptivePortalProbe struct {
+	url      string
+	client   *http.Client
+	resolver *net.Resolver
+	// resolvConfPaths are read in order until one lists nameservers
+	resolvConfPaths []string
+}
+
+// NewCaptivePortalProbe returns a probe which marks its packets with fwmark, so that
+// they are allowed by the kill switch the same way as API requests are.
+func NewCaptivePortalProbe(url string, fwmark uint32) *CaptivePortalProbe {
+	dialer := &net.Dialer{Timeout: captivePortalTimeout}
+	if fwmark != 0 {
+		dialer.Control = func(network, address string, conn syscall.RawConn) error {
+			var operr error
+			if err := conn.Control(func(fd uintptr) {
+				operr = syscall.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, int(fwmark))
+			}); err != nil {
+				return err
+			}
+			return operr
+		}
+	}
+	// resolver uses the same dialer, because portals often intercept DNS as well
+	resolver := &net.Resolver{PreferGo: true, Dial: dialer.DialContext}
+	dialer.Resolver = resolver
+
+	return &CaptivePortalProbe{
+		url:             url,
+		resolver:        resolver,
+		resolvConfPaths: []string{resolvedConfPath, resolvConfPath},
+		client: &http.Client{
+			Transport: &http.Transport{DialContext: dialer.DialContext, DisableKeepAlives: true},
+			Timeout:   captivePortalTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Detect returns the login page of the captive portal or an empty string if the
+// network is not behind one. Only a redirect or a response with unexpected
+// content is considered to be a portal, other errors are returned.
+func (p *CaptivePortalProbe) Detect(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("probing for captive portal: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return "", nil
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		if location, err := resp.Location(); err == nil {
+			return location.String(), nil
+		}
+		return p.url, nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		if err != nil {
+			return "", fmt.Errorf("reading captive portal probe response: %w", err)
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			return "", nil
+		}
+		// portal served its login page instead of the expected empty response
+		return p.url, nil
+	default:
+		return "", fmt.Errorf("unexpected captive portal probe response: %s", resp.Status)
+	}
+}
+
+// Hosts resolves the host of the login page. Packets are marked the same way
+// as the probe, so that resolving works while the kill switch is on.
+func (p *CaptivePortalProbe) Hosts(ctx context.Context, portal string) ([]netip.Addr, error) {
+	u, err := url.Parse(portal)
+	if err != nil {
+		return nil, fmt.Errorf("parsing captive portal url: %w", err)
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+	addrs, err := p.resolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("resolving captive portal host: %w", err)
+	}
+	for i, addr := range addrs {
+		addrs[i] = addr.Unmap()
+	}
+	return addrs, nil
+}
+
+// Nameservers returns the nameservers used by the host. Loopback addresses
+// of local stub resolvers are skipped in favor of their upstream servers.
+func (p *CaptivePortalProbe) Nameservers() ([]netip.Addr, error) {
+	for _, path := range p.resolvConfPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		nameservers := resolvConfNameservers(f)
+		f.Close()
+		if len(nameservers) > 0 {
+			return nameservers, nil
+		}
+	}
+	return nil, fmt.Errorf("no nameservers found in %s", strings.Join(p.resolvConfPaths, ", "))
+}
+
+func resolvConfNameservers(r io.Reader) []netip.Addr {
+	scanner := bufio.NewScanner(r)
+	var nameservers []netip.Addr
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		addr, err := netip.ParseAddr(fields[1])
+		if err != nil || addr.IsLoopback() {
+			continue
+		}
+		nameservers = append(nameservers, addr.Unmap())
+	}
+	return nameservers
+}
@@ -0,0 +1,122 @@
+package netstate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptivePortalProbe_Detect(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		portal  string
+		err     bool
+	}{
+		{
+			name: "no portal",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+		{
+			name: "redirect",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://login.example.com/portal", http.StatusFound)
+			},
+			portal: "http://login.example.com/portal",
+		},
+		{
+			name: "login page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<html>Accept terms</html>"))
+			},
+			portal: "probe",
+		},
+		{
+			name: "empty page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			err: true,
+		},
+		{
+			name:    "not found",
+			handler: http.NotFound,
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			portal, err := NewCaptivePortalProbe(server.URL, 0).Detect(context.Background())
+			if test.err {
+				assert.Error(t, err)
+				assert.Empty(t, portal)
+				return
+			}
+			require.NoError(t, err)
+			if test.portal == "probe" {
+				test.portal = server.URL
+			}
+			assert.Equal(t, test.portal, portal)
+		})
+	}
+}
+
+func TestCaptivePortalProbe_DetectUnreachable(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := NewCaptivePortalProbe(server.URL, 0).Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCaptivePortalProbe_Hosts(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	probe := NewCaptivePortalProbe(CaptivePortalProbeURL, 0)
+	hosts, err := probe.Hosts(context.Background(), "http://192.168.1.1:8080/login")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.168.1.1")}, hosts)
+
+	_, err = probe.Hosts(context.Background(), "://invalid")
+	assert.Error(t, err)
+}
+
+func TestResolvConfNameservers(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	conf := `# generated by NetworkManager
+search example.com
+nameserver 127.0.0.53
+nameserver 192.168.1.1
+nameserver fe80::1%eth0
+nameserver invalid
+`
+	assert.Equal(t, []netip.Addr{
+		netip.MustParseAddr("192.168.1.1"),
+		netip.MustParseAddr("fe80::1%eth0"),
+	}, resolvConfNameservers(strings.NewReader(conf)))
+}
@@ -0,0 +1,212 @@
+package netstate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// networkdLeasesDir holds DHCP leases of systemd-networkd by interface index
+	networkdLeasesDir = "/run/systemd/netif/leases/"
+	resolvConfPath    = "/etc/resolv.conf"
+	// gateway is usually not resolved yet right after joining a network
+	gatewayResolveAttempts = 5
+	gatewayResolveInterval = 200 * time.Millisecond
+)
+
+// ErrNoNetwork is returned when the host has no default route.
+var ErrNoNetwork = errors.New("no default route")
+
+// Fingerprint identifies the network the host is connected to.
+type Fingerprint struct {
+	Interface  string
+	GatewayIP  string
+	GatewayMAC string
+	DHCPDomain string
+	// Addresses of the host on the interface.
+	Addresses []string
+}
+
+// CurrentFingerprint of the network used by the default route in the main
+// routing table. VPN routes are not placed there, so the fingerprint stays
+// the same while connected.
+func CurrentFingerprint() (Fingerprint, error) {
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return Fingerprint{}, fmt.Errorf("listing routes: %w", err)
+	}
+
+	var route *netlink.Route
+	for i, r := range routes {
+		if r.Dst != nil || r.Gw == nil {
+			continue
+		}
+		if route == nil || r.Priority < route.Priority {
+			route = &routes[i]
+		}
+	}
+	if route == nil {
+		return Fingerprint{}, ErrNoNetwork
+	}
+
+	link, err := netlink.LinkByIndex(route.LinkIndex)
+	if err != nil {
+		return Fingerprint{}, fmt.Errorf("getting default route link: %w", err)
+	}
+
+	fp := Fingerprint{
+		Interface: link.Attrs().Name,
+		GatewayIP: route.Gw.String(),
+	}
+
+	fp.GatewayMAC = gatewayMAC(route.LinkIndex, route.Gw)
+
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	if err == nil {
+		for _, addr := range addrs {
+			fp.Addresses = append(fp.Addresses, addr.IPNet.String())
+		}
+	}
+
+	fp.DHCPDomain = dhcpDomain(route.LinkIndex)
+	return fp, nil
+}
+
+// gatewayMAC is taken from the neighbour table. Empty string is returned
+// if the gateway is not resolved after a few attempts.
+func gatewayMAC(linkIndex int, gateway net.IP) string {
+	for attempt := 1; ; attempt++ {
+		neighbours, err := netlink.NeighList(linkIndex, netlink.FAMILY_V4)
+		if err == nil {
+			for _, neigh := range neighbours {
+				if neigh.IP.Equal(gateway) && len(neigh.HardwareAddr) > 0 {
+					return neigh.HardwareAddr.String()
+				}
+			}
+		}
+		if attempt == gatewayResolveAttempts {
+			return ""
+		}
+		time.Sleep(gatewayResolveInterval)
+	}
+}
+
+// dhcpDomain is taken from the systemd-networkd lease if there is one.
+// Otherwise, domain from resolv.conf is used as it is usually populated by
+// the DHCP client.
+func dhcpDomain(linkIndex int) string {
+	if f, err := os.Open(fmt.Sprintf("%s%d", networkdLeasesDir, linkIndex)); err == nil {
+		defer f.Close()
+		if domain := leaseDomain(f); domain != "" {
+			return domain
+		}
+	}
+	if f, err := os.Open(resolvConfPath); err == nil {
+		defer f.Close()
+		return resolvConfDomain(f)
+	}
+	return ""
+}
+
+func leaseDomain(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "DOMAINNAME="); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func resolvConfDomain(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	var search string
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "domain":
+			return fields[1]
+		case "search":
+			if search == "" {
+				search = fields[1]
+			}
+		}
+	}
+	return search
+}
+
+// Matches reports whether all fields set in the rule match the network.
+func (f Fingerprint) Matches(rule config.TrustedNetwork) bool {
+	if rule.GatewayIP != "" && rule.GatewayIP != f.GatewayIP {
+		return false
+	}
+	// rules added for the current network have both gateway IP and MAC,
+	// so they are matched by the IP until the gateway is resolved
+	if rule.GatewayMAC != "" && !equalMAC(rule.GatewayMAC, f.GatewayMAC) &&
+		(f.GatewayMAC != "" || rule.GatewayIP == "") {
+		return false
+	}
+	if rule.Interface != "" && rule.Interface != f.Interface {
+		return false
+	}
+	if rule.DHCPDomain != "" &&
+		!strings.EqualFold(rule.DHCPDomain, strings.TrimSuffix(f.DHCPDomain, ".")) {
+		return false
+	}
+	if rule.Subnet != "" && !f.inSubnet(rule.Subnet) {
+		return false
+	}
+	return true
+}
+
+func equalMAC(a string, b string) bool {
+	macA, err := net.ParseMAC(a)
+	if err != nil {
+		return false
+	}
+	macB, err := net.ParseMAC(b)
+	if err != nil {
+		return false
+	}
+	return macA.String() == macB.String()
+}
+
+func (f Fingerprint) inSubnet(subnet string) bool {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return false
+	}
+	if gw, err := netip.ParseAddr(f.GatewayIP); err == nil && prefix.Contains(gw) {
+		return true
+	}
+	for _, address := range f.Addresses {
+		if addr, err := netip.ParsePrefix(address); err == nil && prefix.Contains(addr.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTrustedNetwork returns the first rule matching the network.
+func MatchTrustedNetwork(rules []config.TrustedNetwork, fp Fingerprint) (config.TrustedNetwork, bool) {
+	for _, rule := range rules {
+		if fp.Matches(rule) {
+			return rule, true
+		}
+	}
+	return config.TrustedNetwork{}, false
+}
@@ -0,0 +1,82 @@
+package netstate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTrustedNetwork(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	rules := []config.TrustedNetwork{
+		{Name: "office", GatewayMAC: "aa:bb:cc:dd:ee:ff", Action: config.TrustedNetworkActionDisconnect},
+		{Name: "lab", Subnet: "10.20.0.0/16", Interface: "eth0", Action: config.TrustedNetworkActionDisconnect},
+		{Name: "home", DHCPDomain: "home.lan", Action: config.TrustedNetworkActionDisconnect},
+		{Name: "other", Action: config.TrustedNetworkActionConnect},
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint Fingerprint
+		expected    string
+	}{
+		{
+			name:        "gateway mac",
+			fingerprint: Fingerprint{Interface: "wlan0", GatewayIP: "192.168.0.1", GatewayMAC: "AA:BB:CC:DD:EE:FF"},
+			expected:    "office",
+		},
+		{
+			name:        "subnet and interface",
+			fingerprint: Fingerprint{Interface: "eth0", GatewayIP: "10.21.0.1", Addresses: []string{"10.20.3.4/24"}},
+			expected:    "lab",
+		},
+		{
+			name:        "subnet on other interface",
+			fingerprint: Fingerprint{Interface: "wlan0", GatewayIP: "10.20.0.1"},
+			expected:    "other",
+		},
+		{
+			name:        "dhcp domain",
+			fingerprint: Fingerprint{Interface: "wlan0", GatewayIP: "192.168.1.1", DHCPDomain: "Home.Lan."},
+			expected:    "home",
+		},
+		{
+			name:        "fallback",
+			fingerprint: Fingerprint{Interface: "wlan0", GatewayIP: "172.16.0.1"},
+			expected:    "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, ok := MatchTrustedNetwork(rules, test.fingerprint)
+			assert.True(t, ok)
+			assert.Equal(t, test.expected, rule.Name)
+		})
+	}
+
+	_, ok := MatchTrustedNetwork(rules[:1], Fingerprint{GatewayIP: "192.168.0.1"})
+	assert.False(t, ok)
+
+	recorded := config.TrustedNetwork{Name: "office", GatewayIP: "192.168.0.1", GatewayMAC: "aa:bb:cc:dd:ee:ff"}
+	assert.True(t, Fingerprint{GatewayIP: "192.168.0.1"}.Matches(recorded), "unresolved gateway")
+	assert.False(t, Fingerprint{GatewayIP: "192.168.0.1", GatewayMAC: "aa:bb:cc:dd:ee:00"}.Matches(recorded))
+	assert.False(t, Fingerprint{GatewayIP: "192.168.1.1"}.Matches(recorded))
+}
+
+func TestDHCPDomain(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	lease := "# This is private data. Do not parse.\nADDRESS=192.168.1.5\nDOMAINNAME=office.example.com\n"
+	assert.Equal(t, "office.example.com", leaseDomain(strings.NewReader(lease)))
+	assert.Equal(t, "", leaseDomain(strings.NewReader("ADDRESS=192.168.1.5\n")))
+
+	assert.Equal(t, "home.lan", resolvConfDomain(strings.NewReader("nameserver 192.168.1.1\nsearch home.lan corp.lan\n")))
+	assert.Equal(t, "corp.lan", resolvConfDomain(strings.NewReader("search home.lan\ndomain corp.lan\n")))
+	assert.Equal(t, "", resolvConfDomain(strings.NewReader("nameserver 1.1.1.1\n")))
+}
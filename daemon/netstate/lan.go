@@ -0,0 +1,83 @@
+package netstate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/vishvananda/netlink"
+)
+
+// LocalSubnets returns private and link-local subnets of the interfaces used
+// by the default routes. Order is stable, so that the results could be
+// compared.
+func (m *NetlinkMonitor) LocalSubnets() ([]netip.Prefix, error) {
+	m.mtx.RLock()
+	names := make([]string, 0, len(m.cached))
+	for name := range m.cached {
+		names = append(names, name)
+	}
+	m.mtx.RUnlock()
+	sort.Strings(names)
+
+	var subnets []netip.Prefix
+	for _, name := range names {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			// interface was removed after the last update
+			if errors.As(err, &netlink.LinkNotFoundError{}) {
+				continue
+			}
+			return nil, fmt.Errorf("retrieving link %s: %w", name, err)
+		}
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, fmt.Errorf("listing addresses of %s: %w", name, err)
+		}
+		for _, subnet := range localSubnets(addrs) {
+			if !containsPrefix(subnets, subnet) {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+	return subnets, nil
+}
+
+// localSubnets of the addresses. Public addresses are skipped, because
+// traffic to them must go through the VPN.
+func localSubnets(addrs []netlink.Addr) []netip.Prefix {
+	var subnets []netip.Prefix
+	for _, addr := range addrs {
+		if addr.IPNet == nil {
+			continue
+		}
+		ip, ok := netip.AddrFromSlice(addr.IP)
+		if !ok {
+			continue
+		}
+		ip = ip.Unmap()
+		if !ip.IsPrivate() && !ip.IsLinkLocalUnicast() {
+			continue
+		}
+		ones, bits := addr.Mask.Size()
+		if bits == 0 {
+			// non canonical mask
+			continue
+		}
+		subnet := netip.PrefixFrom(ip, ones).Masked()
+		if !containsPrefix(subnets, subnet) {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+func containsPrefix(prefixes []netip.Prefix, prefix netip.Prefix) bool {
+	for _, p := range prefixes {
+		if p == prefix {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package netstate
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func toAddr(t *testing.T, cidr string) netlink.Addr {
+	t.Helper()
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	assert.NoError(t, err)
+	ipnet.IP = ip
+	return netlink.Addr{IPNet: ipnet}
+}
+
+func TestLocalSubnets(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	addrs := []netlink.Addr{
+		toAddr(t, "192.168.1.15/24"),
+		toAddr(t, "192.168.1.16/24"),
+		toAddr(t, "10.0.5.1/8"),
+		toAddr(t, "169.254.3.4/16"),
+		toAddr(t, "fe80::1234/64"),
+		toAddr(t, "fd00:1::5/64"),
+		toAddr(t, "85.10.1.2/24"),
+		toAddr(t, "2001:db8::1/64"),
+		{},
+	}
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("fe80::/64"),
+		netip.MustParsePrefix("fd00:1::/64"),
+	}, localSubnets(addrs))
+}
@@ -144,3 +144,16 @@ func getInterfacesFromDefaultRoutes(ignoreSet interfaceSet) interfaceSet {
 	}
 	return newSet
 }
+
+// Revalidate refreshes the connectivity if the host is online, for example
+// after resuming from suspend. Otherwise it is refreshed by the monitor once
+// the network comes back.
+func (m *NetlinkMonitor) Revalidate(re Reconnector) {
+	newSet := getInterfacesFromDefaultRoutes(m.ignored)
+	m.mtx.Lock()
+	m.cached = newSet
+	m.mtx.Unlock()
+	if len(newSet) > 0 {
+		re.Reconnect(true)
+	}
+}
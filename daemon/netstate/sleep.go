@@ -0,0 +1,66 @@
+package netstate
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	logindPath            = "/org/freedesktop/login1"
+	logindManager         = "org.freedesktop.login1.Manager"
+	logindPrepareForSleep = "PrepareForSleep"
+)
+
+// SleepMonitor listens to systemd-logind for the host resuming from
+// suspend or hibernation.
+type SleepMonitor struct {
+	conn    *dbus.Conn
+	signals chan *dbus.Signal
+}
+
+// NewSleepMonitor subscribes to the logind signals on the system bus
+func NewSleepMonitor() (*SleepMonitor, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to system bus: %w", err)
+	}
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(logindPath),
+		dbus.WithMatchInterface(logindManager),
+		dbus.WithMatchMember(logindPrepareForSleep),
+	); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribing to %s: %w", logindPrepareForSleep, err)
+	}
+	signals := make(chan *dbus.Signal, 8)
+	conn.Signal(signals)
+	return &SleepMonitor{conn: conn, signals: signals}, nil
+}
+
+// Start calls onResume every time the host wakes up
+func (m *SleepMonitor) Start(onResume func()) {
+	go func() {
+		for signal := range m.signals {
+			if isResume(signal) {
+				onResume()
+			}
+		}
+	}()
+}
+
+// Stop unsubscribes from logind
+func (m *SleepMonitor) Stop() error {
+	// signals channel is closed together with the connection
+	return m.conn.Close()
+}
+
+// isResume reports whether the signal is PrepareForSleep emitted after
+// waking up. The same signal is emitted before sleeping with true.
+func isResume(signal *dbus.Signal) bool {
+	if signal == nil || signal.Name != logindManager+"."+logindPrepareForSleep || len(signal.Body) != 1 {
+		return false
+	}
+	sleeping, ok := signal.Body[0].(bool)
+	return ok && !sleeping
+}
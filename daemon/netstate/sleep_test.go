@@ -0,0 +1,47 @@
+package netstate
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsResume(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name     string
+		signal   *dbus.Signal
+		expected bool
+	}{
+		{
+			name:     "resume",
+			signal:   &dbus.Signal{Name: "org.freedesktop.login1.Manager.PrepareForSleep", Body: []any{false}},
+			expected: true,
+		},
+		{
+			name:   "suspend",
+			signal: &dbus.Signal{Name: "org.freedesktop.login1.Manager.PrepareForSleep", Body: []any{true}},
+		},
+		{
+			name:   "other signal",
+			signal: &dbus.Signal{Name: "org.freedesktop.login1.Manager.PrepareForShutdown", Body: []any{false}},
+		},
+		{
+			name:   "malformed body",
+			signal: &dbus.Signal{Name: "org.freedesktop.login1.Manager.PrepareForSleep", Body: []any{"false"}},
+		},
+		{
+			name: "nil signal",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isResume(test.signal))
+		})
+	}
+}
@@ -79,6 +79,10 @@ func handleNotificationType(notificationType NotificationType, args []string) st
 		return fmt.Sprintf(internal.ReconnectSuccess, internal.StringsToInterfaces(args)...)
 	case internal.NotificationDisconnected:
 		return internal.DisconnectSuccess
+	case internal.NotificationPinnedServerOffline:
+		return fmt.Sprintf(internal.PinnedServerOffline, internal.StringsToInterfaces(args)...)
+	case internal.NotificationCaptivePortal:
+		return fmt.Sprintf(internal.CaptivePortalDetected, internal.StringsToInterfaces(args)...)
 	default:
 		return fmt.Sprintf("Unknown type (%v)", notificationType)
 	}
@@ -50,6 +50,7 @@ type DaemonClient interface {
 	SetRouting(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
 	SetAnalytics(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
 	SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*Payload, error)
+	ReleaseKillSwitch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Payload, error)
 	SetNotify(ctx context.Context, in *SetNotifyRequest, opts ...grpc.CallOption) (*Payload, error)
 	SetObfuscate(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
 	SetProtocol(ctx context.Context, in *SetProtocolRequest, opts ...grpc.CallOption) (*Payload, error)
@@ -60,6 +61,26 @@ type DaemonClient interface {
 	SettingsTechnologies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Payload, error)
 	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatusResponse, error)
 	SetIpv6(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
+	SetPinnedServer(ctx context.Context, in *SetPinnedServerRequest, opts ...grpc.CallOption) (*Payload, error)
+	ServersHistory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServersHistoryResponse, error)
+	SetLocation(ctx context.Context, in *SetLocationRequest, opts ...grpc.CallOption) (*Payload, error)
+	SettingsExport(ctx context.Context, in *SettingsExportRequest, opts ...grpc.CallOption) (*Payload, error)
+	SettingsImport(ctx context.Context, in *SettingsImportRequest, opts ...grpc.CallOption) (*Payload, error)
+	SettingsHistory(ctx context.Context, in *SettingsHistoryRequest, opts ...grpc.CallOption) (*SettingsHistoryResponse, error)
+	SettingsSubscribe(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Daemon_SettingsSubscribeClient, error)
+	ProfileCreate(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error)
+	ProfileUse(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error)
+	ProfileDelete(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error)
+	Profiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfilesResponse, error)
+	UserSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserSettingsResponse, error)
+	SetUserSetting(ctx context.Context, in *UserSettingRequest, opts ...grpc.CallOption) (*Payload, error)
+	UnsetUserSetting(ctx context.Context, in *UserSettingRequest, opts ...grpc.CallOption) (*Payload, error)
+	TrustedNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TrustedNetworksResponse, error)
+	AddTrustedNetwork(ctx context.Context, in *AddTrustedNetworkRequest, opts ...grpc.CallOption) (*Payload, error)
+	RemoveTrustedNetwork(ctx context.Context, in *RemoveTrustedNetworkRequest, opts ...grpc.CallOption) (*Payload, error)
+	SetIsolation(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
+	SetLanDiscovery(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error)
+	SetReconnectPolicy(ctx context.Context, in *SetReconnectPolicyRequest, opts ...grpc.CallOption) (*Payload, error)
 }
 
 type daemonClient struct {
@@ -391,6 +412,15 @@ func (c *daemonClient) SetKillSwitch(ctx context.Context, in *SetKillSwitchReque
 	return out, nil
 }
 
+func (c *daemonClient) ReleaseKillSwitch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/ReleaseKillSwitch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) SetNotify(ctx context.Context, in *SetNotifyRequest, opts ...grpc.CallOption) (*Payload, error) {
 	out := new(Payload)
 	err := c.cc.Invoke(ctx, "/pb.Daemon/SetNotify", in, out, opts...)
@@ -481,6 +511,209 @@ func (c *daemonClient) SetIpv6(ctx context.Context, in *SetGenericRequest, opts
 	return out, nil
 }
 
+func (c *daemonClient) SetPinnedServer(ctx context.Context, in *SetPinnedServerRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetPinnedServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ServersHistory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServersHistoryResponse, error) {
+	out := new(ServersHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/ServersHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SetLocation(ctx context.Context, in *SetLocationRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SettingsExport(ctx context.Context, in *SettingsExportRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SettingsExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SettingsImport(ctx context.Context, in *SettingsImportRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SettingsImport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SettingsHistory(ctx context.Context, in *SettingsHistoryRequest, opts ...grpc.CallOption) (*SettingsHistoryResponse, error) {
+	out := new(SettingsHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SettingsHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SettingsSubscribe(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Daemon_SettingsSubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[3], "/pb.Daemon/SettingsSubscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonSettingsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_SettingsSubscribeClient interface {
+	Recv() (*SettingsEvent, error)
+	grpc.ClientStream
+}
+
+type daemonSettingsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonSettingsSubscribeClient) Recv() (*SettingsEvent, error) {
+	m := new(SettingsEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonClient) ProfileCreate(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/ProfileCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ProfileUse(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/ProfileUse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ProfileDelete(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/ProfileDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) Profiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProfilesResponse, error) {
+	out := new(ProfilesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/Profiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) UserSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserSettingsResponse, error) {
+	out := new(UserSettingsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/UserSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SetUserSetting(ctx context.Context, in *UserSettingRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetUserSetting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) UnsetUserSetting(ctx context.Context, in *UserSettingRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/UnsetUserSetting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) TrustedNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TrustedNetworksResponse, error) {
+	out := new(TrustedNetworksResponse)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/TrustedNetworks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) AddTrustedNetwork(ctx context.Context, in *AddTrustedNetworkRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/AddTrustedNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) RemoveTrustedNetwork(ctx context.Context, in *RemoveTrustedNetworkRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/RemoveTrustedNetwork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SetIsolation(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetIsolation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SetLanDiscovery(ctx context.Context, in *SetGenericRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetLanDiscovery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SetReconnectPolicy(ctx context.Context, in *SetReconnectPolicyRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/pb.Daemon/SetReconnectPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServer is the server API for Daemon service.
 // All implementations must embed UnimplementedDaemonServer
 // for forward compatibility
@@ -513,6 +746,7 @@ type DaemonServer interface {
 	SetRouting(context.Context, *SetGenericRequest) (*Payload, error)
 	SetAnalytics(context.Context, *SetGenericRequest) (*Payload, error)
 	SetKillSwitch(context.Context, *SetKillSwitchRequest) (*Payload, error)
+	ReleaseKillSwitch(context.Context, *Empty) (*Payload, error)
 	SetNotify(context.Context, *SetNotifyRequest) (*Payload, error)
 	SetObfuscate(context.Context, *SetGenericRequest) (*Payload, error)
 	SetProtocol(context.Context, *SetProtocolRequest) (*Payload, error)
@@ -523,6 +757,26 @@ type DaemonServer interface {
 	SettingsTechnologies(context.Context, *Empty) (*Payload, error)
 	Status(context.Context, *Empty) (*StatusResponse, error)
 	SetIpv6(context.Context, *SetGenericRequest) (*Payload, error)
+	SetPinnedServer(context.Context, *SetPinnedServerRequest) (*Payload, error)
+	ServersHistory(context.Context, *Empty) (*ServersHistoryResponse, error)
+	SetLocation(context.Context, *SetLocationRequest) (*Payload, error)
+	SettingsExport(context.Context, *SettingsExportRequest) (*Payload, error)
+	SettingsImport(context.Context, *SettingsImportRequest) (*Payload, error)
+	SettingsHistory(context.Context, *SettingsHistoryRequest) (*SettingsHistoryResponse, error)
+	SettingsSubscribe(*Empty, Daemon_SettingsSubscribeServer) error
+	ProfileCreate(context.Context, *ProfileRequest) (*Payload, error)
+	ProfileUse(context.Context, *ProfileRequest) (*Payload, error)
+	ProfileDelete(context.Context, *ProfileRequest) (*Payload, error)
+	Profiles(context.Context, *Empty) (*ProfilesResponse, error)
+	UserSettings(context.Context, *Empty) (*UserSettingsResponse, error)
+	SetUserSetting(context.Context, *UserSettingRequest) (*Payload, error)
+	UnsetUserSetting(context.Context, *UserSettingRequest) (*Payload, error)
+	TrustedNetworks(context.Context, *Empty) (*TrustedNetworksResponse, error)
+	AddTrustedNetwork(context.Context, *AddTrustedNetworkRequest) (*Payload, error)
+	RemoveTrustedNetwork(context.Context, *RemoveTrustedNetworkRequest) (*Payload, error)
+	SetIsolation(context.Context, *SetGenericRequest) (*Payload, error)
+	SetLanDiscovery(context.Context, *SetGenericRequest) (*Payload, error)
+	SetReconnectPolicy(context.Context, *SetReconnectPolicyRequest) (*Payload, error)
 	mustEmbedUnimplementedDaemonServer()
 }
 
@@ -614,6 +868,9 @@ func (UnimplementedDaemonServer) SetAnalytics(context.Context, *SetGenericReques
 func (UnimplementedDaemonServer) SetKillSwitch(context.Context, *SetKillSwitchRequest) (*Payload, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetKillSwitch not implemented")
 }
+func (UnimplementedDaemonServer) ReleaseKillSwitch(context.Context, *Empty) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseKillSwitch not implemented")
+}
 func (UnimplementedDaemonServer) SetNotify(context.Context, *SetNotifyRequest) (*Payload, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetNotify not implemented")
 }
@@ -644,6 +901,66 @@ func (UnimplementedDaemonServer) Status(context.Context, *Empty) (*StatusRespons
 func (UnimplementedDaemonServer) SetIpv6(context.Context, *SetGenericRequest) (*Payload, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetIpv6 not implemented")
 }
+func (UnimplementedDaemonServer) SetPinnedServer(context.Context, *SetPinnedServerRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPinnedServer not implemented")
+}
+func (UnimplementedDaemonServer) ServersHistory(context.Context, *Empty) (*ServersHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServersHistory not implemented")
+}
+func (UnimplementedDaemonServer) SetLocation(context.Context, *SetLocationRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLocation not implemented")
+}
+func (UnimplementedDaemonServer) SettingsExport(context.Context, *SettingsExportRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsExport not implemented")
+}
+func (UnimplementedDaemonServer) SettingsImport(context.Context, *SettingsImportRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsImport not implemented")
+}
+func (UnimplementedDaemonServer) SettingsHistory(context.Context, *SettingsHistoryRequest) (*SettingsHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettingsHistory not implemented")
+}
+func (UnimplementedDaemonServer) SettingsSubscribe(*Empty, Daemon_SettingsSubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method SettingsSubscribe not implemented")
+}
+func (UnimplementedDaemonServer) ProfileCreate(context.Context, *ProfileRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileCreate not implemented")
+}
+func (UnimplementedDaemonServer) ProfileUse(context.Context, *ProfileRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileUse not implemented")
+}
+func (UnimplementedDaemonServer) ProfileDelete(context.Context, *ProfileRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileDelete not implemented")
+}
+func (UnimplementedDaemonServer) Profiles(context.Context, *Empty) (*ProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Profiles not implemented")
+}
+func (UnimplementedDaemonServer) UserSettings(context.Context, *Empty) (*UserSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSettings not implemented")
+}
+func (UnimplementedDaemonServer) SetUserSetting(context.Context, *UserSettingRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserSetting not implemented")
+}
+func (UnimplementedDaemonServer) UnsetUserSetting(context.Context, *UserSettingRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsetUserSetting not implemented")
+}
+func (UnimplementedDaemonServer) TrustedNetworks(context.Context, *Empty) (*TrustedNetworksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrustedNetworks not implemented")
+}
+func (UnimplementedDaemonServer) AddTrustedNetwork(context.Context, *AddTrustedNetworkRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTrustedNetwork not implemented")
+}
+func (UnimplementedDaemonServer) RemoveTrustedNetwork(context.Context, *RemoveTrustedNetworkRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTrustedNetwork not implemented")
+}
+func (UnimplementedDaemonServer) SetIsolation(context.Context, *SetGenericRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIsolation not implemented")
+}
+func (UnimplementedDaemonServer) SetLanDiscovery(context.Context, *SetGenericRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLanDiscovery not implemented")
+}
+func (UnimplementedDaemonServer) SetReconnectPolicy(context.Context, *SetReconnectPolicyRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReconnectPolicy not implemented")
+}
 func (UnimplementedDaemonServer) mustEmbedUnimplementedDaemonServer() {}
 
 // UnsafeDaemonServer may be embedded to opt out of forward compatibility for this service.
@@ -1170,6 +1487,24 @@ func _Daemon_SetKillSwitch_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_ReleaseKillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ReleaseKillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/ReleaseKillSwitch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ReleaseKillSwitch(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Daemon_SetNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetNotifyRequest)
 	if err := dec(in); err != nil {
@@ -1350,6 +1685,369 @@ func _Daemon_SetIpv6_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_SetPinnedServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPinnedServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetPinnedServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetPinnedServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetPinnedServer(ctx, req.(*SetPinnedServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ServersHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ServersHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/ServersHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ServersHistory(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SetLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetLocation(ctx, req.(*SetLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SettingsExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SettingsExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SettingsExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SettingsExport(ctx, req.(*SettingsExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SettingsImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SettingsImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SettingsImport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SettingsImport(ctx, req.(*SettingsImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SettingsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SettingsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SettingsHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SettingsHistory(ctx, req.(*SettingsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SettingsSubscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).SettingsSubscribe(m, &daemonSettingsSubscribeServer{stream})
+}
+
+type Daemon_SettingsSubscribeServer interface {
+	Send(*SettingsEvent) error
+	grpc.ServerStream
+}
+
+type daemonSettingsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonSettingsSubscribeServer) Send(m *SettingsEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Daemon_ProfileCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ProfileCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/ProfileCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ProfileCreate(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ProfileUse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ProfileUse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/ProfileUse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ProfileUse(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ProfileDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ProfileDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/ProfileDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ProfileDelete(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_Profiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Profiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/Profiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Profiles(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_UserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).UserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/UserSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).UserSettings(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SetUserSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetUserSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetUserSetting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetUserSetting(ctx, req.(*UserSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_UnsetUserSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).UnsetUserSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/UnsetUserSetting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).UnsetUserSetting(ctx, req.(*UserSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_TrustedNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).TrustedNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/TrustedNetworks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).TrustedNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_AddTrustedNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTrustedNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).AddTrustedNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/AddTrustedNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).AddTrustedNetwork(ctx, req.(*AddTrustedNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_RemoveTrustedNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTrustedNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).RemoveTrustedNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/RemoveTrustedNetwork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).RemoveTrustedNetwork(ctx, req.(*RemoveTrustedNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SetIsolation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGenericRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetIsolation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetIsolation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetIsolation(ctx, req.(*SetGenericRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SetLanDiscovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGenericRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetLanDiscovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetLanDiscovery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetLanDiscovery(ctx, req.(*SetGenericRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SetReconnectPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReconnectPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SetReconnectPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Daemon/SetReconnectPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SetReconnectPolicy(ctx, req.(*SetReconnectPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Daemon_ServiceDesc is the grpc.ServiceDesc for Daemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1457,6 +2155,10 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetKillSwitch",
 			Handler:    _Daemon_SetKillSwitch_Handler,
 		},
+		{
+			MethodName: "ReleaseKillSwitch",
+			Handler:    _Daemon_ReleaseKillSwitch_Handler,
+		},
 		{
 			MethodName: "SetNotify",
 			Handler:    _Daemon_SetNotify_Handler,
@@ -1497,6 +2199,82 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetIpv6",
 			Handler:    _Daemon_SetIpv6_Handler,
 		},
+		{
+			MethodName: "SetPinnedServer",
+			Handler:    _Daemon_SetPinnedServer_Handler,
+		},
+		{
+			MethodName: "ServersHistory",
+			Handler:    _Daemon_ServersHistory_Handler,
+		},
+		{
+			MethodName: "SetLocation",
+			Handler:    _Daemon_SetLocation_Handler,
+		},
+		{
+			MethodName: "SettingsExport",
+			Handler:    _Daemon_SettingsExport_Handler,
+		},
+		{
+			MethodName: "SettingsImport",
+			Handler:    _Daemon_SettingsImport_Handler,
+		},
+		{
+			MethodName: "SettingsHistory",
+			Handler:    _Daemon_SettingsHistory_Handler,
+		},
+		{
+			MethodName: "ProfileCreate",
+			Handler:    _Daemon_ProfileCreate_Handler,
+		},
+		{
+			MethodName: "ProfileUse",
+			Handler:    _Daemon_ProfileUse_Handler,
+		},
+		{
+			MethodName: "ProfileDelete",
+			Handler:    _Daemon_ProfileDelete_Handler,
+		},
+		{
+			MethodName: "Profiles",
+			Handler:    _Daemon_Profiles_Handler,
+		},
+		{
+			MethodName: "UserSettings",
+			Handler:    _Daemon_UserSettings_Handler,
+		},
+		{
+			MethodName: "SetUserSetting",
+			Handler:    _Daemon_SetUserSetting_Handler,
+		},
+		{
+			MethodName: "UnsetUserSetting",
+			Handler:    _Daemon_UnsetUserSetting_Handler,
+		},
+		{
+			MethodName: "TrustedNetworks",
+			Handler:    _Daemon_TrustedNetworks_Handler,
+		},
+		{
+			MethodName: "AddTrustedNetwork",
+			Handler:    _Daemon_AddTrustedNetwork_Handler,
+		},
+		{
+			MethodName: "RemoveTrustedNetwork",
+			Handler:    _Daemon_RemoveTrustedNetwork_Handler,
+		},
+		{
+			MethodName: "SetIsolation",
+			Handler:    _Daemon_SetIsolation_Handler,
+		},
+		{
+			MethodName: "SetLanDiscovery",
+			Handler:    _Daemon_SetLanDiscovery_Handler,
+		},
+		{
+			MethodName: "SetReconnectPolicy",
+			Handler:    _Daemon_SetReconnectPolicy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1514,6 +2292,11 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_LoginOAuth2_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SettingsSubscribe",
+			Handler:       _Daemon_SettingsSubscribe_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "service.proto",
 }
@@ -0,0 +1,563 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.6
+// source: settings_events.proto
+
+package pb
+
+import (
+	config "github.com/NordSecurity/nordvpn-linux/config"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DNSEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Ips     []string `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
+}
+
+func (x *DNSEvent) Reset() {
+	*x = DNSEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_settings_events_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSEvent) ProtoMessage() {}
+
+func (x *DNSEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_events_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSEvent.ProtoReflect.Descriptor instead.
+func (*DNSEvent) Descriptor() ([]byte, []int) {
+	return file_settings_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DNSEvent) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *DNSEvent) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+type WhitelistEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subnets  int64 `protobuf:"varint,1,opt,name=subnets,proto3" json:"subnets,omitempty"`
+	TcpPorts int64 `protobuf:"varint,2,opt,name=tcp_ports,json=tcpPorts,proto3" json:"tcp_ports,omitempty"`
+	UdpPorts int64 `protobuf:"varint,3,opt,name=udp_ports,json=udpPorts,proto3" json:"udp_ports,omitempty"`
+}
+
+func (x *WhitelistEvent) Reset() {
+	*x = WhitelistEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_settings_events_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhitelistEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhitelistEvent) ProtoMessage() {}
+
+func (x *WhitelistEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_events_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhitelistEvent.ProtoReflect.Descriptor instead.
+func (*WhitelistEvent) Descriptor() ([]byte, []int) {
+	return file_settings_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WhitelistEvent) GetSubnets() int64 {
+	if x != nil {
+		return x.Subnets
+	}
+	return 0
+}
+
+func (x *WhitelistEvent) GetTcpPorts() int64 {
+	if x != nil {
+		return x.TcpPorts
+	}
+	return 0
+}
+
+func (x *WhitelistEvent) GetUdpPorts() int64 {
+	if x != nil {
+		return x.UdpPorts
+	}
+	return 0
+}
+
+// SettingsEvent holds a new value of a single setting
+type SettingsEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*SettingsEvent_KillSwitch
+	//	*SettingsEvent_AutoConnect
+	//	*SettingsEvent_Dns
+	//	*SettingsEvent_ThreatProtectionLite
+	//	*SettingsEvent_Protocol
+	//	*SettingsEvent_Whitelist
+	//	*SettingsEvent_Technology
+	//	*SettingsEvent_Obfuscate
+	//	*SettingsEvent_Firewall
+	//	*SettingsEvent_Routing
+	//	*SettingsEvent_Notify
+	//	*SettingsEvent_Meshnet
+	//	*SettingsEvent_Ipv6
+	//	*SettingsEvent_Defaults
+	Event isSettingsEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *SettingsEvent) Reset() {
+	*x = SettingsEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_settings_events_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SettingsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingsEvent) ProtoMessage() {}
+
+func (x *SettingsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_events_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingsEvent.ProtoReflect.Descriptor instead.
+func (*SettingsEvent) Descriptor() ([]byte, []int) {
+	return file_settings_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *SettingsEvent) GetEvent() isSettingsEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *SettingsEvent) GetKillSwitch() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_KillSwitch); ok {
+		return x.KillSwitch
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetAutoConnect() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_AutoConnect); ok {
+		return x.AutoConnect
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetDns() *DNSEvent {
+	if x, ok := x.GetEvent().(*SettingsEvent_Dns); ok {
+		return x.Dns
+	}
+	return nil
+}
+
+func (x *SettingsEvent) GetThreatProtectionLite() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_ThreatProtectionLite); ok {
+		return x.ThreatProtectionLite
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetProtocol() config.Protocol {
+	if x, ok := x.GetEvent().(*SettingsEvent_Protocol); ok {
+		return x.Protocol
+	}
+	return config.Protocol(0)
+}
+
+func (x *SettingsEvent) GetWhitelist() *WhitelistEvent {
+	if x, ok := x.GetEvent().(*SettingsEvent_Whitelist); ok {
+		return x.Whitelist
+	}
+	return nil
+}
+
+func (x *SettingsEvent) GetTechnology() config.Technology {
+	if x, ok := x.GetEvent().(*SettingsEvent_Technology); ok {
+		return x.Technology
+	}
+	return config.Technology(0)
+}
+
+func (x *SettingsEvent) GetObfuscate() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Obfuscate); ok {
+		return x.Obfuscate
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetFirewall() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Firewall); ok {
+		return x.Firewall
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetRouting() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Routing); ok {
+		return x.Routing
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetNotify() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Notify); ok {
+		return x.Notify
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetMeshnet() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Meshnet); ok {
+		return x.Meshnet
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetIpv6() bool {
+	if x, ok := x.GetEvent().(*SettingsEvent_Ipv6); ok {
+		return x.Ipv6
+	}
+	return false
+}
+
+func (x *SettingsEvent) GetDefaults() *Empty {
+	if x, ok := x.GetEvent().(*SettingsEvent_Defaults); ok {
+		return x.Defaults
+	}
+	return nil
+}
+
+type isSettingsEvent_Event interface {
+	isSettingsEvent_Event()
+}
+
+type SettingsEvent_KillSwitch struct {
+	KillSwitch bool `protobuf:"varint,1,opt,name=kill_switch,json=killSwitch,proto3,oneof"`
+}
+
+type SettingsEvent_AutoConnect struct {
+	AutoConnect bool `protobuf:"varint,2,opt,name=auto_connect,json=autoConnect,proto3,oneof"`
+}
+
+type SettingsEvent_Dns struct {
+	Dns *DNSEvent `protobuf:"bytes,3,opt,name=dns,proto3,oneof"`
+}
+
+type SettingsEvent_ThreatProtectionLite struct {
+	ThreatProtectionLite bool `protobuf:"varint,4,opt,name=threat_protection_lite,json=threatProtectionLite,proto3,oneof"`
+}
+
+type SettingsEvent_Protocol struct {
+	Protocol config.Protocol `protobuf:"varint,5,opt,name=protocol,proto3,enum=config.Protocol,oneof"`
+}
+
+type SettingsEvent_Whitelist struct {
+	Whitelist *WhitelistEvent `protobuf:"bytes,6,opt,name=whitelist,proto3,oneof"`
+}
+
+type SettingsEvent_Technology struct {
+	Technology config.Technology `protobuf:"varint,7,opt,name=technology,proto3,enum=config.Technology,oneof"`
+}
+
+type SettingsEvent_Obfuscate struct {
+	Obfuscate bool `protobuf:"varint,8,opt,name=obfuscate,proto3,oneof"`
+}
+
+type SettingsEvent_Firewall struct {
+	Firewall bool `protobuf:"varint,9,opt,name=firewall,proto3,oneof"`
+}
+
+type SettingsEvent_Routing struct {
+	Routing bool `protobuf:"varint,10,opt,name=routing,proto3,oneof"`
+}
+
+type SettingsEvent_Notify struct {
+	Notify bool `protobuf:"varint,11,opt,name=notify,proto3,oneof"`
+}
+
+type SettingsEvent_Meshnet struct {
+	Meshnet bool `protobuf:"varint,12,opt,name=meshnet,proto3,oneof"`
+}
+
+type SettingsEvent_Ipv6 struct {
+	Ipv6 bool `protobuf:"varint,13,opt,name=ipv6,proto3,oneof"`
+}
+
+type SettingsEvent_Defaults struct {
+	// all settings were reset to defaults
+	Defaults *Empty `protobuf:"bytes,14,opt,name=defaults,proto3,oneof"`
+}
+
+func (*SettingsEvent_KillSwitch) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_AutoConnect) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Dns) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_ThreatProtectionLite) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Protocol) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Whitelist) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Technology) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Obfuscate) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Firewall) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Routing) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Notify) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Meshnet) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Ipv6) isSettingsEvent_Event() {}
+
+func (*SettingsEvent_Defaults) isSettingsEvent_Event() {}
+
+var File_settings_events_proto protoreflect.FileDescriptor
+
+var file_settings_events_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x08, 0x44, 0x4e, 0x53,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70,
+	0x73, 0x22, 0x64, 0x0a, 0x0e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x63, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x74, 0x63, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x64,
+	0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75,
+	0x64, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x22, 0xa3, 0x04, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0b, 0x6b, 0x69, 0x6c,
+	0x6c, 0x5f, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x23, 0x0a, 0x0c,
+	0x61, 0x75, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x12, 0x20, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x70, 0x62, 0x2e, 0x44, 0x4e, 0x53, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x03,
+	0x64, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x74, 0x68, 0x72, 0x65, 0x61, 0x74, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x14, 0x74, 0x68, 0x72, 0x65, 0x61, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48,
+	0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x32, 0x0a, 0x09, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x62, 0x2e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x12,
+	0x34, 0x0a, 0x0a, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x65, 0x63,
+	0x68, 0x6e, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x65, 0x63, 0x68, 0x6e,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x1e, 0x0a, 0x09, 0x6f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61,
+	0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x6f, 0x62, 0x66, 0x75,
+	0x73, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x08, 0x66, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c,
+	0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x66, 0x69, 0x72, 0x65, 0x77,
+	0x61, 0x6c, 0x6c, 0x12, 0x1a, 0x0a, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x18, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x00, 0x52, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12, 0x1a, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x68, 0x6e, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x69, 0x70, 0x76, 0x36, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x69, 0x70, 0x76, 0x36, 0x12, 0x27, 0x0a, 0x08, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x31, 0x5a,
+	0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x6f, 0x72, 0x64,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x6e, 0x6f, 0x72, 0x64, 0x76, 0x70, 0x6e,
+	0x2d, 0x6c, 0x69, 0x6e, 0x75, 0x78, 0x2f, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_settings_events_proto_rawDescOnce sync.Once
+	file_settings_events_proto_rawDescData = file_settings_events_proto_rawDesc
+)
+
+func file_settings_events_proto_rawDescGZIP() []byte {
+	file_settings_events_proto_rawDescOnce.Do(func() {
+		file_settings_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_settings_events_proto_rawDescData)
+	})
+	return file_settings_events_proto_rawDescData
+}
+
+var file_settings_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_settings_events_proto_goTypes = []interface{}{
+	(*DNSEvent)(nil),       // 0: pb.DNSEvent
+	(*WhitelistEvent)(nil), // 1: pb.WhitelistEvent
+	(*SettingsEvent)(nil),  // 2: pb.SettingsEvent
+	(config.Protocol)(0),   // 3: config.Protocol
+	(config.Technology)(0), // 4: config.Technology
+	(*Empty)(nil),          // 5: pb.Empty
+}
+var file_settings_events_proto_depIdxs = []int32{
+	0, // 0: pb.SettingsEvent.dns:type_name -> pb.DNSEvent
+	3, // 1: pb.SettingsEvent.protocol:type_name -> config.Protocol
+	1, // 2: pb.SettingsEvent.whitelist:type_name -> pb.WhitelistEvent
+	4, // 3: pb.SettingsEvent.technology:type_name -> config.Technology
+	5, // 4: pb.SettingsEvent.defaults:type_name -> pb.Empty
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_settings_events_proto_init() }
+func file_settings_events_proto_init() {
+	if File_settings_events_proto != nil {
+		return
+	}
+	file_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_settings_events_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_settings_events_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhitelistEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_settings_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SettingsEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_settings_events_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*SettingsEvent_KillSwitch)(nil),
+		(*SettingsEvent_AutoConnect)(nil),
+		(*SettingsEvent_Dns)(nil),
+		(*SettingsEvent_ThreatProtectionLite)(nil),
+		(*SettingsEvent_Protocol)(nil),
+		(*SettingsEvent_Whitelist)(nil),
+		(*SettingsEvent_Technology)(nil),
+		(*SettingsEvent_Obfuscate)(nil),
+		(*SettingsEvent_Firewall)(nil),
+		(*SettingsEvent_Routing)(nil),
+		(*SettingsEvent_Notify)(nil),
+		(*SettingsEvent_Meshnet)(nil),
+		(*SettingsEvent_Ipv6)(nil),
+		(*SettingsEvent_Defaults)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_settings_events_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_settings_events_proto_goTypes,
+		DependencyIndexes: file_settings_events_proto_depIdxs,
+		MessageInfos:      file_settings_events_proto_msgTypes,
+	}.Build()
+	File_settings_events_proto = out.File
+	file_settings_events_proto_rawDesc = nil
+	file_settings_events_proto_goTypes = nil
+	file_settings_events_proto_depIdxs = nil
+}
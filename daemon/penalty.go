@@ -9,6 +9,10 @@ const (
 	K      = 0.5
 	W      = 0.5
 	Fi     = 7
+	Gamma  = 1
+	// HistoryPrior is the number of imaginary successful connections
+	// each server starts with, so that a single failure is not decisive
+	HistoryPrior = 2
 )
 
 func distancePenalty(distance, distanceMin, distanceMax float64) float64 {
@@ -41,6 +45,10 @@ func obfuscationPenalty(obfuscated bool, timestamp, timestampMin, timestampMax i
 	return 0
 }
 
+func historyPenalty(successes, failures int) float64 {
+	return Gamma * float64(failures) / float64(successes+failures+HistoryPrior)
+}
+
 func penalty(
 	obfuscated bool,
 	distance, distanceMin, distanceMax float64,
@@ -49,12 +57,14 @@ func penalty(
 	userCountryCode, serverCountryCode string,
 	hubScore *float64,
 	randomComponent float64,
+	history ServerHistory,
 ) (float64, float64) {
 	distanceP := distancePenalty(distance, distanceMin, distanceMax)
 	loadP := loadPenalty(load)
 	obfuscationP := obfuscationPenalty(obfuscated, timestamp, timestampMin, timestampMax)
 	countryP := countryPenalty(userCountryCode, serverCountryCode)
 	hubP := hubPenalty(hubScore)
-	partialPenalty := distanceP + randomComponent + obfuscationP - countryP*hubP
+	historyP := historyPenalty(history.Successes, history.Failures)
+	partialPenalty := distanceP + randomComponent + obfuscationP - countryP*hubP + historyP
 	return partialPenalty + loadP, partialPenalty
 }
@@ -97,6 +97,26 @@ func TestHubPenalty(t *testing.T) {
 	}
 }
 
+func TestHistoryPenalty(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		successes, failures int
+		expected            float64
+	}{
+		{0, 0, 0},
+		{10, 0, 0},
+		{0, 1, 0.333333},
+		{0, 8, 0.8},
+		{6, 2, 0.2},
+	}
+
+	for _, item := range tests {
+		got := historyPenalty(item.successes, item.failures)
+		assert.InDelta(t, item.expected, got, PenaltyDelta)
+	}
+}
+
 func TestObfuscationPenalty(t *testing.T) {
 	category.Set(t, category.Unit)
 
@@ -149,7 +169,7 @@ func TestPenalty(t *testing.T) {
 			// run through some different random values
 			item.randomComponent = randFloat(time.Now().UnixNano(), 0, 0.001)
 			got, gotPartial := penalty(item.obfuscated, item.d, item.dmin, item.dmax, item.t, item.tmin, item.tmax,
-				item.load, item.userCountry, item.serverCountry, hubScore, item.randomComponent)
+				item.load, item.userCountry, item.serverCountry, hubScore, item.randomComponent, ServerHistory{})
 
 			assert.LessOrEqual(t, math.Abs(item.expected-got), PenaltyDelta)
 			assert.LessOrEqual(t, math.Abs(item.expectedPartial-gotPartial), PenaltyDelta)
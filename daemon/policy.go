@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"log"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"golang.org/x/exp/slices"
+)
+
+// EnforcePolicy saves values pinned by the administrator policy to the config.
+func EnforcePolicy(cm config.Manager, path string) error {
+	policy, err := config.LoadPolicy(path)
+	if err != nil {
+		return err
+	}
+
+	var enforceErr error
+	if err := cm.SaveWith(func(c config.Config) config.Config {
+		// config is left untouched on error
+		c, enforceErr = policy.Apply(c)
+		return c
+	}); err != nil {
+		return err
+	}
+	return enforceErr
+}
+
+// policyPayload returns a payload refusing the change if update modifies any
+// of the given settings locked by the administrator policy. Payload is also
+// returned when the policy cannot be loaded, as locked settings are unknown
+// then. Update keeping the locked values results in nothing to do, nil
+// update is refused whenever any of the settings is locked.
+func (r *RPC) policyPayload(update func(config.Config) config.Config, settings ...string) *pb.Payload {
+	policy, err := config.LoadPolicy(r.policyPath)
+	if err != nil {
+		log.Println(internal.ErrorPrefix, "loading policy:", err)
+		return &pb.Payload{Type: internal.CodePolicyEnforced, Data: settings}
+	}
+
+	var locked []string
+	for _, setting := range settings {
+		if policy.IsLocked(setting) {
+			locked = append(locked, setting)
+		}
+	}
+	if len(locked) == 0 {
+		return nil
+	}
+	if update == nil {
+		return &pb.Payload{Type: internal.CodePolicyEnforced, Data: locked}
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}
+	}
+	changes := config.NewSettings(cfg).Diff(config.NewSettings(update(cfg)))
+	var changed []string
+	for _, change := range changes {
+		name := strings.Split(change.Name, ".")[0]
+		if slices.Contains(locked, name) {
+			changed = append(changed, name)
+		}
+	}
+	switch {
+	case len(changed) > 0:
+		return &pb.Payload{Type: internal.CodePolicyEnforced, Data: changed}
+	case len(changes) == 0:
+		return &pb.Payload{Type: internal.CodeNothingToDo}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPC_PolicyPayload(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	r := RPC{cm: newMockConfigManager(), policyPath: path}
+	setIPv6 := func(enabled bool) int64 {
+		payload, err := r.SetIpv6(context.Background(), &pb.SetGenericRequest{Enabled: enabled})
+		require.NoError(t, err)
+		return payload.Type
+	}
+
+	// locked settings are unknown when the policy cannot be parsed
+	require.NoError(t, os.WriteFile(path, []byte("enforce: ["), 0644))
+	assert.Equal(t, internal.CodePolicyEnforced, setIPv6(true))
+
+	if os.Getuid() != 0 {
+		t.Skip("policy file has to be owned by root")
+	}
+	require.NoError(t, os.WriteFile(path, []byte("enforce:\n  ipv6: false\n"), 0644))
+	assert.Equal(t, internal.CodePolicyEnforced, setIPv6(true))
+	assert.Equal(t, internal.CodeNothingToDo, setIPv6(false))
+	assert.Nil(t, r.policyPayload(nil, config.SettingFirewall))
+	assert.Equal(t, internal.CodePolicyEnforced, r.policyPayload(nil, config.SettingIPv6).Type)
+}
@@ -16,7 +16,8 @@ import (
 // ValidatorFunc validates headers.
 type ValidatorFunc func(headers http.Header, body []byte, vault PKVault) error
 
-// ValidateResponseHeaders validates that the response came from actual NordVPN API
+// ValidateResponseHeaders validates that the response came from actual NordVPN API.
+// Digest is not checked when body is nil, e.g. for 304 Not Modified responses.
 func ValidateResponseHeaders(headers http.Header, body []byte, vault PKVault) error {
 	xDigest := headers.Get("X-Digest")
 	xAuthorization := headers.Get("X-Authorization")
@@ -50,7 +51,7 @@ func ValidateResponseHeaders(headers http.Header, body []byte, vault PKVault) er
 	}
 
 	// Get expected digest value and check if it matches the X-Digest
-	if xDigest != string(hashFunc(body)) {
+	if body != nil && xDigest != string(hashFunc(body)) {
 		return fmt.Errorf("X-Digest value does not match the checksum of response body")
 	}
 
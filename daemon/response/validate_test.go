@@ -151,3 +151,13 @@ func TestValidateResponseHeaders(t *testing.T) {
 		assert.True(t, test.error == (err != nil), err)
 	}
 }
+
+func TestValidateResponseHeaders_NoBody(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	vault := response.PKVault{PublicKey: publicKey}
+	headers := validHeaders([]byte(`"foo": "bar"`))
+	assert.NoError(t, ValidateResponseHeaders(headers, nil, vault))
+	assert.Error(t, ValidateResponseHeaders(headers, []byte{}, vault))
+	assert.Error(t, ValidateResponseHeaders(setHeader(headers, "X-Signature", "invalid"), nil, vault))
+}
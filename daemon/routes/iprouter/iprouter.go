@@ -5,6 +5,7 @@ package iprouter
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/netip"
@@ -13,16 +14,24 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
 	"github.com/NordSecurity/nordvpn-linux/network"
 )
 
 // Router uses `ip route` under the hood.
 type Router struct {
-	routes []routes.Route
+	routes  []routes.Route
+	journal journal.Recorder
 	sync.Mutex
 }
 
+// NewRouter creates a Router which journals added routes, so that they could
+// be deleted after a crash
+func NewRouter(journal journal.Recorder) *Router {
+	return &Router{journal: journal}
+}
+
 // Add calls ip route add command and appends route to a routes list if it does not exist yet
 func (r *Router) Add(route routes.Route) error {
 	r.Lock()
@@ -45,8 +54,12 @@ func (r *Router) Add(route routes.Route) error {
 		return fmt.Errorf("building 'ip route' command: %w", err)
 	}
 
+	r.journal.Record(journal.KindRoute, route.JournalKey(), route)
 	// #nosec G204 -- input is properly sanitized
 	out, err := exec.Command("ip", ipArgs...).CombinedOutput()
+	if err != nil {
+		r.journal.Forget(journal.KindRoute, route.JournalKey())
+	}
 	if strings.HasSuffix(strings.TrimSpace(string(out)), "File exists") {
 		return routes.ErrRouteToOtherDestinationExists
 	}
@@ -66,21 +79,38 @@ func (r *Router) Flush() error {
 			return fmt.Errorf("route %+v does not exist", route)
 		}
 
-		ipArgs, err := getRouteArgs(route, "delete")
-		if err != nil {
-			return fmt.Errorf("building 'ip route' command: %w", err)
-		}
-
-		// #nosec G204 -- input is properly sanitized
-		out, err := exec.Command("ip", ipArgs...).CombinedOutput()
-		if err != nil && !strings.HasSuffix(strings.TrimSpace(string(out)), "No such process") {
-			return fmt.Errorf("executing 'ip route delete %s' command: %w: %s", route.Subnet.String(), err, string(out))
+		if err := deleteRoute(route); err != nil {
+			return err
 		}
+		r.journal.Forget(journal.KindRoute, route.JournalKey())
 	}
 	r.routes = nil
 	return nil
 }
 
+// Revert deletes a journaled route
+func Revert(_ string, value json.RawMessage) error {
+	var route routes.Route
+	if err := json.Unmarshal(value, &route); err != nil {
+		return fmt.Errorf("parsing journaled route: %w", err)
+	}
+	return deleteRoute(route)
+}
+
+func deleteRoute(route routes.Route) error {
+	ipArgs, err := getRouteArgs(route, "delete")
+	if err != nil {
+		return fmt.Errorf("building 'ip route' command: %w", err)
+	}
+
+	// #nosec G204 -- input is properly sanitized
+	out, err := exec.Command("ip", ipArgs...).CombinedOutput()
+	if err != nil && !strings.HasSuffix(strings.TrimSpace(string(out)), "No such process") {
+		return fmt.Errorf("executing 'ip route delete %s' command: %w: %s", route.Subnet.String(), err, string(out))
+	}
+	return nil
+}
+
 func (r *Router) has(route routes.Route) bool {
 	for _, ro := range r.routes {
 		if route.IsEqual(ro) {
@@ -1,6 +1,7 @@
 package iprouter
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net"
@@ -8,9 +9,11 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
 	"github.com/NordSecurity/nordvpn-linux/network"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,7 +72,7 @@ func TestIPRouter_Add(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			preRouter := Router{}
+			preRouter := Router{journal: &testjournal.Memory{}}
 			for _, route := range test.preExistingRoutes {
 				exec.Command("ip", "route", "delete", route.Subnet.String(), "via", route.Gateway.String()).Run()
 				err := preRouter.Add(route)
@@ -78,7 +81,7 @@ func TestIPRouter_Add(t *testing.T) {
 				out, err := exec.Command("ip", "route").CombinedOutput()
 				log.Println(string(out))
 			}
-			router := Router{}
+			router := Router{journal: &testjournal.Memory{}}
 			for i, route := range test.routes {
 				// Ignore errors here. This is just for test preparation
 				if test.errOn != i {
@@ -135,7 +138,7 @@ func TestIPRouterAddIPv6(t *testing.T) {
 		},
 	}
 
-	router := Router{}
+	router := Router{journal: &testjournal.Memory{}}
 	defer router.Flush()
 
 	for _, route := range newRoutes {
@@ -237,7 +240,7 @@ func TestIPRouter_Has(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			router := Router{routes: test.list}
+			router := Router{routes: test.list, journal: &testjournal.Memory{}}
 			assert.Equal(t, test.contains, router.has(test.route))
 		})
 	}
@@ -329,7 +332,7 @@ func TestExistsInRoutingTable(t *testing.T) {
 
 	route := route(t, gateway, netip.AddrFrom4([4]byte{bits[0], bits[1], bits[2], 0}), 24)
 	route.Device = iface
-	router := Router{}
+	router := Router{journal: &testjournal.Memory{}}
 
 	// Cleanup before the execution
 	_ = exec.Command("ip", "route", "delete", route.Subnet.String(), "via", route.Gateway.String()).Run()
@@ -351,3 +354,36 @@ func TestExistsInRoutingTable(t *testing.T) {
 	err = router.Flush()
 	require.NoError(t, err)
 }
+
+func TestIPRouter_Journal(t *testing.T) {
+	category.Set(t, category.Route)
+
+	gateway, iface, err := routes.IPGatewayRetriever{}.Default(false)
+	require.NoError(t, err)
+
+	bits := gateway.As4()
+	journaled := route(t, gateway, netip.AddrFrom4([4]byte{bits[0], bits[1], bits[2] + 1, 0}), 24)
+	journaled.Device = iface
+
+	recorder := &testjournal.Memory{}
+	router := NewRouter(recorder)
+	require.NoError(t, router.Add(journaled))
+	require.Len(t, recorder.Entries, 1)
+	assert.Equal(t, []string{journaled.JournalKey()}, recorder.Keys(journal.KindRoute))
+
+	// simulate a crash by reverting the journaled route without flushing the router
+	require.NoError(t, Revert(recorder.Entries[0].Key, recorder.Entries[0].Value))
+	exists, err := existsInRoutingTable(journaled)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, router.Flush())
+	assert.Empty(t, recorder.Entries)
+}
+
+func TestRevert_InvalidValue(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	assert.Error(t, Revert("", json.RawMessage(`"not a route"`)))
+	assert.Error(t, Revert("", json.RawMessage(`{}`)), "route without device must not be deleted")
+}
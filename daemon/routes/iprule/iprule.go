@@ -3,6 +3,7 @@ package iprule
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 )
@@ -20,12 +22,13 @@ type Router struct {
 	rpFilterManager routes.RPFilterManager
 	tableID         uint
 	fwmark          uint32
+	journal         journal.Recorder
 	mu              sync.Mutex
 }
 
 // NewRouter is a default constructor for Router
-func NewRouter(rpFilterManager routes.RPFilterManager, fwmark uint32) *Router {
-	return &Router{rpFilterManager: rpFilterManager, fwmark: fwmark}
+func NewRouter(rpFilterManager routes.RPFilterManager, fwmark uint32, journal journal.Recorder) *Router {
+	return &Router{rpFilterManager: rpFilterManager, fwmark: fwmark, journal: journal}
 }
 
 // SetupRoutingRules setup policy based routing rules
@@ -45,6 +48,7 @@ func (r *Router) SetupRoutingRules(
 		ipv6EnabledList = append(ipv6EnabledList, true)
 	}
 
+	r.journal.Record(journal.KindRule, ruleKey(r.fwmark), r.fwmark)
 	defer func() { // recover if error
 		if err == nil {
 			return
@@ -59,6 +63,7 @@ func (r *Router) SetupRoutingRules(
 				log.Println(internal.DeferPrefix, err)
 			}
 		}
+		r.journal.Forget(journal.KindRule, ruleKey(r.fwmark))
 	}()
 
 	for _, ipv6 := range ipv6EnabledList {
@@ -101,21 +106,42 @@ func (r *Router) CleanupRouting() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	removeRules(r.fwmark)
+	r.journal.Forget(journal.KindRule, ruleKey(r.fwmark))
+
+	if err := r.rpFilterManager.Unset(); err != nil {
+		return fmt.Errorf("unsetting rp filter: %w", err)
+	}
+
+	return nil
+}
+
+// Revert removes journaled routing rules. rp_filter is journaled and reverted
+// separately.
+func Revert(_ string, value json.RawMessage) error {
+	var fwmark uint32
+	if err := json.Unmarshal(value, &fwmark); err != nil {
+		return fmt.Errorf("parsing journaled fwmark: %w", err)
+	}
+	removeRules(fwmark)
+	return nil
+}
+
+func removeRules(fwmark uint32) {
 	for _, ipv6 := range []bool{false, true} {
 		if err := removeSuppressprefixLengthRule(ipv6); err != nil {
 			log.Println(internal.WarningPrefix, err)
 		}
 
-		if err := removeFwmarkRule(r.fwmark, ipv6); err != nil {
+		if err := removeFwmarkRule(fwmark, ipv6); err != nil {
 			log.Println(internal.WarningPrefix, err)
 		}
 	}
+}
 
-	if err := r.rpFilterManager.Unset(); err != nil {
-		return fmt.Errorf("unsetting rp filter: %w", err)
-	}
-
-	return nil
+// ruleKey identifies routing rules in the journal
+func ruleKey(fwmark uint32) string {
+	return fmt.Sprintf("fwmark %#x", fwmark)
 }
 
 func (r *Router) TableID() uint {
@@ -1,11 +1,16 @@
 package iprule
 
 import (
+	"errors"
+	"net"
 	"testing"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFwmarkRule(t *testing.T) {
@@ -173,3 +178,41 @@ func TestCustomTable(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Greater(t, prioID, prioID2)
 }
+
+type failingRPFilterManager struct{}
+
+func (failingRPFilterManager) Set() error   { return errors.New("rp_filter") }
+func (failingRPFilterManager) Unset() error { return nil }
+
+type workingRPFilterManager struct{}
+
+func (workingRPFilterManager) Set() error   { return nil }
+func (workingRPFilterManager) Unset() error { return nil }
+
+func TestRouter_JournalFailedSetup(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	recorder := &testjournal.Memory{}
+	router := NewRouter(failingRPFilterManager{}, 0xe1f1, recorder)
+	assert.Error(t, router.SetupRoutingRules(net.Interface{}, false))
+	assert.Empty(t, recorder.Entries)
+}
+
+func TestRouter_Journal(t *testing.T) {
+	category.Set(t, category.Route)
+
+	var fwmark uint32 = 0xe1f3
+	recorder := &testjournal.Memory{}
+	router := NewRouter(workingRPFilterManager{}, fwmark, recorder)
+	require.NoError(t, router.SetupRoutingRules(net.Interface{}, false))
+	assert.Equal(t, []string{ruleKey(fwmark)}, recorder.Keys(journal.KindRule))
+
+	// simulate a crash by reverting the journaled rules without cleaning up the router
+	require.NoError(t, Revert(recorder.Entries[0].Key, recorder.Entries[0].Value))
+	found, err := checkFwmarkRule(fwmark, false)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, router.CleanupRouting())
+	assert.Empty(t, recorder.Entries)
+}
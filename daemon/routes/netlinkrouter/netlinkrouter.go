@@ -0,0 +1,206 @@
+/*
+Package netlinkrouter provides routes.Agent implementation which talks to the
+kernel directly over netlink instead of executing ip route.
+*/
+package netlinkrouter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Router uses netlink under the hood.
+//
+// Thread-safe.
+type Router struct {
+	handle  *netlink.Handle
+	routes  []routes.Route
+	journal journal.Recorder
+	mu      sync.Mutex
+}
+
+// NewRouter creates a Router operating in the network namespace of the handle.
+// Added routes are journaled, so that they could be deleted after a crash.
+func NewRouter(handle *netlink.Handle, journal journal.Recorder) *Router {
+	return &Router{handle: handle, journal: journal}
+}
+
+// Add route unless it already exists in the main routing table
+func (r *Router) Add(route routes.Route) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.has(route) {
+		return fmt.Errorf("route %+v already exists", route)
+	}
+
+	nlRoute, err := toNetlinkRoute(r.handle, route)
+	if err != nil {
+		return err
+	}
+
+	exists, err := existsInMainTable(r.handle, nlRoute)
+	if err != nil {
+		return fmt.Errorf("checking if route exists: %w", err)
+	}
+	// If route already existed in the system, do not add it to the router so it would not be flushed
+	if exists {
+		return nil
+	}
+
+	r.journal.Record(journal.KindRoute, route.JournalKey(), route)
+	if err := r.handle.RouteAdd(nlRoute); err != nil {
+		r.journal.Forget(journal.KindRoute, route.JournalKey())
+		if errors.Is(err, unix.EEXIST) {
+			return routes.ErrRouteToOtherDestinationExists
+		}
+		return fmt.Errorf("adding route %+v: %w", route, err)
+	}
+	r.routes = append(r.routes, route)
+	return nil
+}
+
+// Flush deletes all routes added by the router
+func (r *Router) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, route := range r.routes {
+		if err := deleteRoute(r.handle, route); err != nil {
+			return err
+		}
+		r.journal.Forget(journal.KindRoute, route.JournalKey())
+	}
+	r.routes = nil
+	return nil
+}
+
+// Reverter returns a journal reverter which deletes journaled routes in the
+// network namespace of the handle
+func Reverter(handle *netlink.Handle) journal.Reverter {
+	return func(_ string, value json.RawMessage) error {
+		var route routes.Route
+		if err := json.Unmarshal(value, &route); err != nil {
+			return fmt.Errorf("parsing journaled route: %w", err)
+		}
+		return deleteRoute(handle, route)
+	}
+}
+
+func (r *Router) has(route routes.Route) bool {
+	for _, ro := range r.routes {
+		if route.IsEqual(ro) {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteRoute(handle *netlink.Handle, route routes.Route) error {
+	nlRoute, err := toNetlinkRoute(handle, route)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		// routes are removed together with the interface
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := handle.RouteDel(nlRoute); err != nil && !errors.Is(err, unix.ESRCH) {
+		return fmt.Errorf("deleting route %+v: %w", route, err)
+	}
+	return nil
+}
+
+func toNetlinkRoute(handle *netlink.Handle, route routes.Route) (*netlink.Route, error) {
+	if route.Device.Name == "" {
+		return nil, errors.New("dev is empty")
+	}
+	link, err := handle.LinkByName(route.Device.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving link %s: %w", route.Device.Name, err)
+	}
+
+	nlRoute := &netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Table:     int(route.TableID),
+	}
+	switch {
+	case route.Subnet.IsValid():
+		nlRoute.Dst = toIPNet(route.Subnet)
+		if route.Gateway.IsValid() {
+			nlRoute.Gw = route.Gateway.AsSlice()
+		} else {
+			nlRoute.Scope = netlink.SCOPE_LINK
+		}
+	case route.Gateway.IsValid():
+		// same as `ip route add <gateway> dev <device>`
+		nlRoute.Dst = toIPNet(netip.PrefixFrom(route.Gateway, route.Gateway.BitLen()))
+		nlRoute.Scope = netlink.SCOPE_LINK
+	default:
+		nlRoute.Scope = netlink.SCOPE_LINK
+	}
+	return nlRoute, nil
+}
+
+// existsInMainTable checks for a route to the same destination either via the
+// same gateway or through the same device
+func existsInMainTable(handle *netlink.Handle, route *netlink.Route) (bool, error) {
+	existing, err := handle.RouteListFiltered(
+		family(route),
+		&netlink.Route{Table: unix.RT_TABLE_MAIN},
+		netlink.RT_FILTER_TABLE,
+	)
+	if err != nil {
+		return false, fmt.Errorf("listing routes: %w", err)
+	}
+	for _, ro := range existing {
+		if !isSameDst(ro.Dst, route.Dst) {
+			continue
+		}
+		if (route.Gw != nil && ro.Gw.Equal(route.Gw)) || ro.LinkIndex == route.LinkIndex {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func family(route *netlink.Route) int {
+	if route.Dst != nil && route.Dst.IP.To4() == nil {
+		return unix.AF_INET6
+	}
+	if route.Dst == nil && route.Gw != nil && route.Gw.To4() == nil {
+		return unix.AF_INET6
+	}
+	return unix.AF_INET
+}
+
+// isSameDst compares destinations, where nil stands for the default route
+func isSameDst(a, b *net.IPNet) bool {
+	if isDefault(a) || isDefault(b) {
+		return isDefault(a) && isDefault(b)
+	}
+	return a.String() == b.String()
+}
+
+func isDefault(dst *net.IPNet) bool {
+	if dst == nil {
+		return true
+	}
+	ones, _ := dst.Mask.Size()
+	return ones == 0
+}
+
+func toIPNet(prefix netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   prefix.Addr().AsSlice(),
+		Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+	}
+}
@@ -0,0 +1,160 @@
+package netlinkrouter
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
+	testns "github.com/NordSecurity/nordvpn-linux/test/netns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func tableRoutes(t *testing.T, handle *netlink.Handle, family int, table int) []netlink.Route {
+	t.Helper()
+	list, err := handle.RouteListFiltered(family, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	require.NoError(t, err)
+	return list
+}
+
+func hasRoute(list []netlink.Route, dst string) bool {
+	for _, route := range list {
+		if dst == "default" && isDefault(route.Dst) || route.Dst != nil && route.Dst.String() == dst {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRouter_AddFlush(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t, "10.5.0.2/16", "fd00::2/64")
+	device := net.Interface{Name: testns.Device}
+
+	tests := []struct {
+		name   string
+		route  routes.Route
+		family int
+		dst    string
+	}{
+		{
+			name:   "default route to custom table",
+			route:  routes.Route{Subnet: netip.MustParsePrefix("0.0.0.0/0"), Device: device, TableID: 205},
+			family: unix.AF_INET,
+			dst:    "default",
+		},
+		{
+			name: "subnet via gateway",
+			route: routes.Route{
+				Subnet:  netip.MustParsePrefix("192.168.50.0/24"),
+				Gateway: netip.MustParseAddr("10.5.0.1"),
+				Device:  device,
+				TableID: 205,
+			},
+			family: unix.AF_INET,
+			dst:    "192.168.50.0/24",
+		},
+		{
+			name:   "ipv6 default route",
+			route:  routes.Route{Subnet: netip.MustParsePrefix("::/0"), Device: device, TableID: 205},
+			family: unix.AF_INET6,
+			dst:    "default",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := &testjournal.Memory{}
+			router := NewRouter(handle, changes)
+
+			require.NoError(t, router.Add(test.route))
+			assert.True(t, hasRoute(tableRoutes(t, handle, test.family, 205), test.dst))
+			assert.Equal(t, []string{test.route.JournalKey()}, changes.Keys(journal.KindRoute))
+			assert.Error(t, router.Add(test.route), "route must not be added twice")
+
+			require.NoError(t, router.Flush())
+			assert.False(t, hasRoute(tableRoutes(t, handle, test.family, 205), test.dst))
+			assert.Empty(t, changes.Entries)
+		})
+	}
+}
+
+func TestRouter_ExistingRoute(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t, "10.5.0.2/16")
+	changes := &testjournal.Memory{}
+	router := NewRouter(handle, changes)
+
+	// created by the kernel together with the address
+	existing := routes.Route{
+		Subnet: netip.MustParsePrefix("10.5.0.0/16"),
+		Device: net.Interface{Name: testns.Device},
+	}
+	require.NoError(t, router.Add(existing))
+	assert.Empty(t, changes.Entries, "routes not added by the router must not be journaled")
+
+	require.NoError(t, router.Flush())
+	assert.True(t, hasRoute(tableRoutes(t, handle, unix.AF_INET, unix.RT_TABLE_MAIN), "10.5.0.0/16"),
+		"routes not added by the router must not be flushed")
+}
+
+func TestRouter_OtherDestinationExists(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t, "10.5.0.2/16")
+	router := NewRouter(handle, &testjournal.Memory{})
+
+	require.NoError(t, router.Add(routes.Route{
+		Subnet:  netip.MustParsePrefix("192.168.50.0/24"),
+		Gateway: netip.MustParseAddr("10.5.0.1"),
+		Device:  net.Interface{Name: testns.Device},
+	}))
+	err := router.Add(routes.Route{
+		Subnet: netip.MustParsePrefix("192.168.50.0/24"),
+		Device: net.Interface{Name: testns.Peer},
+	})
+	assert.ErrorIs(t, err, routes.ErrRouteToOtherDestinationExists)
+}
+
+func TestRouter_InvalidDevice(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t)
+	router := NewRouter(handle, &testjournal.Memory{})
+
+	assert.Error(t, router.Add(routes.Route{Subnet: netip.MustParsePrefix("0.0.0.0/0")}))
+	assert.Error(t, router.Add(routes.Route{
+		Subnet: netip.MustParsePrefix("0.0.0.0/0"),
+		Device: net.Interface{Name: "nonexistent"},
+	}))
+}
+
+func TestReverter(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t, "10.5.0.2/16")
+	route := routes.Route{
+		Subnet:  netip.MustParsePrefix("0.0.0.0/0"),
+		Device:  net.Interface{Name: testns.Device},
+		TableID: 205,
+	}
+	require.NoError(t, NewRouter(handle, &testjournal.Memory{}).Add(route))
+
+	value, err := json.Marshal(route)
+	require.NoError(t, err)
+	revert := Reverter(handle)
+	require.NoError(t, revert(route.JournalKey(), value))
+	assert.Empty(t, tableRoutes(t, handle, unix.AF_INET, 205))
+
+	assert.NoError(t, revert(route.JournalKey(), value), "reverting must be idempotent")
+	assert.Error(t, revert(route.JournalKey(), json.RawMessage("{")))
+}
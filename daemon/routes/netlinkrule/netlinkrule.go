@@ -0,0 +1,272 @@
+/*
+Package netlinkrule provides routes.PolicyAgent implementation which talks to
+the kernel directly over netlink instead of executing ip rule.
+*/
+package netlinkrule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// maxTableID limits the search of unused routing table
+const maxTableID = 60000
+
+// Router uses netlink under the hood.
+//
+// Thread-safe.
+type Router struct {
+	handle          *netlink.Handle
+	rpFilterManager routes.RPFilterManager
+	tableID         uint
+	fwmark          uint32
+	journal         journal.Recorder
+	mu              sync.Mutex
+}
+
+// NewRouter creates a Router operating in the network namespace of the handle
+func NewRouter(
+	handle *netlink.Handle,
+	rpFilterManager routes.RPFilterManager,
+	fwmark uint32,
+	journal journal.Recorder,
+) *Router {
+	return &Router{
+		handle:          handle,
+		rpFilterManager: rpFilterManager,
+		fwmark:          fwmark,
+		journal:         journal,
+	}
+}
+
+// SetupRoutingRules setup policy based routing rules
+func (r *Router) SetupRoutingRules(
+	vpnInterface net.Interface,
+	ipv6Enabled bool,
+) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fwmark == 0 {
+		return errors.New("fwmark cannot be 0")
+	}
+
+	if err := r.rpFilterManager.Set(); err != nil {
+		return fmt.Errorf("setting rp filter: %w", err)
+	}
+
+	families := []int{unix.AF_INET}
+	if ipv6Enabled {
+		families = append(families, unix.AF_INET6)
+	}
+
+	r.journal.Record(journal.KindRule, ruleKey(r.fwmark), r.fwmark)
+	defer func() { // recover if error
+		if err == nil {
+			return
+		}
+
+		for _, family := range families {
+			if err := removeRules(r.handle, r.fwmark, family); err != nil {
+				log.Println(internal.DeferPrefix, err)
+			}
+		}
+		r.journal.Forget(journal.KindRule, ruleKey(r.fwmark))
+	}()
+
+	// the same table is used by both families, so it must be unused by either
+	tableID, err := unusedTableID(r.handle)
+	if err != nil {
+		return err
+	}
+	r.tableID = tableID
+
+	for _, family := range families {
+		priority, err := rulePriority(r.handle, family)
+		if err != nil {
+			return err
+		}
+		if err := r.handle.RuleAdd(fwmarkRule(r.fwmark, family, priority, tableID)); err != nil {
+			return fmt.Errorf("adding fwmark rule: %w", err)
+		}
+
+		priority, err = rulePriority(r.handle, family)
+		if err != nil {
+			return err
+		}
+		if err := r.handle.RuleAdd(suppressPrefixlenRule(family, priority)); err != nil {
+			return fmt.Errorf("adding suppress_prefixlength rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CleanupRouting for client node enable routing through exit node
+func (r *Router) CleanupRouting() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, family := range []int{unix.AF_INET, unix.AF_INET6} {
+		if err := removeRules(r.handle, r.fwmark, family); err != nil {
+			log.Println(internal.WarningPrefix, err)
+		}
+	}
+	r.journal.Forget(journal.KindRule, ruleKey(r.fwmark))
+
+	if err := r.rpFilterManager.Unset(); err != nil {
+		return fmt.Errorf("unsetting rp filter: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Router) TableID() uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tableID
+}
+
+// Reverter returns a journal reverter which removes journaled routing rules in
+// the network namespace of the handle. rp_filter is journaled and reverted
+// separately.
+func Reverter(handle *netlink.Handle) journal.Reverter {
+	return func(_ string, value json.RawMessage) error {
+		var fwmark uint32
+		if err := json.Unmarshal(value, &fwmark); err != nil {
+			return fmt.Errorf("parsing journaled fwmark: %w", err)
+		}
+		var errs []error
+		for _, family := range []int{unix.AF_INET, unix.AF_INET6} {
+			errs = append(errs, removeRules(handle, fwmark, family))
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// ruleKey identifies routing rules in the journal. It matches the key used by
+// iprule, so that either implementation could revert the rules.
+func ruleKey(fwmark uint32) string {
+	return fmt.Sprintf("fwmark %#x", fwmark)
+}
+
+// fwmarkRule is `not from all fwmark <fwmark> lookup <tableID>`
+func fwmarkRule(fwmark uint32, family int, priority int, tableID uint) *netlink.Rule {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Priority = priority
+	rule.Mark = int(fwmark)
+	rule.Table = int(tableID)
+	rule.Invert = true
+	return rule
+}
+
+// suppressPrefixlenRule is `from all lookup main suppress_prefixlength 0`
+func suppressPrefixlenRule(family int, priority int) *netlink.Rule {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Priority = priority
+	rule.Table = unix.RT_TABLE_MAIN
+	rule.SuppressPrefixlen = 0
+	return rule
+}
+
+func removeRules(handle *netlink.Handle, fwmark uint32, family int) error {
+	var errs []error
+	// priorities are not set, so that rules are matched by their selectors only
+	if err := handle.RuleDel(suppressPrefixlenRule(family, -1)); err != nil && !isNotFound(err) {
+		errs = append(errs, fmt.Errorf("removing suppress_prefixlength rule: %w", err))
+	}
+	if fwmark != 0 {
+		if err := handle.RuleDel(fwmarkRule(fwmark, family, -1, 0)); err != nil && !isNotFound(err) {
+			errs = append(errs, fmt.Errorf("removing fwmark rule: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isNotFound reports whether the rule does not exist or could not exist at all
+// because the address family is disabled
+func isNotFound(err error) bool {
+	return errors.Is(err, unix.ENOENT) || errors.Is(err, unix.EAFNOSUPPORT)
+}
+
+// rulePriority finds an unused priority right before `from all lookup main` rule.
+// Rules with the lower priorities may already exist and rules added by the
+// router must be evaluated before them, see iprule.calculateRulePriority for
+// the example.
+func rulePriority(handle *netlink.Handle, family int) (int, error) {
+	rules, err := handle.RuleList(family)
+	if err != nil {
+		return 0, fmt.Errorf("listing rules: %w", err)
+	}
+
+	priority := 0
+	used := map[int]bool{}
+	for _, rule := range rules {
+		used[rule.Priority] = true
+		if isLookupMain(rule) {
+			priority = rule.Priority
+		}
+	}
+	if priority == 0 {
+		return 0, errors.New("unable to calculate rule priority")
+	}
+
+	for {
+		priority--
+		if priority <= 0 {
+			return 0, errors.New("unable to calculate rule priority")
+		}
+		if !used[priority] {
+			return priority, nil
+		}
+	}
+}
+
+// isLookupMain reports whether the rule is `from all lookup main`
+func isLookupMain(rule netlink.Rule) bool {
+	return rule.Table == unix.RT_TABLE_MAIN &&
+		rule.Src == nil &&
+		rule.Dst == nil &&
+		rule.Mark == -1 &&
+		rule.SuppressPrefixlen == -1 &&
+		rule.IifName == "" &&
+		rule.OifName == "" &&
+		!rule.Invert
+}
+
+// unusedTableID finds a routing table without IPv4 and IPv6 routes starting
+// from the default one
+func unusedTableID(handle *netlink.Handle) (uint, error) {
+	all, err := handle.RouteListFiltered(
+		unix.AF_UNSPEC,
+		&netlink.Route{Table: unix.RT_TABLE_UNSPEC},
+		netlink.RT_FILTER_TABLE,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("listing routes: %w", err)
+	}
+
+	used := map[uint]bool{}
+	for _, route := range all {
+		used[uint(route.Table)] = true
+	}
+	for id := routes.TableID(); id <= maxTableID; id++ {
+		if !used[id] {
+			return id, nil
+		}
+	}
+	return 0, errors.New("unable to calculate custom table id")
+}
@@ -0,0 +1,149 @@
+package netlinkrule
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
+	testns "github.com/NordSecurity/nordvpn-linux/test/netns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+type failingRPFilterManager struct{}
+
+func (failingRPFilterManager) Set() error   { return errors.New("rp_filter") }
+func (failingRPFilterManager) Unset() error { return nil }
+
+type workingRPFilterManager struct{}
+
+func (workingRPFilterManager) Set() error   { return nil }
+func (workingRPFilterManager) Unset() error { return nil }
+
+func listRules(t *testing.T, handle *netlink.Handle, family int) []netlink.Rule {
+	t.Helper()
+	rules, err := handle.RuleList(family)
+	require.NoError(t, err)
+	return rules
+}
+
+// findRules returns priorities of the fwmark and suppress_prefixlength rules
+// or -1 if they do not exist
+func findRules(rules []netlink.Rule, fwmark uint32) (fwmarkPriority int, suppressPriority int) {
+	fwmarkPriority, suppressPriority = -1, -1
+	for _, rule := range rules {
+		if rule.Invert && rule.Mark == int(fwmark) {
+			fwmarkPriority = rule.Priority
+		}
+		if rule.Table == unix.RT_TABLE_MAIN && rule.SuppressPrefixlen == 0 {
+			suppressPriority = rule.Priority
+		}
+	}
+	return fwmarkPriority, suppressPriority
+}
+
+func TestRouter_SetupAndCleanup(t *testing.T) {
+	category.Set(t, category.Root)
+
+	for _, ipv6 := range []bool{false, true} {
+		handle := testns.NewHandle(t)
+		// rule which must stay after the rules added by the router
+		occupied := netlink.NewRule()
+		occupied.Priority = 32765
+		occupied.Table = unix.RT_TABLE_MAIN
+		occupied.Src = &net.IPNet{IP: net.IPv4(1, 1, 1, 1), Mask: net.CIDRMask(32, 32)}
+		require.NoError(t, handle.RuleAdd(occupied))
+		// table which must not be reused
+		require.NoError(t, handle.RouteAdd(&netlink.Route{
+			LinkIndex: linkIndex(t, handle, testns.Device),
+			Dst:       &net.IPNet{IP: net.IPv4(192, 168, 50, 0), Mask: net.CIDRMask(24, 32)},
+			Scope:     netlink.SCOPE_LINK,
+			Table:     205,
+		}))
+
+		changes := &testjournal.Memory{}
+		var fwmark uint32 = 0xe1f1
+		router := NewRouter(handle, workingRPFilterManager{}, fwmark, changes)
+		require.NoError(t, router.SetupRoutingRules(net.Interface{}, ipv6))
+		assert.Equal(t, uint(206), router.TableID())
+		assert.Equal(t, []string{ruleKey(fwmark)}, changes.Keys(journal.KindRule))
+
+		families := []int{unix.AF_INET}
+		if ipv6 {
+			families = append(families, unix.AF_INET6)
+		}
+		for _, family := range families {
+			fwmarkPriority, suppressPriority := findRules(listRules(t, handle, family), fwmark)
+			if family == unix.AF_INET {
+				assert.Equal(t, 32764, fwmarkPriority)
+				assert.Equal(t, 32763, suppressPriority)
+			} else {
+				assert.Equal(t, 32765, fwmarkPriority)
+				assert.Equal(t, 32764, suppressPriority)
+			}
+		}
+		if !ipv6 {
+			fwmarkPriority, suppressPriority := findRules(listRules(t, handle, unix.AF_INET6), fwmark)
+			assert.Equal(t, -1, fwmarkPriority)
+			assert.Equal(t, -1, suppressPriority)
+		}
+
+		require.NoError(t, router.CleanupRouting())
+		assert.Empty(t, changes.Entries)
+		for _, family := range []int{unix.AF_INET, unix.AF_INET6} {
+			fwmarkPriority, suppressPriority := findRules(listRules(t, handle, family), fwmark)
+			assert.Equal(t, -1, fwmarkPriority)
+			assert.Equal(t, -1, suppressPriority)
+		}
+		assert.Len(t, listRules(t, handle, unix.AF_INET), 4, "only added rules must be removed")
+	}
+}
+
+func TestRouter_JournalFailedSetup(t *testing.T) {
+	category.Set(t, category.Root)
+
+	changes := &testjournal.Memory{}
+	router := NewRouter(testns.NewHandle(t), failingRPFilterManager{}, 0xe1f1, changes)
+	assert.Error(t, router.SetupRoutingRules(net.Interface{}, false))
+	assert.Empty(t, changes.Entries)
+
+	router = NewRouter(testns.NewHandle(t), workingRPFilterManager{}, 0, changes)
+	assert.Error(t, router.SetupRoutingRules(net.Interface{}, false))
+	assert.Empty(t, changes.Entries)
+}
+
+func TestReverter(t *testing.T) {
+	category.Set(t, category.Root)
+
+	handle := testns.NewHandle(t)
+	var fwmark uint32 = 0xe1f1
+	router := NewRouter(handle, workingRPFilterManager{}, fwmark, &testjournal.Memory{})
+	require.NoError(t, router.SetupRoutingRules(net.Interface{}, true))
+
+	value, err := json.Marshal(fwmark)
+	require.NoError(t, err)
+	revert := Reverter(handle)
+	require.NoError(t, revert(ruleKey(fwmark), value))
+	for _, family := range []int{unix.AF_INET, unix.AF_INET6} {
+		fwmarkPriority, suppressPriority := findRules(listRules(t, handle, family), fwmark)
+		assert.Equal(t, -1, fwmarkPriority)
+		assert.Equal(t, -1, suppressPriority)
+	}
+
+	assert.NoError(t, revert(ruleKey(fwmark), value), "reverting must be idempotent")
+	assert.Error(t, revert(ruleKey(fwmark), json.RawMessage("{")))
+}
+
+func linkIndex(t *testing.T, handle *netlink.Handle, name string) int {
+	t.Helper()
+	link, err := handle.LinkByName(name)
+	require.NoError(t, err)
+	return link.Attrs().Index
+}
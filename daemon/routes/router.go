@@ -21,6 +21,11 @@ type Route struct {
 	TableID uint
 }
 
+// JournalKey identifies the route in the journal of system changes
+func (r *Route) JournalKey() string {
+	return fmt.Sprintf("%s via %s dev %s table %d", r.Subnet, r.Gateway, r.Device.Name, r.TableID)
+}
+
 // IsEqual compares to routes for equality.
 func (r *Route) IsEqual(to Route) bool {
 	return r.Gateway == to.Gateway &&
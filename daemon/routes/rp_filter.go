@@ -3,6 +3,7 @@ package routes
 import (
 	"fmt"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/kernel"
 )
 
@@ -21,9 +22,9 @@ type SysctlRPFilterManager struct {
 	setter *kernel.SysctlSetter
 }
 
-func NewSysctlRPFilterManager() *SysctlRPFilterManager {
+func NewSysctlRPFilterManager(journal journal.Recorder) *SysctlRPFilterManager {
 	return &SysctlRPFilterManager{
-		setter: kernel.NewSysctlSetter("net.ipv4.conf.all.rp_filter", 2, 1),
+		setter: kernel.NewSysctlSetter("net.ipv4.conf.all.rp_filter", 2, 1, journal),
 	}
 }
 
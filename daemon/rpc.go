@@ -3,6 +3,8 @@
 package daemon
 
 import (
+	"net/netip"
+	"sync"
 	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/auth"
@@ -36,6 +38,7 @@ type RPC struct {
 	repo            *RepoAPI
 	authentication  core.Authentication
 	lastServer      core.Server
+	lastTarget      *pb.ConnectRequest
 	version         string
 	systemInfoFunc  func(string) string
 	networkInfoFunc func() string
@@ -54,6 +57,24 @@ type RPC struct {
 	supportChecker   SupportChecker
 	analytics        events.Analytics
 	fileshare        meshnet.Fileshare
+	// policyPath points to the administrator policy file
+	policyPath string
+	// auditLog holds the history of config changes
+	auditLog *config.AuditLog
+	// settingsBroadcaster forwards settings events to gRPC clients
+	settingsBroadcaster *SettingsBroadcaster
+	// fingerprint identifies the current network for trusted network rules
+	fingerprint func() (netstate.Fingerprint, error)
+	// captivePortal is probed before connecting
+	captivePortal CaptivePortalDetector
+	// persistentKillSwitch keeps blocking traffic when the daemon is not running
+	persistentKillSwitch PersistentKillSwitch
+	// localSubnets are allowed when LAN discovery is enabled
+	localSubnets func() ([]netip.Prefix, error)
+	// whitelistMu serializes whitelist changes of the setter and the expiry job
+	whitelistMu sync.Mutex
+	// lastTargetMu guards lastTarget which is also read by the reconnectors
+	lastTargetMu sync.Mutex
 	pb.UnimplementedDaemonServer
 }
 
@@ -81,32 +102,46 @@ func NewRPC(
 	supportChecker SupportChecker,
 	analytics events.Analytics,
 	fileshare meshnet.Fileshare,
+	captivePortal CaptivePortalDetector,
+	persistentKillSwitch PersistentKillSwitch,
+	localSubnets func() ([]netip.Prefix, error),
+	auditLog *config.AuditLog,
 ) *RPC {
+	settingsBroadcaster := NewSettingsBroadcaster()
+	events.Settings.Subscribe(settingsBroadcaster)
+
 	return &RPC{
-		environment:      environment,
-		ac:               ac,
-		cm:               cm,
-		dm:               dm,
-		api:              api,
-		serversAPI:       serversAPI,
-		credentialsAPI:   credentialsAPI,
-		cdn:              cdn,
-		repo:             repo,
-		authentication:   authentication,
-		version:          version,
-		systemInfoFunc:   getSystemInfo,
-		networkInfoFunc:  getNetworkInfo,
-		httpClient:       httpClient,
-		factory:          factory,
-		events:           events,
-		endpointResolver: endpointResolver,
-		scheduler:        gocron.NewScheduler(time.UTC),
-		netw:             netw,
-		publisher:        publisher,
-		nameservers:      nameservers,
-		ncClient:         ncClient,
-		supportChecker:   supportChecker,
-		analytics:        analytics,
-		fileshare:        fileshare,
+		environment:          environment,
+		ac:                   ac,
+		cm:                   cm,
+		dm:                   dm,
+		api:                  api,
+		serversAPI:           serversAPI,
+		credentialsAPI:       credentialsAPI,
+		cdn:                  cdn,
+		repo:                 repo,
+		authentication:       authentication,
+		version:              version,
+		systemInfoFunc:       getSystemInfo,
+		networkInfoFunc:      getNetworkInfo,
+		httpClient:           httpClient,
+		factory:              factory,
+		events:               events,
+		endpointResolver:     endpointResolver,
+		scheduler:            gocron.NewScheduler(time.UTC),
+		netw:                 netw,
+		publisher:            publisher,
+		nameservers:          nameservers,
+		ncClient:             ncClient,
+		supportChecker:       supportChecker,
+		analytics:            analytics,
+		fileshare:            fileshare,
+		policyPath:           config.PolicyFilePath,
+		auditLog:             auditLog,
+		settingsBroadcaster:  settingsBroadcaster,
+		fingerprint:          netstate.CurrentFingerprint,
+		captivePortal:        captivePortal,
+		persistentKillSwitch: persistentKillSwitch,
+		localSubnets:         localSubnets,
 	}
 }
@@ -42,7 +42,7 @@ func (r *RPC) AccountInfo(ctx context.Context, _ *pb.Empty) (*pb.AccountResponse
 		log.Println(internal.ErrorPrefix, "retrieving user:", err)
 		switch {
 		case errors.Is(err, core.ErrUnauthorized):
-			if err := r.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, r.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				return nil, err
 			}
 			return nil, internal.ErrNotLoggedIn
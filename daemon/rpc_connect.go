@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/auth"
 	"github.com/NordSecurity/nordvpn-linux/config"
@@ -35,7 +36,32 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 		return srv.Send(&pb.Payload{Type: internal.CodeAccountExpired})
 	}
 
-	insights := r.dm.GetInsightsData().Insights
+	if in.GetProfile() != "" {
+		payload := r.useProfile(srv.Context(), cfg, in.GetProfile())
+		switch payload.Type {
+		case internal.CodeSuccess, internal.CodeNothingToDo:
+			if err := r.cm.Load(&cfg); err != nil {
+				log.Println(internal.ErrorPrefix, err)
+			}
+		default:
+			return srv.Send(payload)
+		}
+	}
+
+	// per user settings are not saved, so they do not leak to other users
+	cfg, err := r.userConfig(srv.Context(), cfg)
+	if err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return srv.Send(&pb.Payload{Type: internal.CodePolicyEnforced})
+	}
+
+	if err := r.passCaptivePortal(srv); err != nil {
+		return err
+	}
+
+	insights := userLocation(cfg.Location, r.dm.GetInsightsData().Insights)
+
+	serverTag := in.GetServerTag()
 
 	log.Println(internal.DebugPrefix, "picking servers for", cfg.Technology, "technology")
 	server, remote, err := PickServer(
@@ -47,7 +73,7 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 		cfg.Technology,
 		cfg.AutoConnectData.Protocol,
 		cfg.AutoConnectData.Obfuscate,
-		in.GetServerTag(),
+		serverTag,
 		in.GetServerGroup(),
 	)
 
@@ -55,7 +81,7 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 		log.Println(internal.ErrorPrefix, "picking servers:", err)
 		switch {
 		case errors.Is(err, core.ErrUnauthorized):
-			if err := r.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(srv.Context(), r.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				return err
 			}
 			return internal.ErrNotLoggedIn
@@ -96,6 +122,10 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 		return internal.ErrUnhandled
 	}
 	r.lastServer = server
+	// profile is left out, because useProfile has saved it to the config
+	r.lastTargetMu.Lock()
+	r.lastTarget = &pb.ConnectRequest{ServerTag: serverTag, ServerGroup: in.GetServerGroup()}
+	r.lastTargetMu.Unlock()
 
 	eventCh := make(chan ConnectEvent)
 
@@ -118,14 +148,26 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 		Obfuscated:        cfg.AutoConnectData.Obfuscate,
 		OpenVPNVersion:    server.Version(),
 	}
+	// tunnel is dual-stack when enabled and supported by the server, regardless
+	// of which address is used to reach the server
+	ipv6 := dualStack(cfg, server)
+	if ipv6 {
+		if subnet.Addr().Is6() {
+			serverData.IPv6 = subnet.Addr()
+		} else if ip, err := server.IPv6(); err == nil {
+			serverData.IPv6 = ip
+		}
+	}
 
+	// expired entries may not have been removed by the expiry job yet
+	whitelist, _ := cfg.AutoConnectData.Whitelist.RemoveExpired(time.Now())
 	go Connect(
 		eventCh,
 		creds,
 		serverData,
-		cfg.AutoConnectData.Whitelist,
+		whitelist,
 		cfg.AutoConnectData.DNS.Or(
-			r.nameservers.Get(cfg.AutoConnectData.ThreatProtectionLite, server.SupportsIPv6()),
+			r.nameservers.Get(cfg.AutoConnectData.ThreatProtectionLite, ipv6),
 		),
 		r.netw,
 	)
@@ -156,10 +198,10 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 			}
 			r.events.Service.Connect.Publish(event)
 		case internal.CodeConnected:
-			// If server has at least one IPv6 address
-			// regardless if IPv4 or IPv6 is used to connect
+			// If IPv6 is enabled and server has at least one IPv6
+			// address regardless if IPv4 or IPv6 is used to connect
 			// to the server - DO NOT DISABLE IPv6.
-			if !server.SupportsIPv6() {
+			if !ipv6 {
 				if err := r.netw.DenyIPv6(); err != nil {
 					log.Println(internal.ErrorPrefix, "failed to disable ipv6:")
 				}
@@ -173,6 +215,12 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 				return internal.ErrUnhandled
 			}
 			r.publisher.Publish("connected to vpn")
+			if cfg.KillSwitchPersistent {
+				// boot rules allow reconnecting to the latest server
+				if err := r.setPersistentKillSwitch(true); err != nil {
+					log.Println(internal.WarningPrefix, "updating persistent killswitch:", err)
+				}
+			}
 			if r.systemInfoFunc != nil && r.networkInfoFunc != nil {
 				defer func() {
 					log.Printf("POST_CONNECT system info:\n%s\n", r.networkInfoFunc())
@@ -200,3 +248,8 @@ func (r *RPC) Connect(in *pb.ConnectRequest, srv pb.Daemon_ConnectServer) error
 }
 
 type FactoryFunc func(config.Technology) (vpn.VPN, error)
+
+// dualStack reports whether the tunnel to the server carries IPv6 traffic.
+func dualStack(cfg config.Config, server core.Server) bool {
+	return cfg.IPv6 && server.SupportsIPv6()
+}
@@ -32,7 +32,7 @@ type mockRPCServer struct{}
 func (mockRPCServer) SetHeader(metadata.MD) error  { return nil }
 func (mockRPCServer) SendHeader(metadata.MD) error { return nil }
 func (mockRPCServer) SetTrailer(metadata.MD)       {}
-func (mockRPCServer) Context() context.Context     { return nil }
+func (mockRPCServer) Context() context.Context     { return context.Background() }
 func (mockRPCServer) SendMsg(m interface{}) error  { return nil }
 func (mockRPCServer) RecvMsg(m interface{}) error  { return nil }
 func (mockRPCServer) Send(*pb.Payload) error       { return nil }
@@ -109,16 +109,58 @@ type mockAnalytics struct{}
 func (*mockAnalytics) Enable() error  { return nil }
 func (*mockAnalytics) Disable() error { return nil }
 
+// recordingNetworker stores arguments of the last Start call
+type recordingNetworker struct {
+	workingNetworker
+	server    vpn.ServerData
+	whitelist config.Whitelist
+}
+
+func (n *recordingNetworker) Start(
+	_ vpn.Credentials,
+	server vpn.ServerData,
+	whitelist config.Whitelist,
+	_ config.DNS,
+) error {
+	n.server = server
+	n.whitelist = whitelist
+	return nil
+}
+
+// dualStackServersAPI recommends servers which also have IPv6 addresses
+type dualStackServersAPI struct {
+	mockServersAPI
+}
+
+func (d dualStackServersAPI) RecommendedServers(
+	filter core.ServersFilter,
+	longitude float64,
+	latitude float64,
+) (core.Servers, http.Header, error) {
+	servers, headers, err := d.mockServersAPI.RecommendedServers(filter, longitude, latitude)
+	for i := range servers {
+		servers[i].IPRecords = append(servers[i].IPRecords, core.ServerIPRecord{
+			ServerIP: core.ServerIP{IP: "2001:db8::1", Version: 6},
+		})
+	}
+	return servers, headers, err
+}
+
 func TestRpcConnect(t *testing.T) {
 	category.Set(t, category.Route)
 
 	defer testsCleanup()
+	expired := time.Now().Add(-time.Minute)
+	permanent := config.NewWhitelist([]int64{22}, nil, nil)
+	whitelist, _ := permanent.Add(config.WhitelistEntry{Ports: config.NewPortRange(8080, 8080), Expiry: &expired})
 	tests := []struct {
-		name      string
-		factory   FactoryFunc
-		netw      networker.Networker
-		retriever routes.GatewayRetriever
-		fw        firewall.Service
+		name       string
+		factory    FactoryFunc
+		netw       networker.Networker
+		retriever  routes.GatewayRetriever
+		fw         firewall.Service
+		serversAPI core.ServersAPI
+		ipv6       bool
 	}{
 		{
 			name: "successfull connect",
@@ -138,6 +180,36 @@ func TestRpcConnect(t *testing.T) {
 			retriever: newGatewayMock(netip.Addr{}),
 			fw:        &workingFirewall{},
 		},
+		{
+			name: "expired whitelist entries are not applied",
+			factory: func(config.Technology) (vpn.VPN, error) {
+				return &workingVPN{}, nil
+			},
+			netw:      &recordingNetworker{},
+			retriever: newGatewayMock(netip.Addr{}),
+			fw:        &workingFirewall{},
+		},
+		{
+			name: "dual-stack tunnel",
+			factory: func(config.Technology) (vpn.VPN, error) {
+				return &workingVPN{}, nil
+			},
+			netw:       &recordingNetworker{},
+			retriever:  newGatewayMock(netip.Addr{}),
+			fw:         &workingFirewall{},
+			serversAPI: dualStackServersAPI{},
+			ipv6:       true,
+		},
+		{
+			name: "ipv6 disabled",
+			factory: func(config.Technology) (vpn.VPN, error) {
+				return &workingVPN{}, nil
+			},
+			netw:       &recordingNetworker{},
+			retriever:  newGatewayMock(netip.Addr{}),
+			fw:         &workingFirewall{},
+			serversAPI: dualStackServersAPI{},
+		},
 	}
 
 	for _, test := range tests {
@@ -147,6 +219,11 @@ func TestRpcConnect(t *testing.T) {
 			tokenData.TokenExpiry = time.Now().Add(time.Hour * 1).Format(internal.ServerDateFormat)
 			tokenData.ServiceExpiry = time.Now().Add(time.Hour * 1).Format(internal.ServerDateFormat)
 			cm.c.TokensData[cm.c.AutoConnectData.ID] = tokenData
+			cm.c.AutoConnectData.Whitelist = whitelist
+			cm.c.IPv6 = test.ipv6
+			if test.serversAPI == nil {
+				test.serversAPI = &mockServersAPI{}
+			}
 			dm := testNewDataManager()
 			api := core.NewDefaultAPI(
 				"1.0.0",
@@ -163,7 +240,7 @@ func TestRpcConnect(t *testing.T) {
 				cm,
 				dm,
 				api,
-				&mockServersAPI{},
+				test.serversAPI,
 				&validCredentialsAPI{},
 				testNewCDNAPI(),
 				testNewRepoAPI(),
@@ -202,9 +279,17 @@ func TestRpcConnect(t *testing.T) {
 				NewMockSupportChecker(),
 				&mockAnalytics{},
 				mock.Fileshare{},
+				mockCaptivePortal{},
+				nil,
+				nil,
+				nil,
 			)
 			err := rpc.Connect(&pb.ConnectRequest{}, &mockRPCServer{})
 			assert.NoError(t, err)
+			if recorder, ok := test.netw.(*recordingNetworker); ok {
+				assert.Equal(t, permanent, recorder.whitelist)
+				assert.Equal(t, test.ipv6, recorder.server.IPv6.IsValid())
+			}
 		})
 	}
 }
@@ -282,6 +367,10 @@ func TestRpcReconnect(t *testing.T) {
 		NewMockSupportChecker(),
 		&mockAnalytics{},
 		mock.Fileshare{},
+		mockCaptivePortal{},
+		nil,
+		nil,
+		nil,
 	)
 	err := rpc.Connect(&pb.ConnectRequest{}, &mockRPCServer{})
 	assert.NoError(t, err)
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"log"
+	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
@@ -16,11 +17,29 @@ func (r *RPC) Disconnect(_ *pb.Empty, srv pb.Daemon_DisconnectServer) error {
 		})
 	}
 
-	if err := r.netw.Stop(); err != nil {
+	if err := r.disconnect(DisconnectReasonUser); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return internal.ErrUnhandled
 	}
 
+	return srv.Send(&pb.Payload{
+		Type: internal.CodeDisconnected,
+	})
+}
+
+// disconnect stops the VPN connection and records why it was stopped.
+func (r *RPC) disconnect(reason string) error {
+	// collect connection statistics before they are gone
+	status, _ := r.netw.ConnectionStatus()
+	var uptime time.Duration
+	if status.Uptime != nil {
+		uptime = *status.Uptime
+	}
+
+	if err := r.netw.Stop(); err != nil {
+		return err
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -30,14 +49,12 @@ func (r *RPC) Disconnect(_ *pb.Empty, srv pb.Daemon_DisconnectServer) error {
 		Protocol:             cfg.AutoConnectData.Protocol,
 		Type:                 events.DisconnectSuccess,
 		Technology:           cfg.Technology,
+		TargetServerDomain:   status.Hostname,
 		ThreatProtectionLite: cfg.AutoConnectData.ThreatProtectionLite,
+		Reason:               reason,
+		Uptime:               uptime,
+		Download:             status.Download,
+		Upload:               status.Upload,
 	})
-	if err := Notify(r.cm, internal.NotificationDisconnected, []string{}); err != nil {
-		log.Println(internal.ErrorPrefix, err)
-		return internal.ErrUnhandled
-	}
-
-	return srv.Send(&pb.Payload{
-		Type: internal.CodeDisconnected,
-	})
+	return Notify(r.cm, internal.NotificationDisconnected, []string{})
 }
@@ -24,7 +24,7 @@ type customCallbackType func() (*core.LoginResponse, *pb.LoginResponse, error)
 // Login the user
 func (r *RPC) Login(ctx context.Context, in *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// login common with custom logic
-	return r.loginCommon(func() (*core.LoginResponse, *pb.LoginResponse, error) {
+	return r.loginCommon(ctx, func() (*core.LoginResponse, *pb.LoginResponse, error) {
 		resp, err := r.api.Login(in.GetUsername(), in.GetPassword())
 		if err != nil {
 			log.Println(internal.ErrorPrefix, "logging in:", err)
@@ -57,7 +57,7 @@ func (r *RPC) LoginWithToken(ctx context.Context, in *pb.LoginWithTokenRequest)
 		}, nil
 	}
 	// login common with custom logic
-	return r.loginCommon(func() (*core.LoginResponse, *pb.LoginResponse, error) {
+	return r.loginCommon(ctx, func() (*core.LoginResponse, *pb.LoginResponse, error) {
 		if in.GetToken() != "" {
 			return &core.LoginResponse{
 				Token:     in.GetToken(),
@@ -71,7 +71,7 @@ func (r *RPC) LoginWithToken(ctx context.Context, in *pb.LoginWithTokenRequest)
 }
 
 // loginCommon common login
-func (r *RPC) loginCommon(customCB customCallbackType) (*pb.LoginResponse, error) {
+func (r *RPC) loginCommon(ctx context.Context, customCB customCallbackType) (*pb.LoginResponse, error) {
 	if r.ac.IsLoggedIn() {
 		return nil, internal.ErrAlreadyLoggedIn
 	}
@@ -107,7 +107,7 @@ func (r *RPC) loginCommon(customCB customCallbackType) (*pb.LoginResponse, error
 		}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 		c.TokensData[credentials.ID] = config.TokenData{
 			Token:              resp.Token,
 			RenewToken:         resp.RenewToken,
@@ -172,7 +172,7 @@ func (r *RPC) LoginOAuth2Callback(ctx context.Context, in *pb.String) (*pb.Empty
 		return &pb.Empty{}, err
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 		c.TokensData[credentials.ID] = config.TokenData{
 			Token:              resp.Token,
 			RenewToken:         resp.RenewToken,
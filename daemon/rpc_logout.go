@@ -80,7 +80,7 @@ func (r *RPC) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Payload, er
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 		delete(c.TokensData, c.AutoConnectData.ID)
 		c.AutoConnectData.ID = 0
 		c.Mesh = false
@@ -0,0 +1,131 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// ProfileCreate saves current connection settings under the given name.
+func (r *RPC) ProfileCreate(ctx context.Context, in *pb.ProfileRequest) (*pb.Payload, error) {
+	if !config.IsValidProfileName(in.GetName()) {
+		return &pb.Payload{Type: internal.CodeFormatError}, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	if _, ok := cfg.Profiles[in.GetName()]; ok {
+		return &pb.Payload{Type: internal.CodeConflict}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		if c.Profiles == nil {
+			c.Profiles = map[string]config.Profile{}
+		}
+		c.Profiles[in.GetName()] = config.NewProfile(c)
+		c.ActiveProfile = in.GetName()
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// ProfileUse applies all settings of the given profile at once.
+func (r *RPC) ProfileUse(ctx context.Context, in *pb.ProfileRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return r.useProfile(ctx, cfg, in.GetName()), nil
+}
+
+func (r *RPC) useProfile(ctx context.Context, cfg config.Config, name string) *pb.Payload {
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return &pb.Payload{Type: internal.CodeProfileNonexisting}
+	}
+
+	setActive := func(c config.Config) config.Config {
+		c.ActiveProfile = name
+		return c
+	}
+	payload := r.changeSettings(ctx, cfg, config.NewSettings(profile.Apply(cfg)), false, setActive)
+	if payload.Type == internal.CodeNothingToDo && cfg.ActiveProfile != name {
+		if err := config.SaveWithContext(ctx, r.cm, setActive); err != nil {
+			log.Println(internal.ErrorPrefix, err)
+			return &pb.Payload{Type: internal.CodeConfigError}
+		}
+	}
+	return payload
+}
+
+// ProfileDelete removes the given profile. Settings are left untouched.
+func (r *RPC) ProfileDelete(ctx context.Context, in *pb.ProfileRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	if _, ok := cfg.Profiles[in.GetName()]; !ok {
+		return &pb.Payload{Type: internal.CodeProfileNonexisting}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		delete(c.Profiles, in.GetName())
+		if c.ActiveProfile == in.GetName() {
+			c.ActiveProfile = ""
+		}
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// Profiles returns all profiles sorted by name.
+func (r *RPC) Profiles(ctx context.Context, _ *pb.Empty) (*pb.ProfilesResponse, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.ProfilesResponse{Type: internal.CodeConfigError}, nil
+	}
+
+	profiles := make([]*pb.Profile, 0, len(cfg.Profiles))
+	for name, profile := range cfg.Profiles {
+		profiles = append(profiles, profileToProtobuf(name, profile))
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return &pb.ProfilesResponse{
+		Type:     internal.CodeSuccess,
+		Profiles: profiles,
+		Active:   cfg.ActiveProfile,
+	}, nil
+}
+
+func profileToProtobuf(name string, profile config.Profile) *pb.Profile {
+	return &pb.Profile{
+		Name:                 name,
+		Technology:           profile.Technology,
+		Protocol:             profile.AutoConnectData.Protocol,
+		Obfuscate:            profile.AutoConnectData.Obfuscate,
+		KillSwitch:           profile.KillSwitch,
+		ThreatProtectionLite: profile.AutoConnectData.ThreatProtectionLite,
+		Dns:                  profile.AutoConnectData.DNS,
+		Whitelist:            whitelistToProtobuf(profile.AutoConnectData.Whitelist),
+		Ipv6:                 profile.IPv6,
+	}
+}
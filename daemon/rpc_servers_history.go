@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"context"
+	"sort"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+)
+
+// ServersHistory returns outcomes of past connections, most recently used servers first.
+func (r *RPC) ServersHistory(context.Context, *pb.Empty) (*pb.ServersHistoryResponse, error) {
+	data := r.dm.GetServerHistoryData()
+	servers := make([]*pb.ServerHistory, 0, len(data.Servers))
+	for hostname, history := range data.Servers {
+		var connectedAt int64
+		if !history.ConnectedAt.IsZero() {
+			connectedAt = history.ConnectedAt.Unix()
+		}
+		servers = append(servers, &pb.ServerHistory{
+			Hostname:           hostname,
+			Successes:          int64(history.Successes),
+			Failures:           int64(history.Failures),
+			AverageConnectTime: int64(history.AverageConnectTime()),
+			AverageThroughput:  history.AverageThroughput(),
+			DisconnectReason:   history.DisconnectReason,
+			ConnectedAt:        connectedAt,
+		})
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].ConnectedAt == servers[j].ConnectedAt {
+			return servers[i].Hostname < servers[j].Hostname
+		}
+		return servers[i].ConnectedAt > servers[j].ConnectedAt
+	})
+	return &pb.ServersHistoryResponse{Servers: servers}, nil
+}
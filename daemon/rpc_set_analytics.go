@@ -36,7 +36,7 @@ func (r *RPC) SetAnalytics(ctx context.Context, in *pb.SetGenericRequest) (*pb.P
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 		c.Analytics.Set(in.GetEnabled())
 		return c
 	}); err != nil {
@@ -11,6 +11,20 @@ import (
 )
 
 func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest) (*pb.Payload, error) {
+	settings := []string{config.SettingAutoConnect}
+	if in.GetAutoConnect() {
+		settings = append(settings, config.SettingAutoConnectServer)
+	}
+	if payload := r.policyPayload(func(c config.Config) config.Config {
+		c.AutoConnect = in.GetAutoConnect()
+		if in.GetAutoConnect() {
+			c.AutoConnectData.ServerTag = in.GetServerTag()
+		}
+		return c
+	}, settings...); payload != nil {
+		return payload, nil
+	}
+
 	if !r.ac.IsLoggedIn() {
 		return nil, internal.ErrNotLoggedIn
 	}
@@ -27,7 +41,7 @@ func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest)
 	}
 
 	if in.GetAutoConnect() {
-		switch core.IsServerObfuscated(r.dm.GetServersData().Servers, in.GetServerTag()) {
+		switch core.IsServerObfuscated(r.dm.GetServersSummary(), in.GetServerTag()) {
 		case core.ServerNotObfuscated:
 			if cfg.AutoConnectData.Obfuscate {
 				return &pb.Payload{
@@ -47,7 +61,7 @@ func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest)
 	}
 
 	if in.GetAutoConnect() {
-		if err := r.cm.SaveWith(func(c config.Config) config.Config {
+		if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 			c.AutoConnect = in.GetAutoConnect()
 			c.AutoConnectData = config.AutoConnectData{
 				ID:                   cfg.AutoConnectData.ID,
@@ -56,11 +70,9 @@ func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest)
 				ThreatProtectionLite: in.GetThreatProtectionLite(),
 				Obfuscate:            in.GetObfuscate(),
 				DNS:                  in.GetDns(),
-				Whitelist: config.NewWhitelist(
-					in.GetWhitelist().GetPorts().GetTcp(),
-					in.GetWhitelist().GetPorts().GetUdp(),
-					in.GetWhitelist().GetSubnets(),
-				),
+				Whitelist:            c.AutoConnectData.Whitelist,
+				PinnedServerID:       c.AutoConnectData.PinnedServerID,
+				PinnedServer:         c.AutoConnectData.PinnedServer,
 			}
 			return c
 		}); err != nil {
@@ -70,7 +82,7 @@ func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest)
 			}, nil
 		}
 	} else {
-		if err := r.cm.SaveWith(func(c config.Config) config.Config {
+		if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 			c.AutoConnect = in.GetAutoConnect()
 			return c
 		}); err != nil {
@@ -80,6 +92,10 @@ func (r *RPC) SetAutoConnect(ctx context.Context, in *pb.SetAutoconnectRequest)
 			}, nil
 		}
 	}
+	// auto connect data is replaced with the values kept by the client
+	if err := EnforcePolicy(r.cm, r.policyPath); err != nil {
+		log.Println(internal.WarningPrefix, "enforcing policy:", err)
+	}
 	r.events.Settings.Autoconnect.Publish(in.GetAutoConnect())
 
 	return &pb.Payload{
@@ -30,13 +30,17 @@ func (r *RPC) SetDefaults(ctx context.Context, in *pb.Empty) (*pb.Payload, error
 		log.Println(internal.WarningPrefix, err)
 	}
 
-	if err := r.cm.Reset(); err != nil {
+	if err := config.ResetWithContext(ctx, r.cm); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
 		}, nil
 	}
 
+	if err := EnforcePolicy(r.cm, r.policyPath); err != nil {
+		log.Println(internal.WarningPrefix, "enforcing policy:", err)
+	}
+
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 	}
@@ -11,6 +11,15 @@ import (
 )
 
 func (r *RPC) SetDNS(ctx context.Context, in *pb.SetDNSRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.AutoConnectData.ThreatProtectionLite = in.GetThreatProtectionLite()
+		c.AutoConnectData.DNS = in.GetDns()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingDNS, config.SettingThreatProtectionLite); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -20,8 +29,9 @@ func (r *RPC) SetDNS(ctx context.Context, in *pb.SetDNSRequest) (*pb.Payload, er
 	if in.GetDns() != nil {
 		nameservers = in.GetDns()
 	} else {
-		subnet, _ := r.endpoint.Network() // safe to ignore the error
-		nameservers = r.nameservers.Get(in.GetThreatProtectionLite(), subnet.Addr().Is6())
+		// last server is not used when disconnected
+		ipv6 := r.netw.IsVPNActive() && dualStack(cfg, r.lastServer)
+		nameservers = r.nameservers.Get(in.GetThreatProtectionLite(), ipv6)
 	}
 
 	if err := r.netw.SetDNS(nameservers); err != nil {
@@ -31,11 +41,7 @@ func (r *RPC) SetDNS(ctx context.Context, in *pb.SetDNSRequest) (*pb.Payload, er
 		}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.AutoConnectData.ThreatProtectionLite = in.GetThreatProtectionLite()
-		c.AutoConnectData.DNS = in.GetDns()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
@@ -16,6 +16,14 @@ import (
 // - Whitelist
 // - Connect (impacts only connections, disconnect still works with the old setting)
 func (r *RPC) SetFirewall(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.Firewall = in.GetEnabled()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingFirewall); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -41,10 +49,7 @@ func (r *RPC) SetFirewall(ctx context.Context, in *pb.SetGenericRequest) (*pb.Pa
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.Firewall = in.GetEnabled()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{Type: internal.CodeConfigError}, nil
 	}
@@ -54,6 +59,14 @@ func (r *RPC) SetFirewall(ctx context.Context, in *pb.SetGenericRequest) (*pb.Pa
 }
 
 func (r *RPC) SetFirewallMark(ctx context.Context, in *pb.SetUint32Request) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.FirewallMark = in.GetValue()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingFirewallMark); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -63,10 +76,7 @@ func (r *RPC) SetFirewallMark(ctx context.Context, in *pb.SetUint32Request) (*pb
 		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.FirewallMark = in.GetValue()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{Type: internal.CodeConfigError}, nil
 	}
@@ -11,6 +11,14 @@ import (
 
 // SetIpv6 controls whether ipv6 usage should be allowed
 func (r *RPC) SetIpv6(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.IPv6 = in.GetEnabled()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingIPv6); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	err := r.cm.Load(&cfg)
 	if err != nil {
@@ -21,10 +29,7 @@ func (r *RPC) SetIpv6(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payloa
 		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.IPv6 = in.Enabled
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{Type: internal.CodeConfigError}, nil
 	}
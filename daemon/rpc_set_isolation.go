@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SetIsolation controls whether VPN connections are isolated in a dedicated
+// network namespace. Takes effect on the next connection.
+func (r *RPC) SetIsolation(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.Isolation = in.GetEnabled()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingIsolation); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	if cfg.Isolation == in.GetEnabled() {
+		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
+	}
+
+	if in.GetEnabled() {
+		// only NordLynx interface keeps working after being moved
+		if cfg.Technology != config.Technology_NORDLYNX {
+			return &pb.Payload{
+				Type: internal.CodeDependencyError,
+				Data: []string{config.SettingTechnology},
+			}, nil
+		}
+		if cfg.Mesh {
+			return &pb.Payload{
+				Type: internal.CodeDependencyError,
+				Data: []string{config.SettingMeshnet},
+			}, nil
+		}
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	r.netw.SetIsolation(in.GetEnabled())
+
+	return &pb.Payload{
+		Type: internal.CodeSuccess,
+		Data: []string{strconv.FormatBool(r.netw.IsVPNActive())},
+	}, nil
+}
@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPC_SetIsolation(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name       string
+		technology config.Technology
+		mesh       bool
+		enabled    bool
+		isolation  bool
+		code       int64
+		data       []string
+	}{
+		{
+			name:       "enable",
+			technology: config.Technology_NORDLYNX,
+			enabled:    true,
+			isolation:  true,
+			code:       internal.CodeSuccess,
+			data:       []string{"true"},
+		},
+		{
+			name:       "already disabled",
+			technology: config.Technology_NORDLYNX,
+			code:       internal.CodeNothingToDo,
+		},
+		{
+			name:       "openvpn",
+			technology: config.Technology_OPENVPN,
+			enabled:    true,
+			code:       internal.CodeDependencyError,
+			data:       []string{config.SettingTechnology},
+		},
+		{
+			name:       "meshnet",
+			technology: config.Technology_NORDLYNX,
+			mesh:       true,
+			enabled:    true,
+			code:       internal.CodeDependencyError,
+			data:       []string{config.SettingMeshnet},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cm := newMockConfigManager()
+			cm.c.Technology = test.technology
+			cm.c.Mesh = test.mesh
+			r := RPC{cm: cm, netw: workingNetworker{}}
+
+			payload, err := r.SetIsolation(context.Background(), &pb.SetGenericRequest{Enabled: test.enabled})
+			require.NoError(t, err)
+			assert.Equal(t, test.code, payload.Type)
+			assert.Equal(t, test.data, payload.Data)
+			assert.Equal(t, test.isolation, cm.c.Isolation)
+		})
+	}
+}
+
+func TestRPC_SetTechnologyWithIsolation(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	cm.c.Technology = config.Technology_NORDLYNX
+	cm.c.Isolation = true
+	r := RPC{cm: cm, netw: workingNetworker{}}
+
+	payload, err := r.SetTechnology(context.Background(), &pb.SetTechnologyRequest{Technology: config.Technology_OPENVPN})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeDependencyError, payload.Type)
+	assert.Equal(t, []string{config.SettingIsolation}, payload.Data)
+	assert.Equal(t, config.Technology_NORDLYNX, cm.c.Technology)
+}
+
+func TestRPC_SetIsolationPolicy(t *testing.T) {
+	category.Set(t, category.File)
+
+	if os.Getuid() != 0 {
+		t.Skip("policy file has to be owned by root")
+	}
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("enforce:\n  isolation: false\n"), 0644))
+	cm := newMockConfigManager()
+	cm.c.Technology = config.Technology_NORDLYNX
+	r := RPC{cm: cm, netw: workingNetworker{}, policyPath: path}
+
+	payload, err := r.SetIsolation(context.Background(), &pb.SetGenericRequest{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodePolicyEnforced, payload.Type)
+	assert.False(t, cm.c.Isolation)
+}
@@ -3,13 +3,35 @@ package daemon
 import (
 	"context"
 	"log"
+	"net/netip"
+	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 )
 
+// PersistentKillSwitch blocks traffic at boot and while the daemon is not running
+type PersistentKillSwitch interface {
+	// Enable stores and installs rules allowing only the given VPN endpoints
+	Enable([]netip.Addr) error
+	// Install applies previously enabled rules
+	Install() error
+	// Release removes the rules from the system and the boot configuration
+	Release() error
+	// IsEnabled reports whether rules are configured for the boot
+	IsEnabled() bool
+}
+
 func (r *RPC) SetKillSwitch(ctx context.Context, in *pb.SetKillSwitchRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.KillSwitch = in.GetKillSwitch()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingKillSwitch); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -19,24 +41,22 @@ func (r *RPC) SetKillSwitch(ctx context.Context, in *pb.SetKillSwitchRequest) (*
 		return &pb.Payload{Type: internal.CodeDependencyError}, nil
 	}
 
-	if cfg.KillSwitch == in.GetKillSwitch() {
+	persistent := in.GetKillSwitch() && in.GetPersistent()
+	if cfg.KillSwitch == in.GetKillSwitch() && cfg.KillSwitchPersistent == persistent {
 		return &pb.Payload{
 			Type: internal.CodeNothingToDo,
 		}, nil
 	}
 
 	if in.KillSwitch {
-		whitelist := config.NewWhitelist(
-			in.GetWhitelist().GetPorts().GetUdp(),
-			in.GetWhitelist().GetPorts().GetTcp(),
-			in.GetWhitelist().GetSubnets(),
-		)
-
-		if err := r.netw.SetKillSwitch(whitelist); err != nil {
-			log.Println(internal.ErrorPrefix, "enabling killswitch:", err)
-			return &pb.Payload{
-				Type: internal.CodeKillSwitchError,
-			}, nil
+		if !cfg.KillSwitch {
+			whitelist, _ := cfg.AutoConnectData.Whitelist.RemoveExpired(time.Now())
+			if err := r.netw.SetKillSwitch(whitelist); err != nil {
+				log.Println(internal.ErrorPrefix, "enabling killswitch:", err)
+				return &pb.Payload{
+					Type: internal.CodeKillSwitchError,
+				}, nil
+			}
 		}
 	} else {
 		if err := r.netw.UnsetKillSwitch(); err != nil {
@@ -47,8 +67,16 @@ func (r *RPC) SetKillSwitch(ctx context.Context, in *pb.SetKillSwitchRequest) (*
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
+	if err := r.setPersistentKillSwitch(persistent); err != nil {
+		log.Println(internal.ErrorPrefix, "changing persistent killswitch:", err)
+		return &pb.Payload{
+			Type: internal.CodeKillSwitchError,
+		}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 		c.KillSwitch = in.GetKillSwitch()
+		c.KillSwitchPersistent = persistent
 		return c
 	}); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -56,9 +84,79 @@ func (r *RPC) SetKillSwitch(ctx context.Context, in *pb.SetKillSwitchRequest) (*
 			Type: internal.CodeConfigError,
 		}, nil
 	}
-	r.events.Settings.Killswitch.Publish(in.GetKillSwitch())
+	if cfg.KillSwitch != in.GetKillSwitch() {
+		r.events.Settings.Killswitch.Publish(in.GetKillSwitch())
+	}
 
 	return &pb.Payload{
 		Type: internal.CodeSuccess,
 	}, nil
 }
+
+// ReleaseKillSwitch is an escape hatch which unblocks the traffic by turning off
+// both the kill switch and its persistent mode.
+func (r *RPC) ReleaseKillSwitch(ctx context.Context, _ *pb.Empty) (*pb.Payload, error) {
+	release := func(c config.Config) config.Config {
+		c.KillSwitch = false
+		c.KillSwitchPersistent = false
+		return c
+	}
+	if payload := r.policyPayload(release, config.SettingKillSwitch); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	released := false
+	if r.persistentKillSwitch != nil && r.persistentKillSwitch.IsEnabled() {
+		if err := r.persistentKillSwitch.Release(); err != nil {
+			log.Println(internal.ErrorPrefix, "releasing persistent killswitch:", err)
+			return &pb.Payload{Type: internal.CodeKillSwitchError}, nil
+		}
+		released = true
+	}
+
+	if cfg.KillSwitch {
+		if err := r.netw.UnsetKillSwitch(); err != nil {
+			log.Println(internal.ErrorPrefix, "disabling killswitch:", err)
+			return &pb.Payload{Type: internal.CodeKillSwitchError}, nil
+		}
+		released = true
+	}
+
+	if !released && !cfg.KillSwitchPersistent {
+		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, release); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	if cfg.KillSwitch {
+		r.events.Settings.Killswitch.Publish(false)
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// setPersistentKillSwitch enables persistent rules allowing the current VPN
+// server or releases them.
+func (r *RPC) setPersistentKillSwitch(enabled bool) error {
+	if r.persistentKillSwitch == nil {
+		return nil
+	}
+	if !enabled {
+		if !r.persistentKillSwitch.IsEnabled() {
+			return nil
+		}
+		return r.persistentKillSwitch.Release()
+	}
+
+	var endpoints []netip.Addr
+	if r.netw.IsVPNActive() && r.lastServer.Hostname != "" {
+		endpoints = r.lastServer.IPs()
+	}
+	return r.persistentKillSwitch.Enable(endpoints)
+}
@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPersistentKillSwitch struct {
+	enabled   bool
+	endpoints []netip.Addr
+}
+
+func (m *mockPersistentKillSwitch) Enable(endpoints []netip.Addr) error {
+	m.enabled = true
+	m.endpoints = endpoints
+	return nil
+}
+
+func (m *mockPersistentKillSwitch) Install() error  { return nil }
+func (m *mockPersistentKillSwitch) Release() error  { m.enabled = false; return nil }
+func (m *mockPersistentKillSwitch) IsEnabled() bool { return m.enabled }
+
+func TestRPC_SetKillSwitchPersistent(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	cm.c.Firewall = true
+	killSwitch := &mockPersistentKillSwitch{}
+	r := RPC{
+		cm:                   cm,
+		netw:                 workingNetworker{},
+		events:               &Events{Settings: &SettingsEvents{Killswitch: &subs.Subject[bool]{}}},
+		persistentKillSwitch: killSwitch,
+	}
+	ctx := context.Background()
+
+	payload, err := r.SetKillSwitch(ctx, &pb.SetKillSwitchRequest{KillSwitch: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.False(t, killSwitch.enabled)
+
+	payload, err = r.SetKillSwitch(ctx, &pb.SetKillSwitchRequest{KillSwitch: true, Persistent: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.True(t, killSwitch.enabled)
+	assert.True(t, cm.c.KillSwitch)
+	assert.True(t, cm.c.KillSwitchPersistent)
+
+	payload, err = r.SetKillSwitch(ctx, &pb.SetKillSwitchRequest{KillSwitch: true, Persistent: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNothingToDo, payload.Type)
+
+	// persistent mode cannot stay without the kill switch
+	payload, err = r.SetKillSwitch(ctx, &pb.SetKillSwitchRequest{KillSwitch: false, Persistent: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.False(t, killSwitch.enabled)
+	assert.False(t, cm.c.KillSwitch)
+	assert.False(t, cm.c.KillSwitchPersistent)
+}
+
+func TestRPC_ReleaseKillSwitch(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	cm.c.Firewall = true
+	cm.c.KillSwitch = true
+	cm.c.KillSwitchPersistent = true
+	killSwitch := &mockPersistentKillSwitch{enabled: true}
+	r := RPC{
+		cm:                   cm,
+		netw:                 workingNetworker{},
+		events:               &Events{Settings: &SettingsEvents{Killswitch: &subs.Subject[bool]{}}},
+		persistentKillSwitch: killSwitch,
+	}
+
+	payload, err := r.ReleaseKillSwitch(context.Background(), &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.False(t, killSwitch.enabled)
+	assert.False(t, cm.c.KillSwitch)
+	assert.False(t, cm.c.KillSwitchPersistent)
+
+	payload, err = r.ReleaseKillSwitch(context.Background(), &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNothingToDo, payload.Type)
+}
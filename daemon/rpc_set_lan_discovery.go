@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"net/netip"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/netstate"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SetLanDiscovery controls whether local networks are reachable while
+// connected without whitelisting them manually
+func (r *RPC) SetLanDiscovery(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.LanDiscovery = in.GetEnabled()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingLanDiscovery); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	if cfg.LanDiscovery == in.GetEnabled() {
+		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
+	}
+
+	if in.GetEnabled() {
+		if err := r.applyLanDiscovery(); err != nil {
+			log.Println(internal.ErrorPrefix, "enabling lan discovery:", err)
+			return &pb.Payload{Type: internal.CodeFailure}, nil
+		}
+	} else {
+		if err := r.netw.UnsetLanDiscovery(); err != nil {
+			log.Println(internal.ErrorPrefix, "disabling lan discovery:", err)
+			return &pb.Payload{Type: internal.CodeFailure}, nil
+		}
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// StartLanDiscovery allows local networks on daemon startup
+func (r *RPC) StartLanDiscovery() {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return
+	}
+	if !cfg.LanDiscovery {
+		return
+	}
+	if err := r.applyLanDiscovery(); err != nil {
+		log.Println(internal.ErrorPrefix, "starting lan discovery:", err)
+	}
+}
+
+// applyLanDiscovery allows the subnets of the current network. Multicast
+// discovery is allowed even if the subnets cannot be determined.
+func (r *RPC) applyLanDiscovery() error {
+	var subnets []netip.Prefix
+	if r.localSubnets != nil {
+		var err error
+		if subnets, err = r.localSubnets(); err != nil {
+			log.Println(internal.WarningPrefix, "listing local subnets:", err)
+		}
+	}
+	return r.netw.SetLanDiscovery(subnets)
+}
+
+// LanDiscoveryReconnector updates the allowed local subnets after next has
+// refreshed the connectivity on network change.
+func (r *RPC) LanDiscoveryReconnector(next netstate.Reconnector) netstate.Reconnector {
+	return lanDiscoveryReconnector{next: next, rpc: r}
+}
+
+type lanDiscoveryReconnector struct {
+	next netstate.Reconnector
+	rpc  *RPC
+}
+
+func (l lanDiscoveryReconnector) Reconnect(stateIsUp bool) {
+	l.next.Reconnect(stateIsUp)
+
+	var cfg config.Config
+	if err := l.rpc.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return
+	}
+	if !cfg.LanDiscovery {
+		return
+	}
+	if err := l.rpc.applyLanDiscovery(); err != nil {
+		log.Println(internal.ErrorPrefix, "updating lan discovery:", err)
+	}
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lanDiscoveryNetworker struct {
+	workingNetworker
+	subnets []netip.Prefix
+	enabled bool
+}
+
+func (n *lanDiscoveryNetworker) SetLanDiscovery(subnets []netip.Prefix) error {
+	n.subnets = subnets
+	n.enabled = true
+	return nil
+}
+
+func (n *lanDiscoveryNetworker) UnsetLanDiscovery() error {
+	n.subnets = nil
+	n.enabled = false
+	return nil
+}
+
+type countingReconnector struct{ count int }
+
+func (c *countingReconnector) Reconnect(bool) { c.count++ }
+
+func TestRPC_SetLanDiscovery(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	netw := &lanDiscoveryNetworker{}
+	subnets := []netip.Prefix{netip.MustParsePrefix("192.168.1.0/24")}
+	r := RPC{
+		cm:           cm,
+		netw:         netw,
+		localSubnets: func() ([]netip.Prefix, error) { return subnets, nil },
+	}
+	ctx := context.Background()
+
+	payload, err := r.SetLanDiscovery(ctx, &pb.SetGenericRequest{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.True(t, cm.c.LanDiscovery)
+	assert.True(t, netw.enabled)
+	assert.Equal(t, subnets, netw.subnets)
+
+	payload, err = r.SetLanDiscovery(ctx, &pb.SetGenericRequest{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNothingToDo, payload.Type)
+
+	// allowed subnets follow the network changes
+	next := &countingReconnector{}
+	subnets = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	r.LanDiscoveryReconnector(next).Reconnect(true)
+	assert.Equal(t, 1, next.count)
+	assert.Equal(t, subnets, netw.subnets)
+
+	payload, err = r.SetLanDiscovery(ctx, &pb.SetGenericRequest{Enabled: false})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.False(t, cm.c.LanDiscovery)
+	assert.False(t, netw.enabled)
+
+	r.LanDiscoveryReconnector(next).Reconnect(true)
+	assert.Equal(t, 2, next.count)
+	assert.False(t, netw.enabled, "disabled lan discovery must not be applied")
+}
+
+func TestRPC_SetLanDiscoveryPolicy(t *testing.T) {
+	category.Set(t, category.File)
+
+	if os.Getuid() != 0 {
+		t.Skip("policy file has to be owned by root")
+	}
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("enforce:\n  lan_discovery: false\n"), 0644))
+	cm := newMockConfigManager()
+	netw := &lanDiscoveryNetworker{}
+	r := RPC{cm: cm, netw: netw, policyPath: path}
+
+	payload, err := r.SetLanDiscovery(context.Background(), &pb.SetGenericRequest{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodePolicyEnforced, payload.Type)
+	assert.False(t, cm.c.LanDiscovery)
+	assert.False(t, netw.enabled)
+}
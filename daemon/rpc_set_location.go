@@ -0,0 +1,130 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SetLocation overrides the location detected by the insights API. It is
+// used to pick the nearest servers and to calculate penalties.
+func (r *RPC) SetLocation(ctx context.Context, in *pb.SetLocationRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	var location *config.Location
+	switch in.GetLocation().(type) {
+	case *pb.SetLocationRequest_CountryCode:
+		var ok bool
+		location, ok = countryLocation(r.dm.GetCountryData().Countries, in.GetCountryCode())
+		if !ok {
+			return &pb.Payload{Type: internal.CodeTagNonexisting}, nil
+		}
+	case *pb.SetLocationRequest_Coordinates:
+		coordinates := in.GetCoordinates()
+		if !isValidCoordinates(coordinates.GetLatitude(), coordinates.GetLongitude()) {
+			return &pb.Payload{Type: internal.CodeFormatError}, nil
+		}
+		location = coordinatesLocation(
+			r.dm.GetCountryData().Countries,
+			coordinates.GetLatitude(),
+			coordinates.GetLongitude(),
+		)
+	}
+
+	if isSameLocation(cfg.Location, location) {
+		return &pb.Payload{Type: internal.CodeNothingToDo, Data: locationData(location)}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		c.Location = location
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	// penalties depend on the distance to the servers
+	go func() {
+		if err := JobServers(r.dm, r.cm, r.serversAPI, false)(); err != nil {
+			log.Println(internal.WarningPrefix, "recalculating penalties:", err)
+		}
+	}()
+
+	return &pb.Payload{Type: internal.CodeSuccess, Data: locationData(location)}, nil
+}
+
+// userLocation returns the location override if it is set or the location
+// detected by the insights API otherwise.
+func userLocation(location *config.Location, insights core.Insights) core.Insights {
+	if location == nil {
+		return insights
+	}
+	insights.CountryCode = location.CountryCode
+	insights.Latitude = location.Latitude
+	insights.Longitude = location.Longitude
+	return insights
+}
+
+// countryLocation returns a location in the middle of the country cities.
+func countryLocation(countries core.Countries, code string) (*config.Location, bool) {
+	if strings.EqualFold(code, "uk") {
+		code = "gb"
+	}
+	for _, country := range countries {
+		if !strings.EqualFold(country.Code, code) || len(country.Cities) == 0 {
+			continue
+		}
+		location := config.Location{CountryCode: country.Code}
+		for _, city := range country.Cities {
+			location.Latitude += city.Latitude
+			location.Longitude += city.Longitude
+		}
+		location.Latitude /= float64(len(country.Cities))
+		location.Longitude /= float64(len(country.Cities))
+		return &location, true
+	}
+	return nil, false
+}
+
+// coordinatesLocation returns a location with the country of the nearest city.
+func coordinatesLocation(countries core.Countries, latitude, longitude float64) *config.Location {
+	location := config.Location{Latitude: latitude, Longitude: longitude}
+	nearest := math.Inf(1)
+	for _, country := range countries {
+		for _, city := range country.Cities {
+			dist := distance(latitude, longitude, city.Latitude, city.Longitude)
+			if dist < nearest {
+				nearest = dist
+				location.CountryCode = country.Code
+			}
+		}
+	}
+	return &location
+}
+
+func isValidCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+func isSameLocation(a, b *config.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func locationData(location *config.Location) []string {
+	if location == nil {
+		return nil
+	}
+	return []string{location.CountryCode}
+}
@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var locationTestCountries = core.Countries{
+	{
+		ID:   1,
+		Code: "LT",
+		Cities: core.Cities{
+			{Name: "Vilnius", Latitude: 54.68, Longitude: 25.28},
+			{Name: "Kaunas", Latitude: 54.9, Longitude: 23.9},
+		},
+	},
+	{
+		ID:     2,
+		Code:   "GB",
+		Cities: core.Cities{{Name: "London", Latitude: 51.51, Longitude: -0.13}},
+	},
+}
+
+func TestUserLocation(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	insights := core.Insights{CountryCode: "DE", Latitude: 52.52, Longitude: 13.4}
+	assert.Equal(t, insights, userLocation(nil, insights))
+
+	location := &config.Location{CountryCode: "LT", Latitude: 54.68, Longitude: 25.28}
+	assert.Equal(t,
+		core.Insights{CountryCode: "LT", Latitude: 54.68, Longitude: 25.28},
+		userLocation(location, insights),
+	)
+}
+
+func TestCountryLocation(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name     string
+		code     string
+		expected *config.Location
+	}{
+		{
+			name:     "cities are averaged",
+			code:     "lt",
+			expected: &config.Location{CountryCode: "LT", Latitude: 54.79, Longitude: 24.59},
+		},
+		{
+			name:     "uk alias",
+			code:     "uk",
+			expected: &config.Location{CountryCode: "GB", Latitude: 51.51, Longitude: -0.13},
+		},
+		{name: "unknown country", code: "zz"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location, ok := countryLocation(locationTestCountries, test.code)
+			assert.Equal(t, test.expected != nil, ok)
+			if test.expected == nil {
+				return
+			}
+			assert.Equal(t, test.expected.CountryCode, location.CountryCode)
+			assert.InDelta(t, test.expected.Latitude, location.Latitude, 0.001)
+			assert.InDelta(t, test.expected.Longitude, location.Longitude, 0.001)
+		})
+	}
+}
+
+func TestCoordinatesLocation(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	location := coordinatesLocation(locationTestCountries, 53.0, -2.0)
+	assert.Equal(t, &config.Location{CountryCode: "GB", Latitude: 53.0, Longitude: -2.0}, location)
+
+	assert.True(t, isValidCoordinates(-90, 180))
+	assert.False(t, isValidCoordinates(91, 0))
+	assert.False(t, isValidCoordinates(0, -181))
+}
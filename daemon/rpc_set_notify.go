@@ -29,7 +29,7 @@ func (r *RPC) SetNotify(ctx context.Context, in *pb.SetNotifyRequest) (*pb.Paylo
 	}
 
 	if in.GetNotify() {
-		if err := r.cm.SaveWith(func(c config.Config) config.Config {
+		if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 			c.UsersData.Notify[in.GetUid()] = true
 			return c
 		}); err != nil {
@@ -39,7 +39,7 @@ func (r *RPC) SetNotify(ctx context.Context, in *pb.SetNotifyRequest) (*pb.Paylo
 			}, nil
 		}
 	} else {
-		if err := r.cm.SaveWith(func(c config.Config) config.Config {
+		if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
 			delete(c.UsersData.Notify, in.GetUid())
 			return c
 		}); err != nil {
@@ -12,13 +12,21 @@ import (
 )
 
 func (r *RPC) SetObfuscate(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.AutoConnectData.Obfuscate = in.GetEnabled()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingObfuscate); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 	}
 
 	if cfg.AutoConnect {
-		switch core.IsServerObfuscated(r.dm.GetServersData().Servers, cfg.AutoConnectData.ServerTag) {
+		switch core.IsServerObfuscated(r.dm.GetServersSummary(), cfg.AutoConnectData.ServerTag) {
 		case core.ServerNotObfuscated:
 			if in.GetEnabled() {
 				return &pb.Payload{
@@ -37,10 +45,7 @@ func (r *RPC) SetObfuscate(ctx context.Context, in *pb.SetGenericRequest) (*pb.P
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.AutoConnectData.Obfuscate = in.GetEnabled()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
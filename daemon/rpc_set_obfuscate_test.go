@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"net/netip"
 	"strconv"
 	"testing"
 
@@ -61,19 +62,26 @@ func (mockObfuscateNetworker) IsVPNActive() bool                 { return true }
 func (mockObfuscateNetworker) ConnectionStatus() (networker.ConnectionStatus, error) {
 	return networker.ConnectionStatus{}, nil
 }
-func (mockObfuscateNetworker) EnableFirewall() error                { return nil }
-func (mockObfuscateNetworker) DisableFirewall() error               { return nil }
-func (mockObfuscateNetworker) EnableRouting()                       {}
-func (mockObfuscateNetworker) DisableRouting()                      {}
-func (mockObfuscateNetworker) SetWhitelist(config.Whitelist) error  { return nil }
-func (mockObfuscateNetworker) UnsetWhitelist() error                { return nil }
-func (mockObfuscateNetworker) IsNetworkSet() bool                   { return false }
-func (mockObfuscateNetworker) SetKillSwitch(config.Whitelist) error { return nil }
-func (mockObfuscateNetworker) UnsetKillSwitch() error               { return nil }
-func (mockObfuscateNetworker) PermitIPv6() error                    { return nil }
-func (mockObfuscateNetworker) DenyIPv6() error                      { return nil }
-func (mockObfuscateNetworker) SetVPN(vpn.VPN)                       {}
-func (mockObfuscateNetworker) LastServerName() string               { return "" }
+func (mockObfuscateNetworker) EnableFirewall() error                     { return nil }
+func (mockObfuscateNetworker) DisableFirewall() error                    { return nil }
+func (mockObfuscateNetworker) EnableRouting()                            {}
+func (mockObfuscateNetworker) DisableRouting()                           {}
+func (mockObfuscateNetworker) SetWhitelist(config.Whitelist) error       { return nil }
+func (mockObfuscateNetworker) UnsetWhitelist() error                     { return nil }
+func (mockObfuscateNetworker) IsNetworkSet() bool                        { return false }
+func (mockObfuscateNetworker) SetKillSwitch(config.Whitelist) error      { return nil }
+func (mockObfuscateNetworker) UnsetKillSwitch() error                    { return nil }
+func (mockObfuscateNetworker) PermitIPv6() error                         { return nil }
+func (mockObfuscateNetworker) DenyIPv6() error                           { return nil }
+func (mockObfuscateNetworker) SetVPN(vpn.VPN)                            {}
+func (mockObfuscateNetworker) SetIsolation(bool)                         {}
+func (mockObfuscateNetworker) SetLanDiscovery([]netip.Prefix) error      { return nil }
+func (mockObfuscateNetworker) UnsetLanDiscovery() error                  { return nil }
+func (mockObfuscateNetworker) SetReconnectPolicy(config.ReconnectPolicy) {}
+func (mockObfuscateNetworker) LastServerName() string                    { return "" }
+func (mockObfuscateNetworker) BlockCaptivePortal() error                 { return nil }
+
+func (mockObfuscateNetworker) AllowCaptivePortal([]netip.Addr, []netip.Addr) error { return nil }
 
 func TestSetObfuscate(t *testing.T) {
 	mockConfigManager := mockObfuscateConfigManager{c: config.Config{AutoConnect: false}}
@@ -0,0 +1,110 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SetPinnedServer binds automatic connections to a single server given
+// by its ID or hostname. Empty server removes the pin.
+func (r *RPC) SetPinnedServer(ctx context.Context, in *pb.SetPinnedServerRequest) (*pb.Payload, error) {
+	if !r.ac.IsLoggedIn() {
+		return nil, internal.ErrNotLoggedIn
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	if in.GetServer() == "" {
+		if !cfg.AutoConnectData.IsServerPinned() {
+			return &pb.Payload{Type: internal.CodeNothingToDo}, nil
+		}
+		if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+			c.AutoConnectData.PinnedServerID = 0
+			c.AutoConnectData.PinnedServer = ""
+			return c
+		}); err != nil {
+			log.Println(internal.ErrorPrefix, err)
+			return &pb.Payload{Type: internal.CodeConfigError}, nil
+		}
+		return &pb.Payload{Type: internal.CodeSuccess}, nil
+	}
+
+	server, ok := findServer(r.serversAPI, r.dm.GetServersSummary(), in.GetServer())
+	if !ok {
+		return &pb.Payload{Type: internal.CodeTagNonexisting}, nil
+	}
+
+	if cfg.AutoConnectData.PinnedServerID == server.ID {
+		return &pb.Payload{
+			Type: internal.CodeNothingToDo,
+			Data: []string{server.Hostname},
+		}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		c.AutoConnectData.PinnedServerID = server.ID
+		c.AutoConnectData.PinnedServer = server.Hostname
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	return &pb.Payload{
+		Type: internal.CodeSuccess,
+		Data: []string{server.Hostname},
+	}, nil
+}
+
+// findServer by its ID, full hostname or hostname prefix (e.g. lt16).
+// Local server list is checked first, API is queried only for IDs.
+func findServer(api core.ServersAPI, servers core.Servers, tag string) (core.Server, bool) {
+	id, err := strconv.ParseInt(tag, 10, 64)
+	isID := err == nil
+	for _, server := range servers {
+		if isID && server.ID == id {
+			return server, true
+		}
+		if strings.EqualFold(server.Hostname, tag) ||
+			strings.EqualFold(strings.Split(server.Hostname, ".")[0], tag) {
+			return server, true
+		}
+	}
+
+	if !isID || api == nil {
+		return core.Server{}, false
+	}
+	server, err := api.Server(id)
+	if err != nil || server == nil {
+		return core.Server{}, false
+	}
+	return *server, true
+}
+
+// pinnedServerTag returns a server tag for the pinned server, which can be
+// passed to PickServer, or an empty string if no server is pinned.
+func pinnedServerTag(data config.AutoConnectData) string {
+	if !data.IsServerPinned() {
+		return ""
+	}
+	return strings.Split(data.PinnedServer, ".")[0]
+}
+
+// autoConnectRequest targets the pinned server if there is one, or the
+// auto-connect server otherwise. Manual connections are not pinned.
+func autoConnectRequest(data config.AutoConnectData) *pb.ConnectRequest {
+	if tag := pinnedServerTag(data); tag != "" {
+		return &pb.ConnectRequest{ServerTag: tag}
+	}
+	return &pb.ConnectRequest{ServerTag: data.ServerTag}
+}
@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindServer(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	servers := core.Servers{
+		{ID: 1, Hostname: "lt16.nordvpn.com"},
+		{ID: 2, Hostname: "de512.nordvpn.com"},
+	}
+
+	tests := []struct {
+		name string
+		tag  string
+		id   int64
+		ok   bool
+	}{
+		{name: "by id", tag: "2", id: 2, ok: true},
+		{name: "by hostname", tag: "lt16.nordvpn.com", id: 1, ok: true},
+		{name: "by hostname prefix", tag: "de512", id: 2, ok: true},
+		{name: "case insensitive", tag: "LT16", id: 1, ok: true},
+		{name: "unknown hostname", tag: "us1", ok: false},
+		{name: "unknown id without api", tag: "3", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, ok := findServer(nil, servers, test.tag)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.id, server.ID)
+		})
+	}
+}
+
+func TestPinnedServerTag(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	assert.Equal(t, "", pinnedServerTag(config.AutoConnectData{}))
+	assert.Equal(t, "lt16", pinnedServerTag(config.AutoConnectData{
+		PinnedServerID: 1,
+		PinnedServer:   "lt16.nordvpn.com",
+	}))
+}
+
+func TestAutoConnectRequest(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	pinned := config.AutoConnectData{
+		ServerTag:      "germany",
+		PinnedServerID: 1,
+		PinnedServer:   "lt16.nordvpn.com",
+	}
+
+	tests := []struct {
+		name     string
+		data     config.AutoConnectData
+		expected string
+	}{
+		{name: "pinned", data: pinned, expected: "lt16"},
+		{name: "not pinned", data: config.AutoConnectData{ServerTag: "germany"}, expected: "germany"},
+		{name: "not set up", data: config.AutoConnectData{}, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, &pb.ConnectRequest{ServerTag: test.expected}, autoConnectRequest(test.data))
+		})
+	}
+}
@@ -11,16 +11,21 @@ import (
 )
 
 func (r *RPC) SetProtocol(ctx context.Context, in *pb.SetProtocolRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.AutoConnectData.Protocol = in.GetProtocol()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingProtocol); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 	}
 
 	payload := &pb.Payload{}
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.AutoConnectData.Protocol = in.GetProtocol()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
@@ -0,0 +1,122 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/netstate"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SetReconnectPolicy decides how VPN connection is refreshed after the
+// network changes or the host resumes
+func (r *RPC) SetReconnectPolicy(ctx context.Context, in *pb.SetReconnectPolicyRequest) (*pb.Payload, error) {
+	policy, err := config.ParseReconnectPolicy(in.GetPolicy())
+	if err != nil {
+		return &pb.Payload{Type: internal.CodeFormatError}, nil
+	}
+	update := func(c config.Config) config.Config {
+		c.ReconnectPolicy = policy
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingReconnectPolicy); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+
+	if cfg.ReconnectPolicy.OrDefault() == policy {
+		return &pb.Payload{Type: internal.CodeNothingToDo, Data: []string{string(policy)}}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	r.netw.SetReconnectPolicy(policy)
+
+	return &pb.Payload{Type: internal.CodeSuccess, Data: []string{string(policy)}}, nil
+}
+
+// ReconnectPolicyReconnector picks a new server instead of refreshing the
+// connectivity with next, if the reconnect policy requires it and the country
+// of the new network is different. Insights API must not go through the tunnel.
+func (r *RPC) ReconnectPolicyReconnector(next netstate.Reconnector, api core.InsightsAPI) netstate.Reconnector {
+	return reconnectPolicyReconnector{next: next, rpc: r, api: api}
+}
+
+type reconnectPolicyReconnector struct {
+	next netstate.Reconnector
+	rpc  *RPC
+	api  core.InsightsAPI
+}
+
+func (p reconnectPolicyReconnector) Reconnect(stateIsUp bool) {
+	if stateIsUp {
+		if cfg, ok := p.rpc.reselectRequired(p.api); ok {
+			p.rpc.reselectServer(cfg)
+			return
+		}
+	}
+	p.next.Reconnect(stateIsUp)
+}
+
+// reselectRequired reports whether the server has to be picked for the
+// location of the new network
+func (r *RPC) reselectRequired(api core.InsightsAPI) (config.Config, bool) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return cfg, false
+	}
+	// location override makes servers independent of the network
+	if cfg.ReconnectPolicy != config.ReconnectPolicyReselect || cfg.Location != nil || !r.netw.IsVPNActive() {
+		return cfg, false
+	}
+	return cfg, r.networkCountryChanged(api)
+}
+
+// reselectServer connects to the same target again, so that the tunnel is
+// re-created with the server picked for the location of the new network
+func (r *RPC) reselectServer(cfg config.Config) {
+	log.Println(internal.InfoPrefix, "reselecting server for the new network")
+	r.lastTargetMu.Lock()
+	target := r.lastTarget
+	r.lastTargetMu.Unlock()
+	if target == nil {
+		target = autoConnectRequest(cfg.AutoConnectData)
+	}
+	server := autoconnectServer{}
+	if err := r.Connect(target, &server); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
+	if server.err != nil {
+		log.Println(internal.ErrorPrefix, server.err)
+	}
+}
+
+// networkCountryChanged updates the insights and reports whether the country
+// is different from the previously detected one
+func (r *RPC) networkCountryChanged(api core.InsightsAPI) bool {
+	insights, err := api.Insights()
+	if err != nil || insights == nil {
+		log.Println(internal.WarningPrefix, "detecting location of the new network:", err)
+		return false
+	}
+	previous := r.dm.GetInsightsData().Insights
+	if err := r.dm.SetInsightsData(*insights); err != nil {
+		log.Println(internal.WarningPrefix, err)
+	}
+	if previous.CountryCode == "" || strings.EqualFold(previous.CountryCode, insights.CountryCode) {
+		return false
+	}
+	log.Println(internal.InfoPrefix, "network country changed from", previous.CountryCode, "to", insights.CountryCode)
+	return true
+}
@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type policyNetworker struct {
+	workingNetworker
+	policy config.ReconnectPolicy
+}
+
+func (n *policyNetworker) SetReconnectPolicy(policy config.ReconnectPolicy) {
+	n.policy = policy
+}
+
+func TestRPC_SetReconnectPolicy(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	netw := &policyNetworker{}
+	r := RPC{cm: cm, netw: netw}
+	ctx := context.Background()
+
+	payload, err := r.SetReconnectPolicy(ctx, &pb.SetReconnectPolicyRequest{Policy: "full"})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNothingToDo, payload.Type)
+
+	payload, err = r.SetReconnectPolicy(ctx, &pb.SetReconnectPolicyRequest{Policy: "Fast"})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.Equal(t, []string{"fast"}, payload.Data)
+	assert.Equal(t, config.ReconnectPolicyFast, cm.c.ReconnectPolicy)
+	assert.Equal(t, config.ReconnectPolicyFast, netw.policy)
+
+	payload, err = r.SetReconnectPolicy(ctx, &pb.SetReconnectPolicyRequest{Policy: "slow"})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeFormatError, payload.Type)
+	assert.Equal(t, config.ReconnectPolicyFast, cm.c.ReconnectPolicy)
+}
+
+type countryInsightsAPI struct {
+	country string
+	err     error
+}
+
+func (c countryInsightsAPI) Insights() (*core.Insights, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &core.Insights{CountryCode: c.country}, nil
+}
+
+func TestRPC_networkCountryChanged(t *testing.T) {
+	category.Set(t, category.File)
+	defer testsCleanup()
+
+	r := RPC{dm: testNewDataManager()}
+	// nothing to compare with
+	assert.False(t, r.networkCountryChanged(countryInsightsAPI{country: "LT"}))
+	assert.False(t, r.networkCountryChanged(countryInsightsAPI{country: "lt"}))
+	assert.True(t, r.networkCountryChanged(countryInsightsAPI{country: "DE"}))
+	assert.Equal(t, "DE", r.dm.GetInsightsData().Insights.CountryCode)
+	// location is kept if it cannot be detected
+	assert.False(t, r.networkCountryChanged(countryInsightsAPI{err: errOnPurpose}))
+	assert.Equal(t, "DE", r.dm.GetInsightsData().Insights.CountryCode)
+}
+
+type loggedOutChecker struct{}
+
+func (loggedOutChecker) IsLoggedIn() bool { return false }
+
+func TestReconnectPolicyReconnector(t *testing.T) {
+	category.Set(t, category.File)
+	defer testsCleanup()
+
+	cm := newMockConfigManager()
+	next := &countingReconnector{}
+	r := &RPC{cm: cm, dm: testNewDataManager(), ac: loggedOutChecker{}, netw: workingNetworker{}}
+	require.NoError(t, r.dm.SetInsightsData(core.Insights{CountryCode: "LT"}))
+	reconnector := r.ReconnectPolicyReconnector(next, countryInsightsAPI{country: "DE"})
+
+	// country is not checked for the other policies
+	reconnector.Reconnect(true)
+	assert.Equal(t, 1, next.count)
+	assert.Equal(t, "LT", r.dm.GetInsightsData().Insights.CountryCode)
+
+	// connecting again re-creates the tunnel, so next is not called
+	cm.c.ReconnectPolicy = config.ReconnectPolicyReselect
+	reconnector.Reconnect(true)
+	assert.Equal(t, 1, next.count)
+	assert.Equal(t, "DE", r.dm.GetInsightsData().Insights.CountryCode)
+
+	// same country
+	reconnector.Reconnect(true)
+	assert.Equal(t, 2, next.count)
+
+	reconnector.Reconnect(false)
+	assert.Equal(t, 3, next.count)
+}
@@ -16,6 +16,14 @@ import (
 // - Connect
 // - Meshnet
 func (r *RPC) SetRouting(ctx context.Context, in *pb.SetGenericRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.Routing.Set(in.GetEnabled())
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingRouting); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -29,10 +37,7 @@ func (r *RPC) SetRouting(ctx context.Context, in *pb.SetGenericRequest) (*pb.Pay
 		return &pb.Payload{Type: internal.CodeDependencyError}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.Routing.Set(in.GetEnabled())
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{Type: internal.CodeConfigError}, nil
 	}
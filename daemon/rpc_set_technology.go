@@ -11,6 +11,14 @@ import (
 )
 
 func (r *RPC) SetTechnology(ctx context.Context, in *pb.SetTechnologyRequest) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.Technology = in.GetTechnology()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingTechnology); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -23,6 +31,14 @@ func (r *RPC) SetTechnology(ctx context.Context, in *pb.SetTechnologyRequest) (*
 		}, nil
 	}
 
+	// only NordLynx interface keeps working after being isolated
+	if cfg.Isolation && in.GetTechnology() != config.Technology_NORDLYNX {
+		return &pb.Payload{
+			Type: internal.CodeDependencyError,
+			Data: []string{config.SettingIsolation},
+		}, nil
+	}
+
 	v, err := r.factory(in.GetTechnology())
 	if err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -40,10 +56,7 @@ func (r *RPC) SetTechnology(ctx context.Context, in *pb.SetTechnologyRequest) (*
 	// internal.CodeSuccessWithoutAC was overriden with generic internal.CodeSuccess
 	payload.Type = internal.CodeSuccess
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.Technology = in.GetTechnology()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
@@ -52,7 +65,7 @@ func (r *RPC) SetTechnology(ctx context.Context, in *pb.SetTechnologyRequest) (*
 
 	r.events.Settings.Technology.Publish(in.GetTechnology())
 
-	SetAppData(r.dm, in.GetTechnology(), r.dm.GetServersData().Servers)
+	SetAppData(r.dm, in.GetTechnology(), r.dm.GetServersSummary())
 
 	payload.Data = []string{strconv.FormatBool(r.netw.IsVPNActive()), in.GetTechnology().String()}
 	return payload, nil
@@ -13,6 +13,15 @@ func (r *RPC) SetThreatProtectionLite(
 	ctx context.Context,
 	in *pb.SetThreatProtectionLiteRequest,
 ) (*pb.Payload, error) {
+	update := func(c config.Config) config.Config {
+		c.AutoConnectData.ThreatProtectionLite = in.GetThreatProtectionLite()
+		c.AutoConnectData.DNS = in.GetDns()
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingThreatProtectionLite, config.SettingDNS); payload != nil {
+		return payload, nil
+	}
+
 	var cfg config.Config
 	if err := r.cm.Load(&cfg); err != nil {
 		log.Println(internal.ErrorPrefix, err)
@@ -22,7 +31,9 @@ func (r *RPC) SetThreatProtectionLite(
 	if in.GetDns() != nil {
 		nameservers = in.GetDns()
 	} else {
-		nameservers = r.nameservers.Get(in.GetThreatProtectionLite(), cfg.IPv6)
+		// last server is not used when disconnected
+		ipv6 := r.netw.IsVPNActive() && dualStack(cfg, r.lastServer)
+		nameservers = r.nameservers.Get(in.GetThreatProtectionLite(), ipv6)
 	}
 
 	if err := r.netw.SetDNS(nameservers); err != nil {
@@ -32,11 +43,7 @@ func (r *RPC) SetThreatProtectionLite(
 		}, nil
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.AutoConnectData.ThreatProtectionLite = in.GetThreatProtectionLite()
-		c.AutoConnectData.DNS = in.GetDns()
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
@@ -2,7 +2,10 @@ package daemon
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
@@ -11,17 +14,32 @@ import (
 )
 
 func (r *RPC) SetWhitelist(ctx context.Context, in *pb.SetWhitelistRequest) (*pb.Payload, error) {
-	var cfg config.Config
-	err := r.cm.Load(&cfg)
+	whitelist, err := whitelistFromProtobuf(in.GetWhitelist())
 	if err != nil {
 		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{
+			Type: internal.CodeFormatError,
+		}, nil
+	}
+	// clients send back their copy of the whitelist, which may still
+	// contain entries already removed by the expiry job
+	whitelist, _ = whitelist.RemoveExpired(time.Now())
+
+	update := func(c config.Config) config.Config {
+		c.AutoConnectData.Whitelist = whitelist
+		return c
+	}
+	if payload := r.policyPayload(update, config.SettingWhitelist); payload != nil {
+		return payload, nil
 	}
 
-	whitelist := config.NewWhitelist(
-		in.GetWhitelist().GetPorts().GetUdp(),
-		in.GetWhitelist().GetPorts().GetTcp(),
-		in.GetWhitelist().GetSubnets(),
-	)
+	r.whitelistMu.Lock()
+	defer r.whitelistMu.Unlock()
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+	}
 
 	if r.netw.IsVPNActive() || cfg.KillSwitch {
 		if err := r.netw.UnsetWhitelist(); err != nil {
@@ -38,22 +56,77 @@ func (r *RPC) SetWhitelist(ctx context.Context, in *pb.SetWhitelistRequest) (*pb
 		}
 	}
 
-	if err := r.cm.SaveWith(func(c config.Config) config.Config {
-		c.AutoConnectData.Whitelist = whitelist
-		return c
-	}); err != nil {
+	if err := config.SaveWithContext(ctx, r.cm, update); err != nil {
 		log.Println(internal.ErrorPrefix, err)
 		return &pb.Payload{
 			Type: internal.CodeConfigError,
 		}, nil
 	}
 	r.events.Settings.Whitelist.Publish(events.DataWhitelist{
-		TCPPorts: len(in.Whitelist.Ports.Tcp),
-		UDPPorts: len(in.Whitelist.Ports.Udp),
-		Subnets:  len(in.Whitelist.Subnets),
+		TCPPorts: whitelist.PortCount(config.WhitelistProtocolTCP),
+		UDPPorts: whitelist.PortCount(config.WhitelistProtocolUDP),
+		Subnets:  len(whitelist.Subnets()),
 	})
 
 	return &pb.Payload{
 		Type: internal.CodeSuccess,
 	}, nil
 }
+
+// whitelistFromProtobuf validates and normalizes whitelist entries received from a client.
+func whitelistFromProtobuf(in *pb.Whitelist) (config.Whitelist, error) {
+	// fields of the legacy format are reserved, so a request of an older
+	// client would otherwise be decoded as an empty whitelist
+	if in != nil && len(in.GetEntries()) == 0 && len(in.ProtoReflect().GetUnknown()) > 0 {
+		return config.Whitelist{}, errors.New("whitelist in the legacy format is not supported")
+	}
+
+	var whitelist config.Whitelist
+	for _, item := range in.GetEntries() {
+		entry := config.WhitelistEntry{
+			Subnet:    item.GetSubnet(),
+			Protocol:  item.GetProtocol(),
+			Direction: item.GetDirection(),
+			Interface: item.GetInterface(),
+			Comment:   item.GetComment(),
+		}
+		if item.GetPorts() != "" {
+			ports, err := config.ParsePortRange(item.GetPorts())
+			if err != nil {
+				return config.Whitelist{}, err
+			}
+			entry.Ports = ports
+		}
+		if item.GetExpiry() != 0 {
+			expiry := time.Unix(item.GetExpiry(), 0)
+			entry.Expiry = &expiry
+		}
+		entry = entry.Normalized()
+		if err := entry.Validate(); err != nil {
+			return config.Whitelist{}, fmt.Errorf("whitelist entry %s: %w", entry, err)
+		}
+		whitelist, _ = whitelist.Add(entry)
+	}
+	return whitelist, nil
+}
+
+func whitelistToProtobuf(whitelist config.Whitelist) *pb.Whitelist {
+	entries := make([]*pb.WhitelistEntry, 0, len(whitelist.Entries))
+	for _, entry := range whitelist.Entries {
+		item := &pb.WhitelistEntry{
+			Subnet:    entry.Subnet,
+			Protocol:  entry.Protocol,
+			Direction: entry.Direction,
+			Interface: entry.Interface,
+			Comment:   entry.Comment,
+		}
+		if entry.Ports != nil {
+			item.Ports = entry.Ports.String()
+		}
+		if entry.Expiry != nil {
+			item.Expiry = entry.Expiry.Unix()
+		}
+		entries = append(entries, item)
+	}
+	return &pb.Whitelist{Entries: entries}
+}
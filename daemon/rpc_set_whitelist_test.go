@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestWhitelistFromProtobuf(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	whitelist, err := whitelistFromProtobuf(&pb.Whitelist{})
+	assert.NoError(t, err)
+	assert.Empty(t, whitelist.Entries)
+
+	whitelist, err = whitelistFromProtobuf(&pb.Whitelist{Entries: []*pb.WhitelistEntry{{Ports: "22", Protocol: "tcp"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, config.NewWhitelist(nil, []int64{22}, nil), whitelist)
+
+	// ports of an older client are sent in the reserved field 1
+	legacy := &pb.Whitelist{}
+	unknown := protowire.AppendTag(nil, 1, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 22))
+	legacy.ProtoReflect().SetUnknown(unknown)
+	_, err = whitelistFromProtobuf(legacy)
+	assert.Error(t, err)
+}
+
+func TestRPC_SetWhitelistDropsExpired(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	r := RPC{
+		cm:     cm,
+		netw:   &workingNetworker{},
+		events: &Events{Settings: &SettingsEvents{Whitelist: &subs.Subject[events.DataWhitelist]{}}},
+	}
+
+	payload, err := r.SetWhitelist(context.Background(), &pb.SetWhitelistRequest{
+		Whitelist: &pb.Whitelist{Entries: []*pb.WhitelistEntry{
+			{Ports: "22", Protocol: "tcp"},
+			{Ports: "8080", Protocol: "tcp", Expiry: time.Now().Add(-time.Minute).Unix()},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	assert.Equal(t, config.NewWhitelist(nil, []int64{22}, nil), cm.c.AutoConnectData.Whitelist)
+}
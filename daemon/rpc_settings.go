@@ -16,31 +16,43 @@ func (r *RPC) Settings(ctx context.Context, in *pb.SettingsRequest) (*pb.Setting
 		log.Println(internal.ErrorPrefix, err)
 	}
 
+	policy, err := config.LoadPolicy(r.policyPath)
+	if err != nil {
+		log.Println(internal.WarningPrefix, "loading policy:", err)
+	}
+
 	return &pb.SettingsResponse{
 		Type: internal.CodeSuccess,
 		Data: &pb.Settings{
-			Technology:  cfg.Technology,
-			Firewall:    cfg.Firewall,
-			Fwmark:      cfg.FirewallMark,
-			Routing:     cfg.Routing.Get(),
-			Analytics:   cfg.Analytics.Get(),
-			KillSwitch:  cfg.KillSwitch,
-			AutoConnect: cfg.AutoConnect,
-			Ipv6:        cfg.IPv6,
-			Notify:      cfg.UsersData.Notify[in.GetUid()],
-			Meshnet:     cfg.Mesh,
+			Technology:           cfg.Technology,
+			Firewall:             cfg.Firewall,
+			Fwmark:               cfg.FirewallMark,
+			Routing:              cfg.Routing.Get(),
+			Analytics:            cfg.Analytics.Get(),
+			KillSwitch:           cfg.KillSwitch,
+			KillSwitchPersistent: cfg.KillSwitchPersistent,
+			AutoConnect:          cfg.AutoConnect,
+			Ipv6:                 cfg.IPv6,
+			Notify:               cfg.UsersData.Notify[in.GetUid()],
+			Meshnet:              cfg.Mesh,
+			PinnedServer:         cfg.AutoConnectData.PinnedServer,
+			Location:             locationToProtobuf(cfg.Location),
+			Enforced:             policy.Locked(),
+			Isolation:            cfg.Isolation,
+			LanDiscovery:         cfg.LanDiscovery,
+			ReconnectPolicy:      string(cfg.ReconnectPolicy.OrDefault()),
 		},
 	}, nil
 }
 
-func (r RPC) SettingsProtocols(ctx context.Context, _ *pb.Empty) (*pb.Payload, error) {
+func (r *RPC) SettingsProtocols(ctx context.Context, _ *pb.Empty) (*pb.Payload, error) {
 	return &pb.Payload{
 		Type: internal.CodeSuccess,
 		Data: []string{config.Protocol_UDP.String(), config.Protocol_TCP.String()},
 	}, nil
 }
 
-func (r RPC) SettingsTechnologies(ctx context.Context, _ *pb.Empty) (*pb.Payload, error) {
+func (r *RPC) SettingsTechnologies(ctx context.Context, _ *pb.Empty) (*pb.Payload, error) {
 	return &pb.Payload{
 		Type: internal.CodeSuccess,
 		Data: []string{
@@ -48,3 +60,14 @@ func (r RPC) SettingsTechnologies(ctx context.Context, _ *pb.Empty) (*pb.Payload
 		},
 	}, nil
 }
+
+func locationToProtobuf(location *config.Location) *pb.Location {
+	if location == nil {
+		return nil
+	}
+	return &pb.Location{
+		CountryCode: location.CountryCode,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+	}
+}
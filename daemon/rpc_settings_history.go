@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"context"
+	"log"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// SettingsHistory returns latest recorded config changes.
+func (r *RPC) SettingsHistory(ctx context.Context, in *pb.SettingsHistoryRequest) (*pb.SettingsHistoryResponse, error) {
+	if in.GetLimit() < 0 {
+		return &pb.SettingsHistoryResponse{Type: internal.CodeFormatError}, nil
+	}
+
+	entries, err := r.auditLog.Read(int(in.GetLimit()))
+	if err != nil {
+		log.Println(internal.ErrorPrefix, "reading audit log:", err)
+		return &pb.SettingsHistoryResponse{Type: internal.CodeFailure}, nil
+	}
+
+	history := make([]*pb.SettingsHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]*pb.SettingChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, &pb.SettingChange{Name: change.Name, Old: change.Old, New: change.New})
+		}
+		history = append(history, &pb.SettingsHistoryEntry{
+			Time:       entry.Time.Unix(),
+			Uid:        entry.UID,
+			UnknownUid: entry.UnknownUID,
+			Rpc:        entry.RPC,
+			Changes:    changes,
+		})
+	}
+	return &pb.SettingsHistoryResponse{Type: internal.CodeSuccess, Entries: history}, nil
+}
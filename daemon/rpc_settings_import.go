@@ -0,0 +1,277 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// MsgMeshnetNotImported is returned when imported settings enable or disable meshnet
+const MsgMeshnetNotImported = "meshnet: not changed, use 'nordvpn set meshnet' instead"
+
+// SettingsExport returns the user facing settings in the requested format.
+func (r *RPC) SettingsExport(ctx context.Context, in *pb.SettingsExportRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	data, err := config.MarshalSettings(config.NewSettings(cfg), in.GetFormat())
+	if err != nil {
+		return &pb.Payload{Type: internal.CodeFormatError, Data: []string{err.Error()}}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess, Data: []string{string(data)}}, nil
+}
+
+// SettingsImport validates the given settings and applies all of them at once.
+// Settings missing in the file are left untouched. Applied changes are
+// returned in the payload data.
+func (r *RPC) SettingsImport(ctx context.Context, in *pb.SettingsImportRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	imported := config.NewSettings(cfg)
+	if err := config.UnmarshalSettings(in.GetSettings(), &imported); err != nil {
+		return &pb.Payload{Type: internal.CodeFormatError, Data: []string{err.Error()}}, nil
+	}
+	return r.changeSettings(ctx, cfg, imported, in.GetDryRun(), nil), nil
+}
+
+// changeSettings validates target settings and applies the changed ones
+// through their setters. Either all of the settings are changed or none of
+// them: changes applied so far are reverted if any of the setters fails and
+// the previous settings are saved back at once. Optional update is saved
+// together with the target settings. Applied changes are returned in the
+// payload data.
+func (r *RPC) changeSettings(
+	ctx context.Context,
+	cfg config.Config,
+	target config.Settings,
+	dryRun bool,
+	update func(config.Config) config.Config,
+) *pb.Payload {
+	current := config.NewSettings(cfg)
+
+	// meshnet requires registration of the device, so it cannot be toggled
+	// as a plain setting
+	var notes []string
+	if target.Meshnet != current.Meshnet {
+		target.Meshnet = current.Meshnet
+		notes = append(notes, MsgMeshnetNotImported)
+	}
+
+	if err := target.Validate(); err != nil {
+		return &pb.Payload{Type: internal.CodeFormatError, Data: []string{err.Error()}}
+	}
+
+	changes := current.Diff(target)
+	if len(changes) == 0 {
+		return &pb.Payload{Type: internal.CodeNothingToDo, Data: notes}
+	}
+	changed := make([]string, 0, len(changes))
+	for _, change := range changes {
+		changed = append(changed, strings.Split(change.Name, ".")[0])
+	}
+	if payload := r.policyPayload(target.Apply, changed...); payload != nil {
+		return payload
+	}
+
+	data := make([]string, 0, len(changes)+len(notes))
+	for _, change := range changes {
+		data = append(data, change.String())
+	}
+	data = append(data, notes...)
+
+	if dryRun {
+		return &pb.Payload{Type: internal.CodeSuccess, Data: data}
+	}
+
+	oldCfg, newCfg := current.Apply(cfg), target.Apply(cfg)
+	steps := settingSteps(changed, current, target)
+	for i, step := range steps {
+		payload, err := r.applySetting(ctx, step, newCfg)
+		if settingApplied(payload, err) {
+			continue
+		}
+		if err != nil {
+			log.Println(internal.ErrorPrefix, "changing settings:", step, err)
+			payload = &pb.Payload{Type: internal.CodeFailure, Data: []string{err.Error()}}
+		}
+		if failed := r.revertSettings(ctx, steps[:i], current, oldCfg); len(failed) > 0 {
+			return &pb.Payload{Type: internal.CodeSettingsRollbackFailed, Data: failed}
+		}
+		return payload
+	}
+
+	// setters save their own settings, the final values are written at once
+	// so that nothing else is left behind
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		c = target.Apply(c)
+		if update != nil {
+			c = update(c)
+		}
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}
+	}
+
+	return &pb.Payload{Type: internal.CodeSuccess, Data: data}
+}
+
+// revertSettings undoes the applied steps in the reverse order and saves the
+// previous settings. Returns the steps which could not be reverted.
+func (r *RPC) revertSettings(
+	ctx context.Context,
+	applied []string,
+	previous config.Settings,
+	oldCfg config.Config,
+) []string {
+	var failed []string
+	for i := len(applied) - 1; i >= 0; i-- {
+		if undo, err := r.applySetting(ctx, applied[i], oldCfg); !settingApplied(undo, err) {
+			log.Println(internal.ErrorPrefix, "reverting setting:", applied[i], err)
+			failed = append(failed, applied[i])
+		}
+	}
+	// setters have saved the reverted values already, leftovers of the
+	// partially applied setters are overwritten
+	if err := config.SaveWithContext(ctx, r.cm, previous.Apply); err != nil {
+		log.Println(internal.ErrorPrefix, "reverting settings:", err)
+	}
+	return failed
+}
+
+// settingsOrder lists settings in the order in which they are enabled.
+// Settings are disabled in the reverse order, so that the dependencies
+// between them are satisfied, e.g. kill switch requires firewall.
+var settingsOrder = []string{
+	config.SettingFirewall,
+	config.SettingKillSwitch,
+	config.SettingRouting,
+	config.SettingFirewallMark,
+	config.SettingTechnology,
+	config.SettingIsolation,
+	config.SettingProtocol,
+	config.SettingObfuscate,
+	config.SettingIPv6,
+	config.SettingWhitelist,
+	config.SettingLanDiscovery,
+	config.SettingReconnectPolicy,
+	config.SettingDNS,
+	config.SettingAutoConnect,
+}
+
+// settingSteps returns setters needed to apply the changed settings in the
+// order in which they have to be called.
+func settingSteps(changed []string, old, new config.Settings) []string {
+	set := map[string]bool{}
+	for _, name := range changed {
+		switch name {
+		case config.SettingThreatProtectionLite:
+			name = config.SettingDNS
+		case config.SettingAutoConnectServer:
+			name = config.SettingAutoConnect
+		}
+		set[name] = true
+	}
+
+	var steps []string
+	for i := len(settingsOrder) - 1; i >= 0; i-- {
+		if name := settingsOrder[i]; set[name] && settingDisabled(name, old, new) {
+			steps = append(steps, name)
+		}
+	}
+	for _, name := range settingsOrder {
+		if set[name] && !settingDisabled(name, old, new) {
+			steps = append(steps, name)
+		}
+	}
+	return steps
+}
+
+func settingDisabled(name string, old, new config.Settings) bool {
+	switch name {
+	case config.SettingFirewall:
+		return old.Firewall && !new.Firewall
+	case config.SettingKillSwitch:
+		return old.KillSwitch && !new.KillSwitch
+	case config.SettingRouting:
+		return old.Routing && !new.Routing
+	case config.SettingObfuscate:
+		return old.Obfuscate && !new.Obfuscate
+	case config.SettingIPv6:
+		return old.IPv6 && !new.IPv6
+	case config.SettingIsolation:
+		return old.Isolation && !new.Isolation
+	case config.SettingAutoConnect:
+		return old.AutoConnect && !new.AutoConnect
+	}
+	return false
+}
+
+// applySetting calls the setter of the given setting with its value in cfg.
+func (r *RPC) applySetting(ctx context.Context, name string, cfg config.Config) (*pb.Payload, error) {
+	data := cfg.AutoConnectData
+	switch name {
+	case config.SettingFirewall:
+		return r.SetFirewall(ctx, &pb.SetGenericRequest{Enabled: cfg.Firewall})
+	case config.SettingKillSwitch:
+		return r.SetKillSwitch(ctx, &pb.SetKillSwitchRequest{
+			KillSwitch: cfg.KillSwitch,
+			Persistent: cfg.KillSwitchPersistent,
+		})
+	case config.SettingRouting:
+		return r.SetRouting(ctx, &pb.SetGenericRequest{Enabled: cfg.Routing.Get()})
+	case config.SettingFirewallMark:
+		return r.SetFirewallMark(ctx, &pb.SetUint32Request{Value: cfg.FirewallMark})
+	case config.SettingTechnology:
+		return r.SetTechnology(ctx, &pb.SetTechnologyRequest{Technology: cfg.Technology})
+	case config.SettingIsolation:
+		return r.SetIsolation(ctx, &pb.SetGenericRequest{Enabled: cfg.Isolation})
+	case config.SettingProtocol:
+		return r.SetProtocol(ctx, &pb.SetProtocolRequest{Protocol: data.Protocol})
+	case config.SettingObfuscate:
+		return r.SetObfuscate(ctx, &pb.SetGenericRequest{Enabled: data.Obfuscate})
+	case config.SettingIPv6:
+		return r.SetIpv6(ctx, &pb.SetGenericRequest{Enabled: cfg.IPv6})
+	case config.SettingWhitelist:
+		return r.SetWhitelist(ctx, &pb.SetWhitelistRequest{Whitelist: whitelistToProtobuf(data.Whitelist)})
+	case config.SettingLanDiscovery:
+		return r.SetLanDiscovery(ctx, &pb.SetGenericRequest{Enabled: cfg.LanDiscovery})
+	case config.SettingReconnectPolicy:
+		return r.SetReconnectPolicy(ctx, &pb.SetReconnectPolicyRequest{Policy: string(cfg.ReconnectPolicy.OrDefault())})
+	case config.SettingDNS:
+		return r.SetDNS(ctx, &pb.SetDNSRequest{Dns: data.DNS, ThreatProtectionLite: data.ThreatProtectionLite})
+	case config.SettingAutoConnect:
+		if cfg.AutoConnect {
+			// server cannot be changed while auto-connect is enabled
+			if payload, err := r.SetAutoConnect(ctx, &pb.SetAutoconnectRequest{}); !settingApplied(payload, err) {
+				return payload, err
+			}
+		}
+		return r.SetAutoConnect(ctx, &pb.SetAutoconnectRequest{
+			AutoConnect:          cfg.AutoConnect,
+			ServerTag:            data.ServerTag,
+			Protocol:             data.Protocol,
+			ThreatProtectionLite: data.ThreatProtectionLite,
+			Obfuscate:            data.Obfuscate,
+			Dns:                  data.DNS,
+		})
+	}
+	return nil, fmt.Errorf("unknown setting %s", name)
+}
+
+func settingApplied(payload *pb.Payload, err error) bool {
+	return err == nil && payload != nil &&
+		(payload.Type == internal.CodeSuccess || payload.Type == internal.CodeNothingToDo)
+}
@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/networker"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingSteps(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	enabled := config.Settings{Firewall: true, KillSwitch: true, AutoConnect: true}
+	disabled := config.Settings{}
+	changed := []string{
+		config.SettingAutoConnectServer,
+		config.SettingKillSwitch,
+		config.SettingThreatProtectionLite,
+		config.SettingFirewall,
+		config.SettingDNS,
+	}
+
+	assert.Equal(t, []string{
+		config.SettingFirewall,
+		config.SettingKillSwitch,
+		config.SettingDNS,
+		config.SettingAutoConnect,
+	}, settingSteps(changed, disabled, enabled))
+	assert.Equal(t, []string{
+		config.SettingAutoConnect,
+		config.SettingKillSwitch,
+		config.SettingFirewall,
+		config.SettingDNS,
+	}, settingSteps(changed, enabled, disabled))
+
+	// isolation requires nordlynx
+	isolated := config.Settings{Technology: "nordlynx", Isolation: true}
+	openvpn := config.Settings{Technology: "openvpn"}
+	changed = []string{config.SettingTechnology, config.SettingIsolation}
+	assert.Equal(t, []string{
+		config.SettingIsolation,
+		config.SettingTechnology,
+	}, settingSteps(changed, isolated, openvpn))
+	assert.Equal(t, []string{
+		config.SettingTechnology,
+		config.SettingIsolation,
+	}, settingSteps(changed, openvpn, isolated))
+}
+
+// irreversibleNetworker enables kill switch, but can neither disable it nor
+// enable LAN discovery
+type irreversibleNetworker struct {
+	workingNetworker
+}
+
+func (irreversibleNetworker) UnsetKillSwitch() error               { return errOnPurpose }
+func (irreversibleNetworker) SetLanDiscovery([]netip.Prefix) error { return errOnPurpose }
+
+type failingLanDiscoveryNetworker struct {
+	workingNetworker
+}
+
+func (failingLanDiscoveryNetworker) SetLanDiscovery([]netip.Prefix) error { return errOnPurpose }
+
+func TestRPC_ChangeSettings(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name     string
+		netw     networker.Networker
+		modify   func(*config.Settings)
+		expected int64
+		data     []string
+	}{
+		{
+			name:     "dependencies are validated",
+			netw:     workingNetworker{},
+			modify:   func(s *config.Settings) { s.KillSwitch = true; s.Firewall = false },
+			expected: internal.CodeFormatError,
+			data:     []string{"kill switch requires firewall"},
+		},
+		{
+			name:     "applied settings are reverted",
+			netw:     failingLanDiscoveryNetworker{},
+			modify:   func(s *config.Settings) { s.KillSwitch = true; s.LanDiscovery = true },
+			expected: internal.CodeFailure,
+		},
+		{
+			name:     "failed revert is reported",
+			netw:     irreversibleNetworker{},
+			modify:   func(s *config.Settings) { s.KillSwitch = true; s.LanDiscovery = true },
+			expected: internal.CodeSettingsRollbackFailed,
+			data:     []string{config.SettingKillSwitch},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cm := newMockConfigManager()
+			r := RPC{
+				cm:                   cm,
+				netw:                 test.netw,
+				events:               &Events{Settings: &SettingsEvents{Killswitch: &subs.Subject[bool]{}}},
+				persistentKillSwitch: &mockPersistentKillSwitch{},
+			}
+			target := config.NewSettings(cm.c)
+			test.modify(&target)
+
+			payload := r.changeSettings(context.Background(), cm.c, target, false, nil)
+			assert.Equal(t, test.expected, payload.Type)
+			assert.Equal(t, test.data, payload.Data)
+			// config is left as it was, even if the network could not be reverted
+			assert.False(t, cm.c.KillSwitch)
+			assert.False(t, cm.c.LanDiscovery)
+		})
+	}
+}
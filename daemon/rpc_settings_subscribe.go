@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"errors"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+)
+
+// SettingsSubscribe streams settings changes until the client disconnects.
+func (r *RPC) SettingsSubscribe(_ *pb.Empty, srv pb.Daemon_SettingsSubscribeServer) error {
+	events, unsubscribe := r.settingsBroadcaster.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("settings events are received too slowly")
+			}
+			if err := srv.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
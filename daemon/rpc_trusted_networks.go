@@ -0,0 +1,197 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/netstate"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// TrustedNetworks returns the rules in the evaluation order together with
+// the current network and the rule matching it.
+func (r *RPC) TrustedNetworks(ctx context.Context, _ *pb.Empty) (*pb.TrustedNetworksResponse, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.TrustedNetworksResponse{Type: internal.CodeConfigError}, nil
+	}
+
+	resp := &pb.TrustedNetworksResponse{Type: internal.CodeSuccess}
+	for _, network := range cfg.TrustedNetworks {
+		resp.Networks = append(resp.Networks, trustedNetworkToProtobuf(network))
+	}
+
+	fp, err := r.fingerprint()
+	if err != nil {
+		if !errors.Is(err, netstate.ErrNoNetwork) {
+			log.Println(internal.WarningPrefix, "fingerprinting network:", err)
+		}
+		return resp, nil
+	}
+	resp.Current = &pb.NetworkFingerprint{
+		Interface:  fp.Interface,
+		GatewayIp:  fp.GatewayIP,
+		GatewayMac: fp.GatewayMAC,
+		DhcpDomain: fp.DHCPDomain,
+		Addresses:  fp.Addresses,
+	}
+	if rule, ok := netstate.MatchTrustedNetwork(cfg.TrustedNetworks, fp); ok {
+		resp.Matched = rule.Name
+	}
+	return resp, nil
+}
+
+// AddTrustedNetwork adds a rule after the existing ones. Rules matching
+// every network are always evaluated last.
+func (r *RPC) AddTrustedNetwork(ctx context.Context, in *pb.AddTrustedNetworkRequest) (*pb.Payload, error) {
+	network := trustedNetworkFromProtobuf(in.GetNetwork())
+	if in.GetCurrent() {
+		fp, err := r.fingerprint()
+		if err != nil {
+			log.Println(internal.ErrorPrefix, "fingerprinting network:", err)
+			return &pb.Payload{Type: internal.CodeNoNetwork}, nil
+		}
+		// gateway MAC is the most specific, but it is not known until
+		// something has been sent through the gateway, so the IP is kept
+		// for matching before that
+		if network.GatewayMAC == "" {
+			network.GatewayMAC = fp.GatewayMAC
+		}
+		if network.GatewayIP == "" {
+			network.GatewayIP = fp.GatewayIP
+		}
+	}
+
+	// rules connect and disconnect VPN the same way auto-connect does
+	if payload := r.policyPayload(nil, config.SettingAutoConnect); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	if _, err := cfg.TrustedNetworks.Add(network); err != nil {
+		if errors.Is(err, config.ErrTrustedNetworkExists) {
+			return &pb.Payload{Type: internal.CodeConflict}, nil
+		}
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeFormatError}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		c.TrustedNetworks, _ = c.TrustedNetworks.Add(network)
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// RemoveTrustedNetwork removes the rule with the given name.
+func (r *RPC) RemoveTrustedNetwork(ctx context.Context, in *pb.RemoveTrustedNetworkRequest) (*pb.Payload, error) {
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	if _, ok := cfg.TrustedNetworks.Get(in.GetName()); !ok {
+		return &pb.Payload{Type: internal.CodeTrustedNetworkNonexisting}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		c.TrustedNetworks, _ = c.TrustedNetworks.Remove(in.GetName())
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// TrustedNetworkReconnector applies trusted network rules on network change.
+// Connectivity is refreshed by next unless the rule matching the new network
+// disconnects VPN.
+func (r *RPC) TrustedNetworkReconnector(next netstate.Reconnector) netstate.Reconnector {
+	return trustedNetworkReconnector{next: next, rpc: r}
+}
+
+type trustedNetworkReconnector struct {
+	next netstate.Reconnector
+	rpc  *RPC
+}
+
+func (t trustedNetworkReconnector) Reconnect(stateIsUp bool) {
+	if !stateIsUp {
+		t.next.Reconnect(stateIsUp)
+		return
+	}
+
+	var cfg config.Config
+	if err := t.rpc.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		t.next.Reconnect(stateIsUp)
+		return
+	}
+
+	rule, ok := t.rpc.matchTrustedNetwork(cfg.TrustedNetworks)
+	if ok && rule.Action == config.TrustedNetworkActionDisconnect {
+		if t.rpc.netw.IsVPNActive() {
+			log.Println(internal.InfoPrefix, "disconnecting on trusted network", rule.Name)
+			if err := t.rpc.disconnect(DisconnectReasonTrustedNetwork); err != nil {
+				log.Println(internal.ErrorPrefix, err)
+			}
+		}
+		return
+	}
+
+	t.next.Reconnect(stateIsUp)
+	if ok && rule.Action == config.TrustedNetworkActionConnect && !t.rpc.netw.IsVPNActive() {
+		log.Println(internal.InfoPrefix, "connecting on network", rule.Name)
+		t.rpc.connectAutomatically(cfg)
+	}
+}
+
+func (r *RPC) matchTrustedNetwork(rules config.TrustedNetworks) (config.TrustedNetwork, bool) {
+	if len(rules) == 0 {
+		return config.TrustedNetwork{}, false
+	}
+	fp, err := r.fingerprint()
+	if err != nil {
+		if !errors.Is(err, netstate.ErrNoNetwork) {
+			log.Println(internal.WarningPrefix, "fingerprinting network:", err)
+		}
+		return config.TrustedNetwork{}, false
+	}
+	return netstate.MatchTrustedNetwork(rules, fp)
+}
+
+func trustedNetworkToProtobuf(network config.TrustedNetwork) *pb.TrustedNetwork {
+	return &pb.TrustedNetwork{
+		Name:       network.Name,
+		GatewayIp:  network.GatewayIP,
+		GatewayMac: network.GatewayMAC,
+		Subnet:     network.Subnet,
+		Interface:  network.Interface,
+		DhcpDomain: network.DHCPDomain,
+		Action:     network.Action,
+	}
+}
+
+func trustedNetworkFromProtobuf(network *pb.TrustedNetwork) config.TrustedNetwork {
+	return config.TrustedNetwork{
+		Name:       network.GetName(),
+		GatewayIP:  network.GetGatewayIp(),
+		GatewayMAC: network.GetGatewayMac(),
+		Subnet:     network.GetSubnet(),
+		Interface:  network.GetInterface(),
+		DHCPDomain: network.GetDhcpDomain(),
+		Action:     network.GetAction(),
+	}
+}
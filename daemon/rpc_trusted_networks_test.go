@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/netstate"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/networker"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPC_TrustedNetworks(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	office := netstate.Fingerprint{
+		Interface:  "eth0",
+		GatewayIP:  "10.0.0.1",
+		GatewayMAC: "aa:bb:cc:dd:ee:ff",
+		Addresses:  []string{"10.0.0.5/24"},
+	}
+	r := RPC{
+		cm:          newMockConfigManager(),
+		fingerprint: func() (netstate.Fingerprint, error) { return office, nil },
+	}
+	ctx := context.Background()
+
+	payload, err := r.AddTrustedNetwork(ctx, &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "other", Action: config.TrustedNetworkActionConnect},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+
+	payload, err = r.AddTrustedNetwork(ctx, &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "office"},
+		Current: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+
+	payload, err = r.AddTrustedNetwork(ctx, &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "office", Subnet: "192.168.0.0/16"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeConflict, payload.Type)
+
+	payload, err = r.AddTrustedNetwork(ctx, &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "lab", Subnet: "192.168.0.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeFormatError, payload.Type)
+
+	resp, err := r.TrustedNetworks(ctx, &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, resp.Type)
+	assert.Equal(t, []*pb.TrustedNetwork{
+		{Name: "office", GatewayIp: office.GatewayIP, GatewayMac: office.GatewayMAC, Action: config.TrustedNetworkActionDisconnect},
+		{Name: "other", Action: config.TrustedNetworkActionConnect},
+	}, resp.Networks)
+	assert.Equal(t, office.GatewayIP, resp.Current.GatewayIp)
+	assert.Equal(t, "office", resp.Matched)
+
+	// gateway is not resolved yet right after joining the network
+	r.fingerprint = func() (netstate.Fingerprint, error) {
+		unresolved := office
+		unresolved.GatewayMAC = ""
+		return unresolved, nil
+	}
+	resp, err = r.TrustedNetworks(ctx, &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "office", resp.Matched)
+
+	payload, err = r.RemoveTrustedNetwork(ctx, &pb.RemoveTrustedNetworkRequest{Name: "office"})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+
+	payload, err = r.RemoveTrustedNetwork(ctx, &pb.RemoveTrustedNetworkRequest{Name: "office"})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeTrustedNetworkNonexisting, payload.Type)
+
+	resp, err = r.TrustedNetworks(ctx, &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "other", resp.Matched)
+}
+
+func TestRPC_AddTrustedNetworkOffline(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	r := RPC{
+		cm:          newMockConfigManager(),
+		fingerprint: func() (netstate.Fingerprint, error) { return netstate.Fingerprint{}, netstate.ErrNoNetwork },
+	}
+
+	payload, err := r.AddTrustedNetwork(context.Background(), &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "office"},
+		Current: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNoNetwork, payload.Type)
+}
+
+func TestTrustedNetworkReconnector(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	office := netstate.Fingerprint{Interface: "eth0", GatewayIP: "10.0.0.1"}
+	tests := []struct {
+		name     string
+		action   string
+		netw     networker.Networker
+		expected int
+	}{
+		{name: "no match", netw: workingNetworker{}, expected: 1},
+		{name: "connect", action: config.TrustedNetworkActionConnect, netw: workingNetworker{}, expected: 1},
+		{name: "disconnect", action: config.TrustedNetworkActionDisconnect, netw: failingNetworker{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cm := newMockConfigManager()
+			if test.action != "" {
+				cm.c.TrustedNetworks = config.TrustedNetworks{
+					{Name: "office", GatewayIP: office.GatewayIP, Action: test.action},
+				}
+			}
+			r := &RPC{
+				cm:          cm,
+				netw:        test.netw,
+				fingerprint: func() (netstate.Fingerprint, error) { return office, nil },
+			}
+			next := &countingReconnector{}
+
+			r.TrustedNetworkReconnector(next).Reconnect(true)
+			assert.Equal(t, test.expected, next.count)
+		})
+	}
+}
+
+func TestRPC_AddTrustedNetworkPolicy(t *testing.T) {
+	category.Set(t, category.File)
+
+	if os.Getuid() != 0 {
+		t.Skip("policy file has to be owned by root")
+	}
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("enforce:\n  auto_connect: false\n"), 0644))
+	r := RPC{cm: newMockConfigManager(), policyPath: path}
+
+	payload, err := r.AddTrustedNetwork(context.Background(), &pb.AddTrustedNetworkRequest{
+		Network: &pb.TrustedNetwork{Name: "office", Subnet: "10.0.0.0/24", Action: config.TrustedNetworkActionConnect},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodePolicyEnforced, payload.Type)
+}
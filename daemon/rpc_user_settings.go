@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+
+	"golang.org/x/exp/maps"
+)
+
+// UserSettings returns settings overridden by the calling user.
+func (r *RPC) UserSettings(ctx context.Context, _ *pb.Empty) (*pb.UserSettingsResponse, error) {
+	caller, ok := config.CallerFromContext(ctx)
+	if !ok || caller.UnknownUID {
+		return &pb.UserSettingsResponse{Type: internal.CodeFailure}, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.UserSettingsResponse{Type: internal.CodeConfigError}, nil
+	}
+
+	settings := []*pb.UserSetting{}
+	for name, value := range cfg.UsersData.Overlay(int64(caller.UID)).Values() {
+		settings = append(settings, &pb.UserSetting{Name: name, Value: value})
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Name < settings[j].Name })
+	return &pb.UserSettingsResponse{Type: internal.CodeSuccess, Settings: settings}, nil
+}
+
+// SetUserSetting overrides a setting for the calling user only.
+func (r *RPC) SetUserSetting(ctx context.Context, in *pb.UserSettingRequest) (*pb.Payload, error) {
+	return r.changeOverlay(ctx, in.GetName(), func(o config.Overlay) (config.Overlay, error) {
+		return o.Set(in.GetName(), in.GetValues())
+	})
+}
+
+// UnsetUserSetting makes the calling user follow the global setting again.
+func (r *RPC) UnsetUserSetting(ctx context.Context, in *pb.UserSettingRequest) (*pb.Payload, error) {
+	return r.changeOverlay(ctx, in.GetName(), func(o config.Overlay) (config.Overlay, error) {
+		return o.Unset(in.GetName())
+	})
+}
+
+func (r *RPC) changeOverlay(
+	ctx context.Context,
+	name string,
+	fn func(config.Overlay) (config.Overlay, error),
+) (*pb.Payload, error) {
+	caller, ok := config.CallerFromContext(ctx)
+	if !ok || caller.UnknownUID {
+		return &pb.Payload{Type: internal.CodeFailure}, nil
+	}
+	if payload := r.policyPayload(nil, name); payload != nil {
+		return payload, nil
+	}
+
+	var cfg config.Config
+	if err := r.cm.Load(&cfg); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+
+	uid := int64(caller.UID)
+	old := cfg.UsersData.Overlay(uid)
+	overlay, err := fn(old)
+	if err != nil {
+		if errors.Is(err, config.ErrGlobalSetting) {
+			return &pb.Payload{Type: internal.CodeGlobalSetting, Data: []string{name}}, nil
+		}
+		return &pb.Payload{Type: internal.CodeFormatError, Data: []string{err.Error()}}, nil
+	}
+	if maps.Equal(overlay.Values(), old.Values()) {
+		return &pb.Payload{Type: internal.CodeNothingToDo}, nil
+	}
+
+	if err := config.SaveWithContext(ctx, r.cm, func(c config.Config) config.Config {
+		if c.UsersData == nil {
+			c.UsersData = &config.UsersData{Notify: config.Notify{}}
+		}
+		c.UsersData.SetOverlay(uid, overlay)
+		return c
+	}); err != nil {
+		log.Println(internal.ErrorPrefix, err)
+		return &pb.Payload{Type: internal.CodeConfigError}, nil
+	}
+	return &pb.Payload{Type: internal.CodeSuccess}, nil
+}
+
+// userConfig returns the config with settings overridden by the calling
+// user, except for the ones locked by the administrator policy. Overrides
+// are not applied if the policy cannot be loaded.
+func (r *RPC) userConfig(ctx context.Context, cfg config.Config) (config.Config, error) {
+	caller, ok := config.CallerFromContext(ctx)
+	if !ok || caller.UnknownUID {
+		return cfg, nil
+	}
+	overlay := cfg.UsersData.Overlay(int64(caller.UID))
+	if overlay.IsEmpty() {
+		return cfg, nil
+	}
+
+	policy, err := config.LoadPolicy(r.policyPath)
+	if err != nil {
+		return cfg, fmt.Errorf("loading policy: %w", err)
+	}
+	return overlay.Without(policy.Locked()...).Apply(cfg), nil
+}
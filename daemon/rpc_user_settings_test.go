@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPC_UserSettings(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	cm := newMockConfigManager()
+	r := RPC{cm: cm, policyPath: filepath.Join(t.TempDir(), "policy.yaml")}
+	alice := config.WithCaller(context.Background(), config.Caller{UID: 1000})
+	bob := config.WithCaller(context.Background(), config.Caller{UID: 1001})
+
+	payload, err := r.SetUserSetting(alice, &pb.UserSettingRequest{Name: config.SettingProtocol, Values: []string{"tcp"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+
+	payload, err = r.SetUserSetting(alice, &pb.UserSettingRequest{Name: config.SettingProtocol, Values: []string{"TCP"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeNothingToDo, payload.Type)
+
+	payload, err = r.SetUserSetting(alice, &pb.UserSettingRequest{Name: config.SettingFirewall, Values: []string{"off"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeGlobalSetting, payload.Type)
+
+	payload, err = r.SetUserSetting(alice, &pb.UserSettingRequest{Name: config.SettingDNS, Values: []string{"dns"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeFormatError, payload.Type)
+
+	payload, err = r.SetUserSetting(context.Background(), &pb.UserSettingRequest{Name: config.SettingProtocol, Values: []string{"tcp"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeFailure, payload.Type)
+	unknown := config.WithCaller(context.Background(), config.Caller{UnknownUID: true})
+	payload, err = r.SetUserSetting(unknown, &pb.UserSettingRequest{Name: config.SettingProtocol, Values: []string{"tcp"}})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeFailure, payload.Type)
+
+	resp, err := r.UserSettings(alice, &pb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.UserSetting{{Name: config.SettingProtocol, Value: "tcp"}}, resp.Settings)
+	resp, err = r.UserSettings(bob, &pb.Empty{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Settings)
+
+	var cfg config.Config
+	require.NoError(t, cm.Load(&cfg))
+	// global settings are untouched
+	assert.Equal(t, config.Protocol_UDP, cfg.AutoConnectData.Protocol)
+	aliceCfg, err := r.userConfig(alice, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, config.Protocol_TCP, aliceCfg.AutoConnectData.Protocol)
+	bobCfg, err := r.userConfig(bob, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, config.Protocol_UDP, bobCfg.AutoConnectData.Protocol)
+
+	// overrides are not applied when locked settings are unknown
+	require.NoError(t, os.WriteFile(r.policyPath, []byte("enforce: ["), 0644))
+	_, err = r.userConfig(alice, cfg)
+	assert.Error(t, err)
+	require.NoError(t, os.Remove(r.policyPath))
+
+	payload, err = r.UnsetUserSetting(alice, &pb.UserSettingRequest{Name: config.SettingProtocol})
+	require.NoError(t, err)
+	assert.Equal(t, internal.CodeSuccess, payload.Type)
+	require.NoError(t, cm.Load(&cfg))
+	assert.Empty(t, cfg.UsersData.Overlays)
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/events"
+)
+
+// DisconnectReasonUser is recorded when VPN connection was stopped on user request
+const DisconnectReasonUser = "user request"
+
+// DisconnectReasonTrustedNetwork is recorded when VPN connection was stopped
+// because the host joined a trusted network
+const DisconnectReasonTrustedNetwork = "trusted network"
+
+// ServerHistoryRecorder stores per server connection outcomes reported via
+// connect and disconnect events. Besides the daemon, disconnects are also
+// reported by the networker when the tunnel drops or is re-created.
+type ServerHistoryRecorder struct {
+	dm          *DataManager
+	attemptedAt time.Time
+	mu          sync.Mutex
+}
+
+func NewServerHistoryRecorder(dm *DataManager) *ServerHistoryRecorder {
+	return &ServerHistoryRecorder{dm: dm}
+}
+
+func (h *ServerHistoryRecorder) NotifyConnect(data events.DataConnect) error {
+	if data.TargetServerDomain == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch data.Type {
+	case events.ConnectAttempt:
+		h.attemptedAt = time.Now()
+	case events.ConnectSuccess, events.ConnectFailure:
+		var connectTime time.Duration
+		if !h.attemptedAt.IsZero() {
+			connectTime = time.Since(h.attemptedAt)
+		}
+		h.attemptedAt = time.Time{}
+		return h.dm.AddConnectionOutcome(
+			data.TargetServerDomain,
+			data.Type == events.ConnectSuccess,
+			connectTime,
+		)
+	}
+	return nil
+}
+
+func (h *ServerHistoryRecorder) NotifyDisconnect(data events.DataDisconnect) error {
+	if data.TargetServerDomain == "" {
+		return nil
+	}
+	return h.dm.AddDisconnectOutcome(
+		data.TargetServerDomain,
+		data.Uptime,
+		data.Download+data.Upload,
+		data.Reason,
+		data.Type == events.DisconnectFailure,
+	)
+}
@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/networker"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerHistoryRecorder(t *testing.T) {
+	category.Set(t, category.File)
+
+	dir := t.TempDir()
+	dm := NewDataManager(
+		filepath.Join(dir, TestInsightsFile),
+		filepath.Join(dir, TestServersFile),
+		filepath.Join(dir, TestCountryFile),
+		filepath.Join(dir, TestVersionFile),
+		filepath.Join(dir, TestHistoryFile),
+	)
+	assert.NoError(t, dm.SetServersData(time.Now(), core.Servers{
+		{Hostname: "lt16.nordvpn.com", Penalty: 1, PartialPenalty: 1},
+		{Hostname: "lv20.nordvpn.com", Penalty: 1.1, PartialPenalty: 1.1},
+	}, ""))
+
+	recorder := NewServerHistoryRecorder(dm)
+	connect := events.DataConnect{TargetServerDomain: "lt16.nordvpn.com", Type: events.ConnectAttempt}
+	assert.NoError(t, recorder.NotifyConnect(connect))
+	connect.Type = events.ConnectFailure
+	assert.NoError(t, recorder.NotifyConnect(connect))
+
+	// failed server is deprioritized
+	servers := dm.GetServersData().Servers
+	assert.Equal(t, "lv20.nordvpn.com", servers[0].Hostname)
+	assert.InDelta(t, 1+historyPenalty(0, 1), servers[1].Penalty, PenaltyDelta)
+
+	connect.Type = events.ConnectAttempt
+	assert.NoError(t, recorder.NotifyConnect(connect))
+	connect.Type = events.ConnectSuccess
+	assert.NoError(t, recorder.NotifyConnect(connect))
+	assert.NoError(t, recorder.NotifyDisconnect(events.DataDisconnect{
+		TargetServerDomain: "lt16.nordvpn.com",
+		Reason:             DisconnectReasonUser,
+		Uptime:             10 * time.Second,
+		Download:           1500,
+		Upload:             500,
+	}))
+
+	history := dm.GetServerHistoryData().Servers["lt16.nordvpn.com"]
+	assert.Equal(t, 1, history.Successes)
+	assert.Equal(t, 1, history.Failures)
+	assert.Equal(t, uint64(200), history.AverageThroughput())
+	assert.Equal(t, DisconnectReasonUser, history.DisconnectReason)
+	assert.False(t, history.ConnectedAt.IsZero())
+
+	// history survives restarts
+	loaded := ServerHistoryData{filePath: filepath.Join(dir, TestHistoryFile)}
+	assert.NoError(t, loaded.load())
+	loadedHistory := loaded.Servers["lt16.nordvpn.com"]
+	assert.Equal(t, history.Successes, loadedHistory.Successes)
+	assert.Equal(t, history.Failures, loadedHistory.Failures)
+	assert.True(t, history.ConnectedAt.Equal(loadedHistory.ConnectedAt))
+
+	// dropped tunnels count as failures
+	assert.NoError(t, recorder.NotifyDisconnect(events.DataDisconnect{
+		TargetServerDomain: "lt16.nordvpn.com",
+		Type:               events.DisconnectFailure,
+		Reason:             networker.DisconnectReasonConnectionLost,
+	}))
+	history = dm.GetServerHistoryData().Servers["lt16.nordvpn.com"]
+	assert.Equal(t, 2, history.Failures)
+	assert.Equal(t, networker.DisconnectReasonConnectionLost, history.DisconnectReason)
+	servers = dm.GetServersData().Servers
+	assert.InDelta(t, 1+historyPenalty(1, 2), servers[1].Penalty, PenaltyDelta)
+}
@@ -285,7 +285,7 @@ func serverTagFromString(
 
 	var err error
 	if len(countries) == 0 {
-		countries, _, err = api.ServersCountries()
+		countries, _, err = api.ServersCountries("")
 		if err != nil {
 			return core.ServerTag{Action: core.ServerByUnknown, ID: 0}, err
 		}
@@ -0,0 +1,298 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+// Servers data file starts with a magic and a format version followed by the
+// length prefixed index and the server records:
+//
+//	magic | version (uint16) | index length (uint32) | index | records
+//
+// Records are gob encoded by a single encoder, so that all of them can be
+// decoded at once. Type definitions are kept in the index as a preamble which
+// allows decoding a single record as well. Files without magic are written by
+// the older versions and contain a gob encoded ServersData.
+const (
+	serversDataMagic   = "NVSD"
+	serversDataVersion = 2
+	// serversDataHeaderSize is a size of magic, version and index length
+	serversDataHeaderSize = len(serversDataMagic) + 2 + 4
+)
+
+type ServersData struct {
+	filePath  string
+	UpdatedAt time.Time
+	// Servers are kept in memory only until they are saved.
+	// Use DataManager.GetServersData to read them.
+	Servers core.Servers
+	Hash    string
+	index   *serversIndex
+}
+
+// serversIndex is kept in memory instead of the servers list.
+type serversIndex struct {
+	UpdatedAt time.Time
+	Hash      string
+	Preamble  []byte
+	Entries   []serverEntry
+	// hostnames map full hostnames to the entries
+	hostnames map[string]int
+}
+
+type serverEntry struct {
+	Offset int64
+	Length int64
+	// Summary contains only the fields needed for lookups and app data.
+	// IPs, specifications, technology metadata and penalties are left out.
+	Summary core.Server
+}
+
+func (data *ServersData) load() error {
+	file, err := os.Open(data.filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := make([]byte, serversDataHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil ||
+		string(header[:len(serversDataMagic)]) != serversDataMagic {
+		return data.migrate()
+	}
+
+	version := binary.BigEndian.Uint16(header[len(serversDataMagic):])
+	switch version {
+	case serversDataVersion:
+	default:
+		return fmt.Errorf("unsupported servers data version %d", version)
+	}
+
+	indexLength := binary.BigEndian.Uint32(header[len(serversDataMagic)+2:])
+	var index serversIndex
+	if err := gob.NewDecoder(io.LimitReader(file, int64(indexLength))).Decode(&index); err != nil {
+		return fmt.Errorf("decoding servers index: %w", err)
+	}
+	data.setIndex(&index)
+	return nil
+}
+
+// migrate servers data from the format used by the older versions.
+func (data *ServersData) migrate() error {
+	content, err := internal.FileRead(data.filePath)
+	if err != nil {
+		return err
+	}
+
+	var legacy struct {
+		UpdatedAt time.Time
+		Servers   core.Servers
+		Hash      string
+	}
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(&legacy); err != nil {
+		return err
+	}
+
+	data.UpdatedAt = legacy.UpdatedAt
+	data.Servers = legacy.Servers
+	data.Hash = legacy.Hash
+	return data.save()
+}
+
+// save writes servers to disk and releases them from memory.
+func (data *ServersData) save() error {
+	var records bytes.Buffer
+	encoder := gob.NewEncoder(&records)
+	// zero value makes encoder write type definitions
+	if err := encoder.Encode(core.Server{}); err != nil {
+		return err
+	}
+	index := serversIndex{
+		UpdatedAt: data.UpdatedAt,
+		Hash:      data.Hash,
+		Preamble:  bytes.Clone(records.Bytes()),
+		Entries:   make([]serverEntry, 0, len(data.Servers)),
+	}
+	records.Reset()
+
+	for _, server := range data.Servers {
+		offset := records.Len()
+		if err := encoder.Encode(server); err != nil {
+			return err
+		}
+		index.Entries = append(index.Entries, serverEntry{
+			Offset:  int64(offset),
+			Length:  int64(records.Len() - offset),
+			Summary: serverSummary(server),
+		})
+	}
+
+	var encodedIndex bytes.Buffer
+	if err := gob.NewEncoder(&encodedIndex).Encode(index); err != nil {
+		return err
+	}
+
+	content := make([]byte, 0, serversDataHeaderSize+encodedIndex.Len()+records.Len())
+	content = append(content, serversDataMagic...)
+	content = binary.BigEndian.AppendUint16(content, serversDataVersion)
+	content = binary.BigEndian.AppendUint32(content, uint32(encodedIndex.Len()))
+	content = append(content, encodedIndex.Bytes()...)
+	content = append(content, records.Bytes()...)
+
+	// write to a temporary file first so that records are never read from
+	// a partially written file
+	tmpPath := data.filePath + ".tmp"
+	if err := internal.FileWrite(tmpPath, content, internal.PermUserRWGroupROthersR); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, data.filePath); err != nil {
+		return err
+	}
+
+	data.setIndex(&index)
+	data.Servers = nil
+	return nil
+}
+
+func (data *ServersData) setIndex(index *serversIndex) {
+	index.hostnames = make(map[string]int, len(index.Entries))
+	for idx, entry := range index.Entries {
+		index.hostnames[entry.Summary.Hostname] = idx
+	}
+	data.index = index
+	data.UpdatedAt = index.UpdatedAt
+	data.Hash = index.Hash
+}
+
+// all returns the full servers list, reading it from disk if needed.
+func (data *ServersData) all() (core.Servers, error) {
+	if data.Servers != nil || data.index == nil {
+		return data.Servers, nil
+	}
+
+	file, err := os.Open(data.filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	offset, err := data.recordsOffset(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := gob.NewDecoder(io.MultiReader(
+		bytes.NewReader(data.index.Preamble),
+		io.NewSectionReader(file, offset, 1<<62),
+	))
+	if err := decoder.Decode(&core.Server{}); err != nil {
+		return nil, err
+	}
+
+	servers := make(core.Servers, len(data.index.Entries))
+	for idx := range servers {
+		if err := decoder.Decode(&servers[idx]); err != nil {
+			return nil, fmt.Errorf("decoding server %d: %w", idx, err)
+		}
+	}
+	return servers, nil
+}
+
+// summaries returns servers without the details which are needed only to
+// connect. They are kept in the index, so disk is not accessed.
+func (data *ServersData) summaries() core.Servers {
+	if data.Servers != nil || data.index == nil {
+		return data.Servers
+	}
+	servers := make(core.Servers, 0, len(data.index.Entries))
+	for _, entry := range data.index.Entries {
+		servers = append(servers, entry.Summary)
+	}
+	return servers
+}
+
+// byHostname reads a single server from disk.
+func (data *ServersData) byHostname(hostname string) (core.Server, error) {
+	if data.Servers != nil || data.index == nil {
+		for _, server := range data.Servers {
+			if server.Hostname == hostname {
+				return server, nil
+			}
+		}
+		return core.Server{}, internal.ErrServerIsUnavailable
+	}
+
+	idx, ok := data.index.hostnames[hostname]
+	if !ok {
+		return core.Server{}, internal.ErrServerIsUnavailable
+	}
+	entry := data.index.Entries[idx]
+
+	file, err := os.Open(data.filePath)
+	if err != nil {
+		return core.Server{}, err
+	}
+	defer file.Close()
+
+	offset, err := data.recordsOffset(file)
+	if err != nil {
+		return core.Server{}, err
+	}
+	decoder := gob.NewDecoder(io.MultiReader(
+		bytes.NewReader(data.index.Preamble),
+		io.NewSectionReader(file, offset+entry.Offset, entry.Length),
+	))
+	if err := decoder.Decode(&core.Server{}); err != nil {
+		return core.Server{}, err
+	}
+	var server core.Server
+	if err := decoder.Decode(&server); err != nil {
+		return core.Server{}, fmt.Errorf("decoding server %s: %w", hostname, err)
+	}
+	return server, nil
+}
+
+func (data *ServersData) recordsOffset(file *os.File) (int64, error) {
+	header := make([]byte, serversDataHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return 0, err
+	}
+	indexLength := binary.BigEndian.Uint32(header[len(serversDataMagic)+2:])
+	return int64(serversDataHeaderSize) + int64(indexLength), nil
+}
+
+func (data *ServersData) exists() bool {
+	return internal.FileExists(data.filePath)
+}
+
+func (data *ServersData) isValid() bool {
+	// in order not to override servers.dat - uncomment
+	// return true
+	return data.UpdatedAt.Add(1 * time.Hour).After(time.Now())
+}
+
+func serverSummary(server core.Server) core.Server {
+	technologies := make(core.Technologies, 0, len(server.Technologies))
+	for _, technology := range server.Technologies {
+		technologies = append(technologies, core.Technology{
+			ID:    technology.ID,
+			Pivot: technology.Pivot,
+		})
+	}
+	return core.Server{
+		ID:           server.ID,
+		Hostname:     server.Hostname,
+		Status:       server.Status,
+		Locations:    server.Locations,
+		Technologies: technologies,
+		Groups:       server.Groups,
+	}
+}
@@ -0,0 +1,168 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/core"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServersList(count int) core.Servers {
+	servers := make(core.Servers, 0, count)
+	for i := 0; i < count; i++ {
+		server := testServer(int64(i), fmt.Sprintf("lt%d.nordvpn.com", i), core.Online)
+		server.Keys = []string{"lithuania", "lt", "vilnius", fmt.Sprintf("lt%d", i)}
+		server.Technologies = core.Technologies{
+			{ID: core.WireguardTech, Pivot: core.Pivot{Status: core.Online}},
+			{ID: core.OpenVPNUDP, Pivot: core.Pivot{Status: core.Online}},
+		}
+		server.Technologies[0].Metadata = append(server.Technologies[0].Metadata, struct {
+			Name  string      `json:"name,omitempty"`
+			Value interface{} `json:"value,omitempty"`
+		}{Name: "public_key", Value: "QGcVJTq0Zt8yT9lAm3BzuRtoMXvH6r1E9t0NCVp9bHs="})
+		server.IPRecords = []core.ServerIPRecord{
+			{ServerIP: core.ServerIP{IP: fmt.Sprintf("10.0.%d.%d", i/256, i%256), Version: 4}, Type: "entry"},
+			{ServerIP: core.ServerIP{IP: fmt.Sprintf("2001:db8::%x", i), Version: 6}, Type: "entry"},
+		}
+		server.Specifications = []core.Specification{{Identifier: "version"}}
+		server.Specifications[0].Values = append(server.Specifications[0].Values, struct {
+			Value string `json:"value"`
+		}{Value: "2.1.0"})
+		server.Station = fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+		server.Groups = core.Groups{{ID: 11, Title: "Standard VPN servers"}, {ID: 19, Title: "Europe"}}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+func writeLegacyServersData(t testing.TB, path string, servers core.Servers) {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(ServersData{
+		UpdatedAt: time.Now(),
+		Servers:   servers,
+		Hash:      "hash",
+	}))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), internal.PermUserRWGroupROthersR))
+}
+
+func TestServersData_Migration(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), TestServersFile)
+	require.NoError(t, internal.FileCopy(TestdataPath+"s2.dat", path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var legacy ServersData
+	require.NoError(t, gob.NewDecoder(bytes.NewReader(content)).Decode(&legacy))
+
+	data := ServersData{filePath: path}
+	require.NoError(t, data.load())
+	assert.Nil(t, data.Servers)
+	assert.Equal(t, legacy.Hash, data.Hash)
+	assert.True(t, legacy.UpdatedAt.Equal(data.UpdatedAt))
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte(serversDataMagic)))
+
+	// migrated file is loaded without another migration
+	data = ServersData{filePath: path}
+	require.NoError(t, data.load())
+	servers, err := data.all()
+	require.NoError(t, err)
+	assert.Equal(t, legacy.Servers, servers)
+}
+
+func TestServersData_LazyLookups(t *testing.T) {
+	category.Set(t, category.File)
+
+	path := filepath.Join(t.TempDir(), TestServersFile)
+	original := testServersList(50)
+	data := ServersData{filePath: path, Servers: original, UpdatedAt: time.Now(), Hash: "hash"}
+	require.NoError(t, data.save())
+	assert.Nil(t, data.Servers)
+
+	data = ServersData{filePath: path}
+	require.NoError(t, data.load())
+	assert.True(t, data.isValid())
+
+	server, err := data.byHostname("lt42.nordvpn.com")
+	require.NoError(t, err)
+	assert.Equal(t, original[42], server)
+
+	_, err = data.byHostname("lv1.nordvpn.com")
+	assert.ErrorIs(t, err, internal.ErrServerIsUnavailable)
+
+	summaries := data.summaries()
+	assert.Len(t, summaries, len(original))
+	assert.Empty(t, summaries[7].IPRecords)
+	assert.Empty(t, summaries[7].Technologies[0].Metadata)
+	assert.Empty(t, summaries[7].Keys)
+	assert.Equal(t, original[7].Hostname, summaries[7].Hostname)
+	assert.True(t, core.IsConnectableVia(core.WireguardTech)(summaries[7]))
+
+	servers, err := data.all()
+	require.NoError(t, err)
+	assert.Equal(t, original, servers)
+}
+
+// BenchmarkServersDataLoad compares daemon startup with the legacy and indexed
+// servers data. Retained heap after load approximates daemon RSS growth.
+func BenchmarkServersDataLoad(b *testing.B) {
+	servers := testServersList(6000)
+
+	b.Run("legacy", func(b *testing.B) {
+		path := filepath.Join(b.TempDir(), TestServersFile)
+		writeLegacyServersData(b, path, servers)
+		benchmarkLoad(b, func() any {
+			content, err := internal.FileRead(path)
+			require.NoError(b, err)
+			var data ServersData
+			require.NoError(b, gob.NewDecoder(bytes.NewReader(content)).Decode(&data))
+			return data
+		})
+	})
+
+	b.Run("indexed", func(b *testing.B) {
+		path := filepath.Join(b.TempDir(), TestServersFile)
+		data := ServersData{filePath: path, Servers: servers, UpdatedAt: time.Now()}
+		require.NoError(b, data.save())
+		benchmarkLoad(b, func() any {
+			data := ServersData{filePath: path}
+			require.NoError(b, data.load())
+			return data
+		})
+	})
+}
+
+func benchmarkLoad(b *testing.B, load func() any) {
+	b.ReportAllocs()
+	var retained uint64
+	var loaded any
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		loaded = nil
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		loaded = load()
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		if after.HeapAlloc > before.HeapAlloc {
+			retained += after.HeapAlloc - before.HeapAlloc
+		}
+	}
+	runtime.KeepAlive(loaded)
+	b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+}
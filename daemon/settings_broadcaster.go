@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"sync"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/events"
+)
+
+// settingsEventsBuffer is the number of events kept for a client which
+// does not keep up. Clients falling further behind are disconnected, so
+// that they can re-read the settings instead of seeing stale values.
+const settingsEventsBuffer = 64
+
+// SettingsBroadcaster forwards settings events to subscribed gRPC clients.
+//
+// Thread-safe.
+type SettingsBroadcaster struct {
+	subscribers map[chan *pb.SettingsEvent]struct{}
+	mu          sync.Mutex
+}
+
+// NewSettingsBroadcaster without any subscribers.
+func NewSettingsBroadcaster() *SettingsBroadcaster {
+	return &SettingsBroadcaster{subscribers: map[chan *pb.SettingsEvent]struct{}{}}
+}
+
+// subscribe returns a channel of events and a function to stop receiving
+// them. The channel is closed when the subscriber falls behind.
+func (b *SettingsBroadcaster) subscribe() (<-chan *pb.SettingsEvent, func()) {
+	ch := make(chan *pb.SettingsEvent, settingsEventsBuffer)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *SettingsBroadcaster) publish(event *pb.SettingsEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+func (b *SettingsBroadcaster) NotifyKillswitch(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_KillSwitch{KillSwitch: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyAutoconnect(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_AutoConnect{AutoConnect: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyDNS(data events.DataDNS) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Dns{
+		Dns: &pb.DNSEvent{Enabled: data.Enabled, Ips: data.Ips},
+	}})
+}
+
+func (b *SettingsBroadcaster) NotifyThreatProtectionLite(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_ThreatProtectionLite{ThreatProtectionLite: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyProtocol(value config.Protocol) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Protocol{Protocol: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyWhitelist(data events.DataWhitelist) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Whitelist{
+		Whitelist: &pb.WhitelistEvent{
+			Subnets:  int64(data.Subnets),
+			TcpPorts: int64(data.TCPPorts),
+			UdpPorts: int64(data.UDPPorts),
+		},
+	}})
+}
+
+func (b *SettingsBroadcaster) NotifyTechnology(value config.Technology) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Technology{Technology: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyObfuscate(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Obfuscate{Obfuscate: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyFirewall(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Firewall{Firewall: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyRouting(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Routing{Routing: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyNotify(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Notify{Notify: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyMeshnet(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Meshnet{Meshnet: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyIpv6(value bool) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Ipv6{Ipv6: value}})
+}
+
+func (b *SettingsBroadcaster) NotifyDefaults(any) error {
+	return b.publish(&pb.SettingsEvent{Event: &pb.SettingsEvent_Defaults{Defaults: &pb.Empty{}}})
+}
@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/pb"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsBroadcaster(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	broadcaster := NewSettingsBroadcaster()
+	first, unsubscribeFirst := broadcaster.subscribe()
+	second, unsubscribeSecond := broadcaster.subscribe()
+	defer unsubscribeSecond()
+
+	assert.NoError(t, broadcaster.NotifyKillswitch(true))
+	assert.NoError(t, broadcaster.NotifyTechnology(config.Technology_OPENVPN))
+	assert.NoError(t, broadcaster.NotifyDNS(events.DataDNS{Enabled: true, Ips: []string{"1.1.1.1"}}))
+
+	for _, ch := range []<-chan *pb.SettingsEvent{first, second} {
+		assert.True(t, (<-ch).GetKillSwitch())
+		assert.Equal(t, config.Technology_OPENVPN, (<-ch).GetTechnology())
+		assert.Equal(t, []string{"1.1.1.1"}, (<-ch).GetDns().GetIps())
+	}
+
+	unsubscribeFirst()
+	// unsubscribing twice is safe
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+
+	assert.NoError(t, broadcaster.NotifyDefaults(nil))
+	assert.NotNil(t, (<-second).GetDefaults())
+}
+
+func TestSettingsBroadcaster_SlowSubscriber(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	broadcaster := NewSettingsBroadcaster()
+	ch, unsubscribe := broadcaster.subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= settingsEventsBuffer; i++ {
+		require.NoError(t, broadcaster.NotifyIpv6(true))
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	// channel is closed after the buffered events are read
+	assert.Equal(t, settingsEventsBuffer, received)
+}
@@ -65,7 +65,7 @@ func (k *KernelSpace) Start(
 	}
 
 	interfaceIps := []netip.Addr{netip.MustParseAddr("10.5.0.2")}
-	ipv6, err := vpn.InterfaceIPv6(serverData.IP, interfaceID())
+	ipv6, err := vpn.InterfaceIPv6(serverData.IPv6, interfaceID())
 	if err == nil {
 		interfaceIps = append(interfaceIps, ipv6)
 	}
@@ -53,14 +53,14 @@ type ovpnConfigData struct {
 
 // setOpenVPNConfig is used to pass generated config to the OpenVPN process.
 // Config has to be passed everytime when new OpenVPN process is started.
-func setOpenVPNConfig(protocol config.Protocol, serverIP netip.Addr, obfuscated bool, serverVersion string) error {
+func setOpenVPNConfig(protocol config.Protocol, serverIP netip.Addr, ipv6 bool, obfuscated bool, serverVersion string) error {
 	if serverVersion == "" {
 		return ErrServerVersion
 	}
-	return generateConfigFile(protocol, serverIP, obfuscated)
+	return generateConfigFile(protocol, serverIP, ipv6, obfuscated)
 }
 
-func generateConfigFile(protocol config.Protocol, serverIP netip.Addr, obfuscated bool) error {
+func generateConfigFile(protocol config.Protocol, serverIP netip.Addr, ipv6 bool, obfuscated bool) error {
 	templatePath := internal.OvpnTemplatePath
 	if obfuscated {
 		templatePath = internal.OvpnObfsTemplatePath
@@ -81,7 +81,8 @@ func generateConfigFile(protocol config.Protocol, serverIP netip.Addr, obfuscate
 		return fmt.Errorf("generating OpenVPN config: %w", err)
 	}
 
-	if err := addExtraParameters(out, serverIP, protocol); err != nil {
+	out, err = addExtraParameters(out, serverIP, ipv6, protocol)
+	if err != nil {
 		return fmt.Errorf("adding extra parameters to OpenVPN config: %w", err)
 	}
 
@@ -173,9 +174,14 @@ func getConfigIdentifier(protocol config.Protocol, obfuscated bool) (openvpnID,
 	}
 }
 
-func addExtraParameters(data []byte, serverIP netip.Addr, protocol config.Protocol) error {
+// addExtraParameters to the config. IPv6 address is accepted from the server
+// only for the dual-stack tunnel, while IPv6 routes are always managed by the
+// daemon itself.
+func addExtraParameters(data []byte, serverIP netip.Addr, ipv6 bool, protocol config.Protocol) ([]byte, error) {
 	args := strings.Split(string(data), "\n")
-	if !serverIP.Is6() {
+	if ipv6 {
+		args = removeArgument(args, "pull-filter ignore \"ifconfig-ipv6\".*$")
+	} else {
 		args = addOrReplaceArgument(args, "pull-filter ignore \"ifconfig-ipv6\"", "pull-filter ignore \"ifconfig-ipv6\".*$")
 	}
 	args = addOrReplaceArgument(args, "pull-filter ignore \"route-ipv6\"", "pull-filter ignore \"route-ipv6\".*$")
@@ -192,11 +198,10 @@ func addExtraParameters(data []byte, serverIP netip.Addr, protocol config.Protoc
 		case config.Protocol_UNKNOWN_PROTOCOL:
 			fallthrough
 		default:
-			return errors.New("unknown protocol")
+			return nil, errors.New("unknown protocol")
 		}
 	}
-	data = []byte(strings.Join(args, "\n"))
-	return nil
+	return []byte(strings.Join(args, "\n")), nil
 }
 
 func addOrReplaceArgument(args []string, newArg string, regex string) []string {
@@ -213,3 +218,14 @@ func addOrReplaceArgument(args []string, newArg string, regex string) []string {
 	}
 	return args
 }
+
+func removeArgument(args []string, regex string) []string {
+	reg, _ := regexp.Compile(regex)
+	var kept []string
+	for _, arg := range args {
+		if !reg.MatchString(arg) {
+			kept = append(kept, arg)
+		}
+	}
+	return kept
+}
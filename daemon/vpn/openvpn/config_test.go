@@ -135,3 +135,50 @@ func TestGenerateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAddExtraParameters(t *testing.T) {
+	category.Set(t, category.Unit)
+	template := "client\npull-filter ignore \"ifconfig-ipv6\"\nproto udp"
+
+	tests := []struct {
+		name        string
+		ip          netip.Addr
+		ipv6        bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "ipv4 only",
+			ip:          netip.MustParseAddr("1.1.1.1"),
+			contains:    []string{`pull-filter ignore "ifconfig-ipv6"`, `pull-filter ignore "route-ipv6"`},
+			notContains: []string{"proto udp6"},
+		},
+		{
+			name:        "dual-stack over ipv4",
+			ip:          netip.MustParseAddr("1.1.1.1"),
+			ipv6:        true,
+			contains:    []string{`pull-filter ignore "route-ipv6"`},
+			notContains: []string{`pull-filter ignore "ifconfig-ipv6"`, "proto udp6"},
+		},
+		{
+			name:        "dual-stack over ipv6",
+			ip:          netip.MustParseAddr("2001:db8::1"),
+			ipv6:        true,
+			contains:    []string{`pull-filter ignore "route-ipv6"`, "proto udp6"},
+			notContains: []string{`pull-filter ignore "ifconfig-ipv6"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := addExtraParameters([]byte(template), tt.ip, tt.ipv6, config.Protocol_UDP)
+			assert.NoError(t, err)
+			for _, arg := range tt.contains {
+				assert.Contains(t, string(out), arg)
+			}
+			for _, arg := range tt.notContains {
+				assert.NotContains(t, string(out), arg)
+			}
+		})
+	}
+}
@@ -79,6 +79,7 @@ func (ovpn *OpenVPN) Start(
 	err := setOpenVPNConfig(
 		serverData.Protocol,
 		serverData.IP,
+		serverData.IPv6.IsValid(),
 		serverData.Obfuscated,
 		serverData.OpenVPNVersion,
 	)
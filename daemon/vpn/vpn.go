@@ -39,9 +39,10 @@ func (c Credentials) IsOpenVPNDefined() bool {
 // ServerData required to connect to VPN server.
 type ServerData struct {
 	IP                netip.Addr
-	Hostname          string // used in openvpn server certificate validation
-	Country           string // status display only
-	City              string // status display only
+	IPv6              netip.Addr // set if the tunnel is dual-stack
+	Hostname          string     // used in openvpn server certificate validation
+	Country           string     // status display only
+	City              string     // status display only
 	Protocol          config.Protocol
 	NordLynxPublicKey string
 	Obfuscated        bool
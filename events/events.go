@@ -80,8 +80,13 @@ type DataDisconnect struct {
 	ServerFromAPI         bool
 	Type                  TypeDisconnect
 	Technology            config.Technology
+	TargetServerDomain    string
 	TargetServerSelection string
 	ThreatProtectionLite  bool
+	Reason                string
+	Uptime                time.Duration
+	Download              uint64
+	Upload                uint64
 }
 
 type DataRequestAPI struct {
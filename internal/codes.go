@@ -48,4 +48,11 @@ const (
 	CodeAutoConnectServerNotObfuscated int64 = 3037
 	CodeAutoConnectServerObfuscated    int64 = 3038
 	CodeTokenInvalid                   int64 = 3039
+	CodePolicyEnforced                 int64 = 3040
+	CodeProfileNonexisting             int64 = 3041
+	CodeGlobalSetting                  int64 = 3042
+	CodeTrustedNetworkNonexisting      int64 = 3043
+	CodeNoNetwork                      int64 = 3044
+	CodeCaptivePortal                  int64 = 3045
+	CodeSettingsRollbackFailed         int64 = 3046
 )
@@ -5,6 +5,10 @@ const (
 	ReconnectSuccess  = "You have been reconnected to %s (%s)"
 	DisconnectSuccess = "You are disconnected from NordVPN."
 
+	PinnedServerOffline = "Pinned server %s is %s. Auto-connect will keep trying it until it comes back or the pin is removed."
+
+	CaptivePortalDetected = "This network requires you to log in at %s. Connection to VPN will continue once you are logged in."
+
 	ProtocolErrorMessage   = "protocol: failed to parse %s"
 	TechnologyErrorMessage = "technology: failed to parse %s"
 
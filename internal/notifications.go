@@ -1,7 +1,9 @@
 package internal
 
 const (
-	NotificationConnected    = 0000
-	NotificationReconnected  = 0001
-	NotificationDisconnected = 0002
+	NotificationConnected           = 0000
+	NotificationReconnected         = 0001
+	NotificationDisconnected        = 0002
+	NotificationPinnedServerOffline = 0003
+	NotificationCaptivePortal       = 0004
 )
@@ -4,6 +4,7 @@ package ipv6
 import (
 	"sync"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/kernel"
 )
 
@@ -20,9 +21,9 @@ type Ipv6 struct {
 
 const netIPv6KernelParameterName = "net.ipv6.conf.all.disable_ipv6"
 
-func NewIpv6() *Ipv6 {
+func NewIpv6(journal journal.Recorder) *Ipv6 {
 	return &Ipv6{
-		sysctlSetter: kernel.NewSysctlSetter(netIPv6KernelParameterName, 1, 0),
+		sysctlSetter: kernel.NewSysctlSetter(netIPv6KernelParameterName, 1, 0, journal),
 	}
 }
 
@@ -1,29 +1,48 @@
 package kernel
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+)
 
 type SysctlSetter struct {
 	paramName     string
 	desiredValue  int
 	unwantedValue int
 	changed       bool
+	journal       journal.Recorder
+	parameter     func(string) (map[string]int, error)
+	setParameter  func(string, int) error
+}
+
+// sysctlChange is stored in the journal, so that the unwanted value could be
+// restored after a crash
+type sysctlChange struct {
+	Desired  int `json:"desired"`
+	Unwanted int `json:"unwanted"`
 }
 
 func NewSysctlSetter(
 	paramName string,
 	desiredValue int,
 	unwantedValue int,
+	journal journal.Recorder,
 ) *SysctlSetter {
 	return &SysctlSetter{
 		paramName:     paramName,
 		desiredValue:  desiredValue,
 		unwantedValue: unwantedValue,
 		changed:       false,
+		journal:       journal,
+		parameter:     Parameter,
+		setParameter:  SetParameter,
 	}
 }
 
 func (s *SysctlSetter) Set() error {
-	values, err := Parameter(s.paramName)
+	values, err := s.parameter(s.paramName)
 	if err != nil {
 		return fmt.Errorf(
 			"retrieving the value of '%s': %w",
@@ -32,8 +51,13 @@ func (s *SysctlSetter) Set() error {
 		)
 	}
 	if values[s.paramName] == s.unwantedValue {
-		err := SetParameter(s.paramName, s.desiredValue)
+		s.journal.Record(journal.KindSysctl, s.paramName, sysctlChange{
+			Desired:  s.desiredValue,
+			Unwanted: s.unwantedValue,
+		})
+		err := s.setParameter(s.paramName, s.desiredValue)
 		if err != nil {
+			s.journal.Forget(journal.KindSysctl, s.paramName)
 			return fmt.Errorf(
 				"setting the value of '%s' to %d: %w",
 				s.paramName,
@@ -48,7 +72,7 @@ func (s *SysctlSetter) Set() error {
 
 func (s *SysctlSetter) Unset() error {
 	if s.changed {
-		err := SetParameter(s.paramName, s.unwantedValue)
+		err := s.setParameter(s.paramName, s.unwantedValue)
 		if err != nil {
 			return fmt.Errorf(
 				"setting the value of '%s' to '%d': %w",
@@ -58,6 +82,33 @@ func (s *SysctlSetter) Unset() error {
 			)
 		}
 		s.changed = false
+		s.journal.Forget(journal.KindSysctl, s.paramName)
 	}
 	return nil
 }
+
+// RevertSysctl restores the unwanted value of a journaled parameter unless
+// it was changed by someone else in the meantime.
+func RevertSysctl(paramName string, value json.RawMessage) error {
+	return revertSysctl(paramName, value, Parameter, SetParameter)
+}
+
+func revertSysctl(
+	paramName string,
+	value json.RawMessage,
+	parameter func(string) (map[string]int, error),
+	setParameter func(string, int) error,
+) error {
+	var change sysctlChange
+	if err := json.Unmarshal(value, &change); err != nil {
+		return fmt.Errorf("parsing journaled value of '%s': %w", paramName, err)
+	}
+	values, err := parameter(paramName)
+	if err != nil {
+		return fmt.Errorf("retrieving the value of '%s': %w", paramName, err)
+	}
+	if values[paramName] != change.Desired {
+		return nil
+	}
+	return setParameter(paramName, change.Unwanted)
+}
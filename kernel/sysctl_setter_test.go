@@ -1,12 +1,17 @@
 package kernel
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSet_rp_filter(t *testing.T) {
@@ -21,7 +26,7 @@ func TestSet_rp_filter(t *testing.T) {
 	desiredVal := 2
 	unwantedVal := 0
 
-	setter := NewSysctlSetter(param, desiredVal, unwantedVal)
+	setter := NewSysctlSetter(param, desiredVal, unwantedVal, &testjournal.Memory{})
 
 	err = setter.Set()
 	assert.NoError(t, err)
@@ -48,3 +53,92 @@ func TestSet_rp_filter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, paramVal[param], paramVal2[param])
 }
+
+type fakeSysctl struct {
+	values map[string]int
+	err    error
+}
+
+func (f *fakeSysctl) parameter(name string) (map[string]int, error) {
+	return map[string]int{name: f.values[name]}, nil
+}
+
+func (f *fakeSysctl) setParameter(name string, value int) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.values[name] = value
+	return nil
+}
+
+func newFakeSysctlSetter(sysctl *fakeSysctl, recorder journal.Recorder) *SysctlSetter {
+	setter := NewSysctlSetter("net.ipv4.ip_forward", 1, 0, recorder)
+	setter.parameter = sysctl.parameter
+	setter.setParameter = sysctl.setParameter
+	return setter
+}
+
+func TestSysctlSetter_Journal(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	sysctl := &fakeSysctl{values: map[string]int{"net.ipv4.ip_forward": 0}}
+	recorder := &testjournal.Memory{}
+	setter := newFakeSysctlSetter(sysctl, recorder)
+
+	require.NoError(t, setter.Set())
+	assert.Equal(t, 1, sysctl.values["net.ipv4.ip_forward"])
+	require.Len(t, recorder.Entries, 1)
+	assert.Equal(t, journal.KindSysctl, recorder.Entries[0].Kind)
+	assert.JSONEq(t, `{"desired":1,"unwanted":0}`, string(recorder.Entries[0].Value))
+
+	require.NoError(t, setter.Unset())
+	assert.Equal(t, 0, sysctl.values["net.ipv4.ip_forward"])
+	assert.Empty(t, recorder.Entries)
+}
+
+func TestSysctlSetter_JournalUnchanged(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	recorder := &testjournal.Memory{}
+	setter := newFakeSysctlSetter(
+		&fakeSysctl{values: map[string]int{"net.ipv4.ip_forward": 1}},
+		recorder,
+	)
+	require.NoError(t, setter.Set())
+	assert.Empty(t, recorder.Entries, "value which was not changed must not be journaled")
+
+	setter = newFakeSysctlSetter(
+		&fakeSysctl{values: map[string]int{"net.ipv4.ip_forward": 0}, err: errors.New("sysctl")},
+		recorder,
+	)
+	assert.Error(t, setter.Set())
+	assert.Empty(t, recorder.Entries, "failed change must not be journaled")
+}
+
+func TestRevertSysctl(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name     string
+		current  int
+		expected int
+	}{
+		{name: "restores unwanted value", current: 2, expected: 1},
+		{name: "keeps value changed by someone else", current: 0, expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			param := "net.ipv4.conf.all.rp_filter"
+			sysctl := &fakeSysctl{values: map[string]int{param: test.current}}
+			err := revertSysctl(
+				param,
+				json.RawMessage(`{"desired":2,"unwanted":1}`),
+				sysctl.parameter,
+				sysctl.setParameter,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sysctl.values[param])
+		})
+	}
+}
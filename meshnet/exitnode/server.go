@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/NordSecurity/nordvpn-linux/core/mesh"
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
 	"github.com/NordSecurity/nordvpn-linux/kernel"
 )
 
@@ -29,13 +30,14 @@ type Server struct {
 }
 
 // NewServer create & initialize new Server
-func NewServer(interfaceNames []string) Node {
+func NewServer(interfaceNames []string, journal journal.Recorder) Node {
 	return &Server{
 		interfaceNames: interfaceNames,
 		sysctlSetter: kernel.NewSysctlSetter(
 			ipv4fwdKernelParamName,
 			1,
 			0,
+			journal,
 		),
 	}
 }
@@ -118,11 +118,20 @@ func (s *Server) EnableMeshnet(ctx context.Context, _ *pb.Empty) (*pb.MeshnetRes
 		}, nil
 	}
 
+	// isolated tunnel is moved out of the host, where meshnet runs
+	if cfg.Isolation {
+		return &pb.MeshnetResponse{
+			Response: &pb.MeshnetResponse_MeshnetError{
+				MeshnetError: pb.MeshnetErrorCode_ISOLATION_ENABLED,
+			},
+		}, nil
+	}
+
 	token := cfg.TokensData[cfg.AutoConnectData.ID].Token
 	resp, err := s.reg.Map(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.MeshnetResponse{
 					Response: &pb.MeshnetResponse_ServiceError{
@@ -177,7 +186,7 @@ func (s *Server) EnableMeshnet(ctx context.Context, _ *pb.Empty) (*pb.MeshnetRes
 		}
 	}
 
-	if err = s.cm.SaveWith(func(c config.Config) config.Config {
+	if err = config.SaveWithContext(ctx, s.cm, func(c config.Config) config.Config {
 		c.Mesh = true
 		c.Meshnet.EnabledByUID = ucred.Uid
 		c.Meshnet.EnabledByGID = ucred.Gid
@@ -257,7 +266,7 @@ func (s *Server) StartMeshnet() error {
 	resp, err := s.reg.Map(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(context.Background(), s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				return err
 			}
 		}
@@ -282,7 +291,7 @@ func (s *Server) StartMeshnet() error {
 }
 
 // DisableMeshnet disconnects device from meshnet.
-func (s *Server) DisableMeshnet(context.Context, *pb.Empty) (*pb.MeshnetResponse, error) {
+func (s *Server) DisableMeshnet(ctx context.Context, _ *pb.Empty) (*pb.MeshnetResponse, error) {
 	var cfg config.Config
 	if err := s.cm.Load(&cfg); err != nil {
 		return &pb.MeshnetResponse{
@@ -316,7 +325,7 @@ func (s *Server) DisableMeshnet(context.Context, *pb.Empty) (*pb.MeshnetResponse
 		s.pub.Publish("unsetting mesh: " + err.Error())
 	}
 
-	if err := s.cm.SaveWith(func(c config.Config) config.Config {
+	if err := config.SaveWithContext(ctx, s.cm, func(c config.Config) config.Config {
 		c.Mesh = false
 		return c
 	}); err != nil {
@@ -335,7 +344,7 @@ func (s *Server) DisableMeshnet(context.Context, *pb.Empty) (*pb.MeshnetResponse
 }
 
 // RefreshMeshnet updates peer configuration.
-func (s *Server) RefreshMeshnet(context.Context, *pb.Empty) (*pb.MeshnetResponse, error) {
+func (s *Server) RefreshMeshnet(ctx context.Context, _ *pb.Empty) (*pb.MeshnetResponse, error) {
 	if !s.ac.IsLoggedIn() {
 		return &pb.MeshnetResponse{
 			Response: &pb.MeshnetResponse_ServiceError{
@@ -374,7 +383,7 @@ func (s *Server) RefreshMeshnet(context.Context, *pb.Empty) (*pb.MeshnetResponse
 	resp, err := s.reg.Map(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.MeshnetResponse{
 					Response: &pb.MeshnetResponse_ServiceError{
@@ -497,7 +506,7 @@ func (s *Server) Invite(
 			}, nil
 		}
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.InviteResponse{
 					Response: &pb.InviteResponse_ServiceErrorCode{
@@ -566,7 +575,7 @@ func (s *Server) AcceptInvite(
 	received, err := s.invitationAPI.Received(tokenData.Token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.RespondToInviteResponse{
 					Response: &pb.RespondToInviteResponse_ServiceErrorCode{
@@ -691,7 +700,7 @@ func (s *Server) DenyInvite(
 	received, err := s.invitationAPI.Received(tokenData.Token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.RespondToInviteResponse{
 					Response: &pb.RespondToInviteResponse_ServiceErrorCode{
@@ -782,7 +791,7 @@ func (s *Server) RevokeInvite(
 	sent, err := s.invitationAPI.Sent(tokenData.Token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.RespondToInviteResponse{
 					Response: &pb.RespondToInviteResponse_ServiceErrorCode{
@@ -831,7 +840,7 @@ func (s *Server) RevokeInvite(
 }
 
 // GetInvites from the API
-func (s *Server) GetInvites(context.Context, *pb.Empty) (*pb.GetInvitesResponse, error) {
+func (s *Server) GetInvites(ctx context.Context, _ *pb.Empty) (*pb.GetInvitesResponse, error) {
 	if !s.ac.IsLoggedIn() {
 		return &pb.GetInvitesResponse{
 			Response: &pb.GetInvitesResponse_ServiceErrorCode{
@@ -870,7 +879,7 @@ func (s *Server) GetInvites(context.Context, *pb.Empty) (*pb.GetInvitesResponse,
 	resp, err := s.invitationAPI.Received(tokenData.Token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.GetInvitesResponse{
 					Response: &pb.GetInvitesResponse_ServiceErrorCode{
@@ -932,7 +941,7 @@ func (s *Server) isMeshOn() bool {
 }
 
 // GetPeers returns a list of this machine meshnet peers
-func (s *Server) GetPeers(context.Context, *pb.Empty) (*pb.GetPeersResponse, error) {
+func (s *Server) GetPeers(ctx context.Context, _ *pb.Empty) (*pb.GetPeersResponse, error) {
 	if !s.ac.IsLoggedIn() {
 		return &pb.GetPeersResponse{
 			Response: &pb.GetPeersResponse_ServiceErrorCode{
@@ -966,7 +975,7 @@ func (s *Server) GetPeers(context.Context, *pb.Empty) (*pb.GetPeersResponse, err
 		resp, err := s.reg.Local(token)
 		if err != nil {
 			if errors.Is(err, core.ErrUnauthorized) {
-				if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+				if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 					s.pub.Publish(err.Error())
 					return &pb.GetPeersResponse{
 						Response: &pb.GetPeersResponse_ServiceErrorCode{
@@ -1007,7 +1016,7 @@ func (s *Server) GetPeers(context.Context, *pb.Empty) (*pb.GetPeersResponse, err
 		resp, err := s.reg.List(token, cfg.MeshDevice.ID)
 		if err != nil {
 			if errors.Is(err, core.ErrUnauthorized) {
-				if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+				if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 					s.pub.Publish(err.Error())
 					return &pb.GetPeersResponse{
 						Response: &pb.GetPeersResponse_ServiceErrorCode{
@@ -1099,7 +1108,7 @@ func (s *Server) RemovePeer(
 		resp, err := s.reg.Local(token)
 		if err != nil {
 			if errors.Is(err, core.ErrUnauthorized) {
-				if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+				if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 					s.pub.Publish(err.Error())
 					return &pb.RemovePeerResponse{
 						Response: &pb.RemovePeerResponse_ServiceErrorCode{
@@ -1171,7 +1180,7 @@ func (s *Server) RemovePeer(
 	resp, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.RemovePeerResponse{
 					Response: &pb.RemovePeerResponse_ServiceErrorCode{
@@ -1288,7 +1297,7 @@ func (s *Server) AllowIncoming(
 	resp, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.AllowIncomingResponse{
 					Response: &pb.AllowIncomingResponse_ServiceErrorCode{
@@ -1402,7 +1411,7 @@ func (s *Server) DenyIncoming(
 	resp, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.DenyIncomingResponse{
 					Response: &pb.DenyIncomingResponse_ServiceErrorCode{
@@ -1509,7 +1518,7 @@ func (s *Server) AllowRouting(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.AllowRoutingResponse{
 					Response: &pb.AllowRoutingResponse_ServiceErrorCode{
@@ -1625,7 +1634,7 @@ func (s *Server) DenyRouting(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.DenyRoutingResponse{
 					Response: &pb.DenyRoutingResponse_ServiceErrorCode{
@@ -1741,7 +1750,7 @@ func (s *Server) AllowLocalNetwork(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.AllowLocalNetworkResponse{
 					Response: &pb.AllowLocalNetworkResponse_ServiceErrorCode{
@@ -1857,7 +1866,7 @@ func (s *Server) DenyLocalNetwork(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.DenyLocalNetworkResponse{
 					Response: &pb.DenyLocalNetworkResponse_ServiceErrorCode{
@@ -1973,7 +1982,7 @@ func (s *Server) AllowFileshare(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.AllowFileshareResponse{
 					Response: &pb.AllowFileshareResponse_ServiceErrorCode{
@@ -2089,7 +2098,7 @@ func (s *Server) DenyFileshare(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.DenyFileshareResponse{
 					Response: &pb.DenyFileshareResponse_ServiceErrorCode{
@@ -2212,7 +2221,7 @@ func (s *Server) NotifyNewTransfer(
 	peers, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				s.pub.Publish(err.Error())
 				return &pb.NotifyNewTransferResponse{
 					Response: &pb.NotifyNewTransferResponse_ServiceErrorCode{
@@ -2327,7 +2336,7 @@ func (s *Server) Connect(
 	resp, err := s.reg.List(token, cfg.MeshDevice.ID)
 	if err != nil {
 		if errors.Is(err, core.ErrUnauthorized) {
-			if err := s.cm.SaveWith(auth.Logout(cfg.AutoConnectData.ID)); err != nil {
+			if err := config.SaveWithContext(ctx, s.cm, auth.Logout(cfg.AutoConnectData.ID)); err != nil {
 				return &pb.ConnectResponse{
 					Response: &pb.ConnectResponse_ServiceErrorCode{
 						ServiceErrorCode: pb.ServiceErrorCode_CONFIG_FAILURE,
@@ -207,6 +207,21 @@ func TestServer_EnableMeshnet(t *testing.T) {
 			fileshare: failingFileshare{},
 			success:   true, // Fileshare shouldn't impact meshnet enabling
 		},
+		{
+			name: "isolation enabled",
+			netw: workingNetworker{},
+			ac:   meshRenewChecker{},
+			inv:  invitationsAPI{},
+			rc:   registrationChecker{},
+			reg:  registryAPI{},
+			cm: &memory{cfg: &config.Config{
+				Technology: config.Technology_NORDLYNX,
+				Isolation:  true,
+			}},
+			dns:       dnsGetter{},
+			fileshare: mock.Fileshare{},
+			success:   false,
+		},
 	}
 
 	for _, test := range tests {
@@ -3,6 +3,7 @@ package networker
 import (
 	"log"
 
+	"github.com/NordSecurity/nordvpn-linux/config"
 	"github.com/NordSecurity/nordvpn-linux/internal"
 )
 
@@ -13,8 +14,10 @@ func (c *Combined) Reconnect(stateIsUp bool) {
 
 	isStarted := c.isVpnSet
 	ipv6Enabled := c.ipv6Enabled
+	// host IPv6 is not blocked for the tunnel in the namespace
+	isolated := c.isIsolated || c.isIsolationEnabled
 
-	if isStarted {
+	if isStarted && !isolated {
 		if !ipv6Enabled {
 			if err := c.denyIPv6(); err != nil {
 				log.Println(internal.ErrorPrefix, "refreshing network", err)
@@ -23,6 +26,15 @@ func (c *Combined) Reconnect(stateIsUp bool) {
 	}
 
 	if stateIsUp {
+		if c.reconnectPolicy == config.ReconnectPolicyFast {
+			changed, err := c.changeNetwork()
+			if err != nil {
+				log.Println(internal.WarningPrefix, "changing network, falling back to reconnect:", err)
+			}
+			if changed {
+				return
+			}
+		}
 		if err := c.refreshVPN(); err != nil {
 			log.Println(internal.ErrorPrefix, "refreshing vpn", err)
 		}
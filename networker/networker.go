@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math"
 	"net"
 	"net/netip"
 	"strconv"
@@ -20,6 +19,7 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/daemon/device"
 	"github.com/NordSecurity/nordvpn-linux/daemon/dns"
 	"github.com/NordSecurity/nordvpn-linux/daemon/firewall"
+	"github.com/NordSecurity/nordvpn-linux/daemon/isolation"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
 	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
 	"github.com/NordSecurity/nordvpn-linux/events"
@@ -28,6 +28,7 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/meshnet"
 	"github.com/NordSecurity/nordvpn-linux/meshnet/exitnode"
 	"github.com/NordSecurity/nordvpn-linux/slices"
+	"github.com/NordSecurity/nordvpn-linux/tunnel"
 
 	"github.com/kofalt/go-memoize"
 )
@@ -42,7 +43,12 @@ var (
 	ErrMeshPeerIsNotRoutable = errors.New("mesh peer is not routable")
 	// ErrMeshPeerNotFound to report to outside
 	ErrMeshPeerNotFound = errors.New("mesh peer not found")
-	defaultMeshSubnet   = netip.MustParsePrefix("100.64.0.0/10")
+	// ErrIsolationWithMeshnet to report to outside
+	ErrIsolationWithMeshnet  = errors.New("isolation cannot be used together with meshnet")
+	errIsolationNotSupported = errors.New("isolation is not supported")
+	defaultMeshSubnet        = netip.MustParsePrefix("100.64.0.0/10")
+	defaultIPv4Route         = netip.MustParsePrefix("0.0.0.0/0")
+	defaultIPv6Route         = netip.MustParsePrefix("::/0")
 )
 
 // ConnectionStatus of a currently active connection
@@ -98,6 +104,21 @@ type Networker interface {
 	DenyIPv6() error
 	SetVPN(vpn.VPN)
 	LastServerName() string
+	// AllowCaptivePortal lets the user log in to the captive portal hosts while
+	// the kill switch is on
+	AllowCaptivePortal(hosts []netip.Addr, nameservers []netip.Addr) error
+	// BlockCaptivePortal closes the window opened by AllowCaptivePortal
+	BlockCaptivePortal() error
+	// SetIsolation decides whether the next connections are isolated in a
+	// dedicated network namespace instead of changing the host network
+	SetIsolation(enabled bool)
+	// SetLanDiscovery allows traffic to the local subnets together with mDNS
+	// and SSDP. Replaces previously allowed subnets.
+	SetLanDiscovery(subnets []netip.Prefix) error
+	// UnsetLanDiscovery removes what was allowed by SetLanDiscovery
+	UnsetLanDiscovery() error
+	// SetReconnectPolicy decides how connections are refreshed by Reconnect
+	SetReconnectPolicy(policy config.ReconnectPolicy)
 }
 
 // Combined configures networking for VPN connections.
@@ -109,6 +130,7 @@ type Combined struct {
 	mesh               meshnet.Mesh
 	gateway            routes.GatewayRetriever
 	publisher          events.Publisher[string]
+	disconnects        events.Publisher[events.DataDisconnect]
 	whitelistRouter    routes.Service
 	dnsSetter          dns.Setter
 	ipv6               ipv6.Blocker
@@ -119,15 +141,20 @@ type Combined struct {
 	router             routes.Service
 	peerRouter         routes.Service
 	exitNode           exitnode.Node
+	isolator           isolation.Isolator
 	isNetworkSet       bool // used during cleanup
 	isKillSwitchSet    bool // used during cleanup
 	isV6TrafficAllowed bool // used during cleanup
 	isVpnSet           bool // used during cleanup
 	isMeshnetSet       bool
+	isIsolationEnabled bool
+	isIsolated         bool // used during cleanup
+	reconnectPolicy    config.ReconnectPolicy
 	rules              []string // firewall rule names
 	nextVPN            vpn.VPN
 	cfg                mesh.MachineMap
 	whitelist          config.Whitelist
+	whitelistRules     []string
 	lastServer         vpn.ServerData
 	lastCreds          vpn.Credentials
 	startTime          *time.Time
@@ -145,6 +172,7 @@ func NewCombined(
 	mesh meshnet.Mesh,
 	gateway routes.GatewayRetriever,
 	publisher events.Publisher[string],
+	disconnects events.Publisher[events.DataDisconnect],
 	whitelistRouter routes.Service,
 	dnsSetter dns.Setter,
 	ipv6 ipv6.Blocker,
@@ -155,6 +183,7 @@ func NewCombined(
 	router routes.Service,
 	peerRouter routes.Service,
 	exitNode exitnode.Node,
+	isolator isolation.Isolator,
 	fwmark uint32,
 ) *Combined {
 	return &Combined{
@@ -162,6 +191,7 @@ func NewCombined(
 		mesh:            mesh,
 		gateway:         gateway,
 		publisher:       publisher,
+		disconnects:     disconnects,
 		whitelistRouter: whitelistRouter,
 		dnsSetter:       dnsSetter,
 		ipv6:            ipv6,
@@ -172,6 +202,7 @@ func NewCombined(
 		router:          router,
 		peerRouter:      peerRouter,
 		exitNode:        exitNode,
+		isolator:        isolator,
 		rules:           []string{},
 		fwmark:          fwmark,
 	}
@@ -187,7 +218,17 @@ func (netw *Combined) Start(
 	netw.mu.Lock()
 	defer netw.mu.Unlock()
 	if netw.isConnectedToVPN() {
-		return netw.restart(creds, serverData, nameservers)
+		if !netw.isIsolated && !netw.isIsolationEnabled {
+			return netw.restart(creds, serverData, nameservers)
+		}
+		// tunnel cannot be restarted in place while it is in the namespace
+		// and switching between isolated and host connections needs a clean start
+		if err := netw.stop(); err != nil {
+			return err
+		}
+	}
+	if netw.isIsolationEnabled {
+		return netw.startIsolated(creds, serverData, nameservers)
 	}
 	return netw.start(creds, serverData, whitelist, nameservers)
 }
@@ -213,12 +254,6 @@ func failureRecover(netw *Combined) {
 			log.Println(internal.DeferPrefix, err)
 		}
 	}
-
-	if netw.isV6TrafficAllowed {
-		if err := netw.stopAllowedIPv6Traffic(); err != nil {
-			log.Println(internal.DebugPrefix, err)
-		}
-	}
 	netw.isVpnSet = false
 }
 
@@ -257,7 +292,7 @@ func (netw *Combined) start(
 		netw.publisher.Publish("Setting the routing rules up")
 		err = netw.policyRouter.SetupRoutingRules(
 			netw.vpnet.Tun().Interface(),
-			serverData.IP.Is6(),
+			routesIPv6(serverData),
 		)
 
 		if err != nil {
@@ -278,14 +313,8 @@ func (netw *Combined) start(
 		return err
 	}
 
-	err = netw.router.Add(routes.Route{
-		Subnet:  netip.MustParsePrefix("0.0.0.0/0"),
-		Device:  netw.vpnet.Tun().Interface(),
-		TableID: netw.policyRouter.TableID(),
-	})
-
-	if err != nil {
-		return fmt.Errorf("adding the default route: %w", err)
+	if err = netw.addDefaultRoutes(serverData); err != nil {
+		return err
 	}
 
 	dnsGetter := &dns.NameServers{}
@@ -356,14 +385,24 @@ func (netw *Combined) restart(
 		return err
 	}
 
+	// routing rules have to be set up for IPv6 as well when switching to
+	// a dual-stack server and vice versa
+	if !netw.isMeshnetSet && routesIPv6(serverData) != routesIPv6(netw.lastServer) {
+		if err := netw.policyRouter.CleanupRouting(); err != nil {
+			log.Println(internal.WarningPrefix, err)
+		}
+		if err = netw.policyRouter.SetupRoutingRules(
+			netw.vpnet.Tun().Interface(),
+			routesIPv6(serverData),
+		); err != nil {
+			return err
+		}
+	}
+
 	// after restarting need to restore routing - because tun interface was recreated
 	// assuming all other routing rules are left as it was before restart
-	if err = netw.router.Add(routes.Route{
-		Subnet:  netip.MustParsePrefix("0.0.0.0/0"),
-		Device:  netw.vpnet.Tun().Interface(),
-		TableID: netw.policyRouter.TableID(),
-	}); err != nil {
-		return fmt.Errorf("adding the default route: %w", err)
+	if err = netw.addDefaultRoutes(serverData); err != nil {
+		return err
 	}
 
 	dnsGetter := &dns.NameServers{}
@@ -383,6 +422,117 @@ func (netw *Combined) restart(
 	return nil
 }
 
+// startIsolated starts the VPN connection and moves the tunnel into the
+// isolated network namespace. Host routing, DNS and firewall are left untouched.
+func (netw *Combined) startIsolated(
+	creds vpn.Credentials,
+	serverData vpn.ServerData,
+	nameservers config.DNS,
+) error {
+	if netw.vpnet == nil {
+		return errNilVPN
+	}
+	if netw.isolator == nil {
+		return errIsolationNotSupported
+	}
+	if netw.isMeshnetSet {
+		return ErrIsolationWithMeshnet
+	}
+
+	netw.publisher.Publish("starting isolated vpn")
+
+	if serverData.IP == (netip.Addr{}) {
+		serverData = netw.lastServer
+	}
+	if err := netw.vpnet.Start(creds, serverData); err != nil {
+		if err := netw.vpnet.Stop(); err != nil {
+			log.Println(internal.DeferPrefix, err)
+		}
+		return err
+	}
+
+	tun := netw.vpnet.Tun()
+	if err := netw.isolator.Isolate(tun.Interface(), tun.IPs(), nameservers); err != nil {
+		if err := netw.vpnet.Stop(); err != nil {
+			log.Println(internal.DeferPrefix, err)
+		}
+		return fmt.Errorf("isolating vpn interface: %w", err)
+	}
+
+	netw.isIsolated = true
+	netw.isVpnSet = true
+	netw.lastServer = serverData
+	netw.lastCreds = creds
+	netw.lastNameservers = nameservers
+	start := time.Now()
+	netw.startTime = &start
+	return nil
+}
+
+// stopIsolated moves the tunnel back to the host before stopping it, so that
+// the VPN implementation could clean it up
+func (netw *Combined) stopIsolated() error {
+	netw.publisher.Publish("releasing isolated vpn interface")
+	if err := netw.isolator.Release(); err != nil {
+		log.Println(internal.WarningPrefix, err)
+	}
+	netw.isIsolated = false
+
+	netw.publisher.Publish("stopping vpn")
+	if err := netw.vpnet.Stop(); err != nil {
+		return err
+	}
+	netw.switchToNextVpn()
+	netw.isVpnSet = false
+	return nil
+}
+
+// SetIsolation takes effect on the next connection
+func (netw *Combined) SetIsolation(enabled bool) {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+	netw.isIsolationEnabled = enabled
+}
+
+// SetReconnectPolicy takes effect on the next network change
+func (netw *Combined) SetReconnectPolicy(policy config.ReconnectPolicy) {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+	netw.reconnectPolicy = policy
+}
+
+// routesIPv6 reports whether IPv6 routing rules are needed for the server
+func routesIPv6(serverData vpn.ServerData) bool {
+	return serverData.IP.Is6() || serverData.IPv6.IsValid()
+}
+
+// addDefaultRoutes routes all traffic through the tunnel. IPv6 traffic is
+// routed and permitted only if the tunnel is dual-stack, otherwise it is left
+// for the caller to deny.
+func (netw *Combined) addDefaultRoutes(serverData vpn.ServerData) error {
+	subnets := []netip.Prefix{defaultIPv4Route}
+	if serverData.IPv6.IsValid() {
+		subnets = append(subnets, defaultIPv6Route)
+	}
+	for _, subnet := range subnets {
+		if err := netw.router.Add(routes.Route{
+			Subnet:  subnet,
+			Device:  netw.vpnet.Tun().Interface(),
+			TableID: netw.policyRouter.TableID(),
+		}); err != nil {
+			return fmt.Errorf("adding the default route: %w", err)
+		}
+	}
+
+	if serverData.IPv6.IsValid() {
+		netw.ipv6Enabled = true
+		if err := netw.ipv6.Unblock(); err != nil {
+			return fmt.Errorf("permitting ipv6: %w", err)
+		}
+	}
+	return nil
+}
+
 // Stop VPN connection and clean up network after it stopped.
 func (netw *Combined) Stop() error {
 	netw.mu.Lock()
@@ -400,6 +550,9 @@ func (netw *Combined) stop() error {
 	if netw.vpnet == nil {
 		return errNilVPN
 	}
+	if netw.isIsolated {
+		return netw.stopIsolated()
+	}
 	netw.publisher.Publish("stopping network configuration")
 	if err := netw.ipv6.Unblock(); err != nil {
 		log.Println(internal.WarningPrefix, err)
@@ -452,7 +605,7 @@ func (netw *Combined) ConnectionStatus() (ConnectionStatus, error) {
 		return ConnectionStatus{}, errInactiveVPN
 	}
 
-	stats, err := netw.vpnet.Tun().TransferRates()
+	stats, err := netw.transferRates()
 	if err != nil {
 		return ConnectionStatus{}, err
 	}
@@ -482,6 +635,16 @@ func (netw *Combined) ConnectionStatus() (ConnectionStatus, error) {
 	}, nil
 }
 
+// transferRates of the tunnel, which is in the namespace if it is isolated
+//
+// Thread unsafe.
+func (netw *Combined) transferRates() (tunnel.Statistics, error) {
+	if netw.isIsolated {
+		return netw.isolator.TransferRates()
+	}
+	return netw.vpnet.Tun().TransferRates()
+}
+
 // LastServerName returns last used server hostname
 func (netw *Combined) LastServerName() string {
 	return netw.lastServer.Hostname
@@ -498,6 +661,9 @@ func (netw *Combined) SetDNS(nameservers []string) error {
 }
 
 func (netw *Combined) setDNS(nameservers []string) error {
+	if netw.isIsolated {
+		return netw.isolator.SetDNS(nameservers)
+	}
 	err := netw.dnsSetter.Set(netw.vpnet.Tun().Interface().Name, nameservers)
 	if err != nil {
 		return fmt.Errorf("networker setting dns: %w", err)
@@ -516,6 +682,10 @@ func (netw *Combined) UnsetDNS() error {
 }
 
 func (netw *Combined) unsetDNS() error {
+	if netw.isIsolated {
+		// removed together with the namespace
+		return nil
+	}
 	err := netw.dnsSetter.Unset(netw.vpnet.Tun().Interface().Name)
 	if err != nil {
 		return fmt.Errorf("networker unsetting dns: %w", err)
@@ -787,7 +957,6 @@ func (netw *Combined) setWhitelist(whitelist config.Whitelist) error {
 	}
 
 	rules := []firewall.Rule{}
-	var subnets []netip.Prefix
 
 	cache := memoize.NewMemoizer(30*time.Second, 1*time.Minute)
 	type cachedGateway struct {
@@ -795,16 +964,23 @@ func (netw *Combined) setWhitelist(whitelist config.Whitelist) error {
 		defaultInterface net.Interface
 	}
 
-	for cidr := range whitelist.Subnets {
-		subnet, err := netip.ParsePrefix(cidr)
+	for i, entry := range whitelist.Entries {
+		rule := whitelistRule(entry, ifaces)
+		rule.Name = whitelistRuleName(i)
+		rules = append(rules, rule)
+		if entry.Subnet == "" {
+			continue
+		}
+
+		subnet, err := netip.ParsePrefix(entry.Subnet)
 		if err != nil {
 			// TODO: after Go 1.20, rewrite using error joining
 			return fmt.Errorf("parsing subnet CIDR: %w", err)
 		}
+		rules[len(rules)-1].RemoteNetworks = []netip.Prefix{subnet}
 
-		// for private network we add only firewall exception
-		if subnet.Addr().IsPrivate() {
-			subnets = append(subnets, subnet)
+		// for private and IPv6 link-local network we add only firewall exception
+		if subnet.Addr().IsPrivate() || subnet.Addr().IsLinkLocalUnicast() {
 			continue
 		}
 
@@ -816,7 +992,6 @@ func (netw *Combined) setWhitelist(whitelist config.Whitelist) error {
 
 		if err != nil {
 			// if gateway does not exist, we still honour users choice
-			subnets = append(subnets, subnet)
 			log.Println(internal.WarningPrefix, "whitelisting routes gateway not found for", subnet.String(), err)
 			continue
 		}
@@ -836,45 +1011,13 @@ func (netw *Combined) setWhitelist(whitelist config.Whitelist) error {
 			// TODO: after Go 1.20, rewrite using error joining
 			return fmt.Errorf("adding route for subnet %s: %w", route.Subnet, err)
 		}
-
-		subnets = append(subnets, subnet)
-	}
-	if subnets != nil {
-		rules = append(rules, firewall.Rule{
-			Name:           "whitelist_subnets",
-			Interfaces:     ifaces,
-			RemoteNetworks: subnets,
-			Direction:      firewall.TwoWay,
-			Allow:          true,
-		})
 	}
 
-	for _, pair := range []struct {
-		name  string
-		ports map[int64]bool
-	}{
-		{name: "tcp", ports: whitelist.Ports.TCP},
-		{name: "udp", ports: whitelist.Ports.UDP},
-	} {
-		var ports []int
-		for port := range pair.ports {
-			if port > math.MaxUint16 {
-				continue
-			}
-			ports = append(ports, int(port))
-		}
-		if ports != nil {
-			rules = append(rules, firewall.Rule{
-				Name:       "whitelist_ports_" + pair.name,
-				Interfaces: ifaces,
-				Protocols:  []string{pair.name},
-				Direction:  firewall.TwoWay,
-				Ports:      ports,
-				Allow:      true,
-			})
-		}
+	// names are stored beforehand, as missing rules are ignored on removal
+	netw.whitelistRules = make([]string, 0, len(rules))
+	for _, rule := range rules {
+		netw.whitelistRules = append(netw.whitelistRules, rule.Name)
 	}
-
 	if err := netw.fw.Add(rules); err != nil {
 		return err
 	}
@@ -882,6 +1025,42 @@ func (netw *Combined) setWhitelist(whitelist config.Whitelist) error {
 	return nil
 }
 
+// whitelistRule allows traffic of a single whitelist entry. Remote
+// networks are filled in by the caller.
+func whitelistRule(entry config.WhitelistEntry, ifaces []net.Interface) firewall.Rule {
+	rule := firewall.Rule{
+		Interfaces: ifaces,
+		Direction:  firewall.TwoWay,
+		Allow:      true,
+	}
+	if entry.Interface != "" {
+		rule.Interfaces = []net.Interface{{Name: entry.Interface}}
+	}
+	switch entry.Direction {
+	case config.WhitelistDirectionInbound:
+		rule.Direction = firewall.Inbound
+	case config.WhitelistDirectionOutbound:
+		rule.Direction = firewall.Outbound
+	}
+	switch entry.Protocol {
+	case config.WhitelistProtocolTCP, config.WhitelistProtocolUDP:
+		rule.Protocols = []string{entry.Protocol}
+	default:
+		// ports can be matched only together with a protocol
+		if entry.Ports != nil {
+			rule.Protocols = []string{config.WhitelistProtocolTCP, config.WhitelistProtocolUDP}
+		}
+	}
+	if entry.Ports != nil {
+		rule.PortRanges = []firewall.PortRange{{Min: int(entry.Ports.Min), Max: int(entry.Ports.Max)}}
+	}
+	return rule
+}
+
+func whitelistRuleName(index int) string {
+	return fmt.Sprintf("whitelist_%d", index)
+}
+
 func (netw *Combined) UnsetWhitelist() error {
 	netw.mu.Lock()
 	defer netw.mu.Unlock()
@@ -893,17 +1072,14 @@ func (netw *Combined) unsetWhitelist() error {
 		return fmt.Errorf("flushing the whitelist router: %w", err)
 	}
 
-	for _, rule := range []string{
-		"whitelist_subnets",
-		"whitelist_ports_tcp",
-		"whitelist_ports_udp",
-	} {
-		err := netw.fw.Delete([]string{rule})
+	for _, name := range netw.whitelistRules {
+		err := netw.fw.Delete([]string{name})
 		if err != nil && !errors.Is(err, firewall.ErrRuleNotFound) {
 			// TODO: after Go 1.20, rewrite using error joining
 			return err
 		}
 	}
+	netw.whitelistRules = nil
 	return nil
 }
 
@@ -940,6 +1116,12 @@ func (netw *Combined) setNetwork(whitelist config.Whitelist) error {
 		return err
 	}
 
+	// unlike ARP, neighbor discovery is filtered, so it has to be allowed
+	// for IPv6 to keep working on the physical interfaces
+	if err := netw.allowIPv6Traffic(); err != nil && !errors.Is(err, firewall.ErrRuleAlreadyExists) {
+		return err
+	}
+
 	netw.isNetworkSet = true
 	return nil
 }
@@ -958,10 +1140,175 @@ func (netw *Combined) unsetNetwork() error {
 		return err
 	}
 
+	if netw.isV6TrafficAllowed {
+		if err := netw.stopAllowedIPv6Traffic(); err != nil {
+			return err
+		}
+	}
+
 	netw.isNetworkSet = false
 	return nil
 }
 
+const (
+	// captivePortalRule is the name of the firewall rule opened for the
+	// duration of captive portal login
+	captivePortalRule = "captive_portal"
+	// captivePortalDNSRule allows resolving the portal and the pages it loads
+	captivePortalDNSRule = "captive_portal_dns"
+)
+
+// AllowCaptivePortal allows web traffic to the portal hosts and DNS traffic to
+// the nameservers outside of the tunnel, which is enough for the user to open
+// the login page of the captive portal.
+func (netw *Combined) AllowCaptivePortal(hosts []netip.Addr, nameservers []netip.Addr) error {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+
+	ifaces, err := netw.devices()
+	if err != nil {
+		return err
+	}
+
+	// rule without remote networks would allow everything
+	var rules []firewall.Rule
+	if len(hosts) > 0 {
+		rules = append(rules, firewall.Rule{
+			Name:           captivePortalRule,
+			Interfaces:     ifaces,
+			RemoteNetworks: hostPrefixes(hosts),
+			Protocols:      []string{config.WhitelistProtocolTCP},
+			Ports:          []int{80, 443},
+			Direction:      firewall.TwoWay,
+			Allow:          true,
+		})
+	}
+	if len(nameservers) > 0 {
+		rules = append(rules, firewall.Rule{
+			Name:           captivePortalDNSRule,
+			Interfaces:     ifaces,
+			RemoteNetworks: hostPrefixes(nameservers),
+			Protocols:      []string{config.WhitelistProtocolTCP, config.WhitelistProtocolUDP},
+			Ports:          []int{53},
+			Direction:      firewall.TwoWay,
+			Allow:          true,
+		})
+	}
+	for _, rule := range rules {
+		err := netw.fw.Add([]firewall.Rule{rule})
+		if err != nil && !errors.Is(err, firewall.ErrRuleAlreadyExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (netw *Combined) BlockCaptivePortal() error {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+
+	for _, name := range []string{captivePortalRule, captivePortalDNSRule} {
+		err := netw.fw.Delete([]string{name})
+		if err != nil && !errors.Is(err, firewall.ErrRuleNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// hostPrefixes converts addresses to single host prefixes.
+func hostPrefixes(addrs []netip.Addr) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(addrs))
+	for _, addr := range addrs {
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes
+}
+
+const (
+	// lanDiscoveryRule allows traffic to the local private and link-local subnets
+	lanDiscoveryRule = "lan_discovery"
+	// lanDiscoveryMDNSRule allows multicast DNS queries
+	lanDiscoveryMDNSRule = "lan_discovery_mdns"
+	// lanDiscoverySSDPRule allows UPnP discovery queries
+	lanDiscoverySSDPRule = "lan_discovery_ssdp"
+)
+
+var (
+	mDNSGroups = []netip.Prefix{
+		netip.MustParsePrefix("224.0.0.251/32"),
+		netip.MustParsePrefix("ff02::fb/128"),
+	}
+	ssdpGroups = []netip.Prefix{
+		netip.MustParsePrefix("239.255.255.250/32"),
+		netip.MustParsePrefix("ff02::c/128"),
+	}
+)
+
+// SetLanDiscovery allows traffic to the local subnets. Answers to multicast
+// queries are sent from the local subnets, so only the queries themselves
+// need separate rules.
+func (netw *Combined) SetLanDiscovery(subnets []netip.Prefix) error {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+
+	ifaces, err := netw.devices()
+	if err != nil {
+		return err
+	}
+	if err := netw.unsetLanDiscovery(); err != nil {
+		return err
+	}
+
+	rules := []firewall.Rule{
+		{
+			Name:           lanDiscoveryMDNSRule,
+			Interfaces:     ifaces,
+			RemoteNetworks: mDNSGroups,
+			Protocols:      []string{config.WhitelistProtocolUDP},
+			Ports:          []int{5353},
+			Direction:      firewall.TwoWay,
+			Allow:          true,
+		},
+		{
+			Name:           lanDiscoverySSDPRule,
+			Interfaces:     ifaces,
+			RemoteNetworks: ssdpGroups,
+			Protocols:      []string{config.WhitelistProtocolUDP},
+			Ports:          []int{1900},
+			Direction:      firewall.TwoWay,
+			Allow:          true,
+		},
+	}
+	// rule without remote networks would allow everything
+	if len(subnets) > 0 {
+		rules = append(rules, firewall.Rule{
+			Name:           lanDiscoveryRule,
+			Interfaces:     ifaces,
+			RemoteNetworks: subnets,
+			Direction:      firewall.TwoWay,
+			Allow:          true,
+		})
+	}
+	return netw.fw.Add(rules)
+}
+
+func (netw *Combined) UnsetLanDiscovery() error {
+	netw.mu.Lock()
+	defer netw.mu.Unlock()
+	return netw.unsetLanDiscovery()
+}
+
+func (netw *Combined) unsetLanDiscovery() error {
+	for _, name := range []string{lanDiscoveryRule, lanDiscoveryMDNSRule, lanDiscoverySSDPRule} {
+		err := netw.fw.Delete([]string{name})
+		if err != nil && !errors.Is(err, firewall.ErrRuleNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (netw *Combined) SetKillSwitch(whitelist config.Whitelist) error {
 	netw.mu.Lock()
 	defer netw.mu.Unlock()
@@ -13,6 +13,7 @@ import (
 	"github.com/NordSecurity/nordvpn-linux/daemon/firewall"
 	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
 	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
+	"github.com/NordSecurity/nordvpn-linux/events"
 	"github.com/NordSecurity/nordvpn-linux/events/subs"
 	"github.com/NordSecurity/nordvpn-linux/meshnet"
 	"github.com/NordSecurity/nordvpn-linux/test/category"
@@ -224,6 +225,7 @@ func TestCombined_Start(t *testing.T) {
 				nil,
 				test.gateway,
 				&subs.Subject[string]{},
+				nil,
 				test.whitelistRouter,
 				test.dns,
 				&workingIpv6{},
@@ -234,6 +236,7 @@ func TestCombined_Start(t *testing.T) {
 				workingRouter{},
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			err := netw.Start(
@@ -247,6 +250,310 @@ func TestCombined_Start(t *testing.T) {
 	}
 }
 
+type recordingRouter struct {
+	workingRouter
+	routes []routes.Route
+}
+
+func (r *recordingRouter) Add(route routes.Route) error {
+	r.routes = append(r.routes, route)
+	return nil
+}
+
+type recordingRoutingSetup struct {
+	workingRoutingSetup
+	ipv6 bool
+}
+
+func (r *recordingRoutingSetup) SetupRoutingRules(_ net.Interface, ipv6 bool) error {
+	r.ipv6 = ipv6
+	return nil
+}
+
+type recordingIpv6 struct{ unblocked bool }
+
+func (r *recordingIpv6) Block() error   { r.unblocked = false; return nil }
+func (r *recordingIpv6) Unblock() error { r.unblocked = true; return nil }
+
+func TestCombined_StartDualStack(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name       string
+		serverData vpn.ServerData
+		subnets    []netip.Prefix
+		ipv6       bool
+	}{
+		{
+			name:       "ipv4 only",
+			serverData: vpn.ServerData{IP: netip.MustParseAddr("1.1.1.1")},
+			subnets:    []netip.Prefix{defaultIPv4Route},
+		},
+		{
+			name: "dual-stack over ipv4",
+			serverData: vpn.ServerData{
+				IP:   netip.MustParseAddr("1.1.1.1"),
+				IPv6: netip.MustParseAddr("2001:db8::1"),
+			},
+			subnets: []netip.Prefix{defaultIPv4Route, defaultIPv6Route},
+			ipv6:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := &recordingRouter{}
+			routing := &recordingRoutingSetup{}
+			blocker := &recordingIpv6{}
+			netw := NewCombined(
+				testvpn.WorkingInactive{},
+				nil,
+				workingGateway{},
+				&subs.Subject[string]{},
+				nil,
+				workingRouter{},
+				workingDNS{},
+				blocker,
+				workingFirewall{},
+				workingDeviceList,
+				routing,
+				nil,
+				router,
+				nil,
+				nil,
+				nil,
+				0,
+			)
+			err := netw.Start(
+				vpn.Credentials{},
+				test.serverData,
+				config.NewWhitelist(nil, nil, []string{"2001:db8:1::/48"}),
+				[]string{"1.1.1.1"},
+			)
+			assert.NoError(t, err)
+
+			var subnets []netip.Prefix
+			for _, route := range router.routes {
+				subnets = append(subnets, route.Subnet)
+			}
+			assert.Equal(t, test.subnets, subnets)
+			assert.Equal(t, test.ipv6, routing.ipv6)
+			assert.Equal(t, test.ipv6, blocker.unblocked)
+			assert.True(t, netw.isV6TrafficAllowed, "neighbor discovery must be allowed")
+		})
+	}
+}
+
+type recordingIsolator struct {
+	isolated    bool
+	nameservers []string
+	releases    int
+}
+
+func (r *recordingIsolator) Isolate(_ net.Interface, _ []netip.Addr, nameservers []string) error {
+	r.isolated = true
+	r.nameservers = nameservers
+	return nil
+}
+
+func (r *recordingIsolator) Release() error {
+	r.isolated = false
+	r.releases++
+	return nil
+}
+
+func (r *recordingIsolator) SetDNS(nameservers []string) error {
+	r.nameservers = nameservers
+	return nil
+}
+
+func (r *recordingIsolator) TransferRates() (tunnel.Statistics, error) {
+	return tunnel.Statistics{Tx: 1, Rx: 2}, nil
+}
+
+func TestCombined_StartIsolated(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	isolator := &recordingIsolator{}
+	// host network must not be touched, so every host facing dependency fails
+	netw := NewCombined(
+		testvpn.WorkingInactive{},
+		nil,
+		workingGateway{},
+		&subs.Subject[string]{},
+		nil,
+		failingRouter{},
+		failingDNS{},
+		&workingIpv6{},
+		failingFirewall{},
+		failingDeviceList,
+		nil,
+		nil,
+		failingRouter{},
+		nil,
+		nil,
+		isolator,
+		0,
+	)
+	netw.SetIsolation(true)
+
+	err := netw.Start(
+		vpn.Credentials{},
+		vpn.ServerData{},
+		config.NewWhitelist(nil, nil, nil),
+		[]string{"1.1.1.1"},
+	)
+	assert.NoError(t, err)
+	assert.True(t, isolator.isolated)
+	assert.Equal(t, []string{"1.1.1.1"}, isolator.nameservers)
+
+	assert.NoError(t, netw.setDNS([]string{"8.8.8.8"}))
+	assert.Equal(t, []string{"8.8.8.8"}, isolator.nameservers)
+
+	netw.vpnet = testvpn.Working{}
+	status, err := netw.ConnectionStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), status.Upload)
+	assert.Equal(t, uint64(2), status.Download)
+
+	// reconnecting moves the new tunnel into a fresh namespace
+	err = netw.Start(
+		vpn.Credentials{},
+		vpn.ServerData{},
+		config.NewWhitelist(nil, nil, nil),
+		[]string{"1.1.1.1"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, isolator.releases)
+	assert.True(t, isolator.isolated)
+
+	assert.NoError(t, netw.Stop())
+	assert.Equal(t, 2, isolator.releases)
+	assert.False(t, isolator.isolated)
+	assert.False(t, netw.isIsolated)
+}
+
+func TestCombined_ReconnectIsolated(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	isolator := &recordingIsolator{}
+	// host network must not be touched, so every host facing dependency fails
+	netw := NewCombined(
+		testvpn.WorkingInactive{},
+		nil,
+		workingGateway{},
+		&subs.Subject[string]{},
+		nil,
+		failingRouter{},
+		failingDNS{},
+		&workingIpv6{},
+		failingFirewall{},
+		failingDeviceList,
+		nil,
+		nil,
+		failingRouter{},
+		nil,
+		nil,
+		isolator,
+		0,
+	)
+	netw.SetIsolation(true)
+
+	err := netw.Start(
+		vpn.Credentials{},
+		vpn.ServerData{IP: netip.MustParseAddr("1.2.3.4")},
+		config.NewWhitelist(nil, nil, nil),
+		[]string{"1.1.1.1"},
+	)
+	assert.NoError(t, err)
+	netw.vpnet = testvpn.Working{}
+
+	// tunnel is re-created in a fresh namespace instead of on the host
+	netw.Reconnect(true)
+	assert.Equal(t, 1, isolator.releases)
+	assert.True(t, isolator.isolated)
+	assert.True(t, netw.isIsolated)
+	assert.True(t, netw.isVpnSet)
+	assert.False(t, netw.isKillSwitchSet)
+	assert.False(t, netw.isNetworkSet)
+	assert.Equal(t, []string{"1.1.1.1"}, isolator.nameservers)
+}
+
+// namedFirewall stores rules by their names
+type namedFirewall struct {
+	workingFirewall
+	rules map[string]firewall.Rule
+}
+
+func (f *namedFirewall) Add(rules []firewall.Rule) error {
+	for _, rule := range rules {
+		if _, ok := f.rules[rule.Name]; ok {
+			return firewall.ErrRuleAlreadyExists
+		}
+		f.rules[rule.Name] = rule
+	}
+	return nil
+}
+
+func (f *namedFirewall) Delete(names []string) error {
+	for _, name := range names {
+		if _, ok := f.rules[name]; !ok {
+			return firewall.ErrRuleNotFound
+		}
+		delete(f.rules, name)
+	}
+	return nil
+}
+
+func TestCombined_AllowCaptivePortal(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	fw := &namedFirewall{rules: map[string]firewall.Rule{}}
+	netw := Combined{fw: fw, devices: workingDeviceList}
+	portal := netip.MustParseAddr("192.168.1.1")
+	nameserver := netip.MustParseAddr("192.168.1.2")
+
+	assert.NoError(t, netw.AllowCaptivePortal([]netip.Addr{portal}, []netip.Addr{nameserver}))
+	assert.Len(t, fw.rules, 2)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.168.1.1/32")}, fw.rules[captivePortalRule].RemoteNetworks)
+	assert.Equal(t, []int{80, 443}, fw.rules[captivePortalRule].Ports)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.168.1.2/32")}, fw.rules[captivePortalDNSRule].RemoteNetworks)
+	assert.Equal(t, []int{53}, fw.rules[captivePortalDNSRule].Ports)
+
+	assert.NoError(t, netw.BlockCaptivePortal())
+	assert.Empty(t, fw.rules)
+
+	// nothing is allowed without known hosts
+	assert.NoError(t, netw.AllowCaptivePortal(nil, nil))
+	assert.Empty(t, fw.rules)
+}
+
+func TestCombined_SetLanDiscovery(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	fw := &namedFirewall{rules: map[string]firewall.Rule{}}
+	netw := Combined{fw: fw, devices: workingDeviceList}
+	subnets := []netip.Prefix{
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("fe80::/64"),
+	}
+
+	assert.NoError(t, netw.SetLanDiscovery(subnets))
+	assert.Len(t, fw.rules, 3)
+	assert.Equal(t, subnets, fw.rules[lanDiscoveryRule].RemoteNetworks)
+	assert.Equal(t, mDNSGroups, fw.rules[lanDiscoveryMDNSRule].RemoteNetworks)
+	assert.Equal(t, ssdpGroups, fw.rules[lanDiscoverySSDPRule].RemoteNetworks)
+
+	// subnets are replaced after the network change
+	assert.NoError(t, netw.SetLanDiscovery(nil))
+	assert.Len(t, fw.rules, 2)
+	assert.NotContains(t, fw.rules, lanDiscoveryRule, "rule without subnets would allow everything")
+
+	assert.NoError(t, netw.UnsetLanDiscovery())
+	assert.Empty(t, fw.rules)
+	assert.NoError(t, netw.UnsetLanDiscovery())
+}
+
 func TestCombined_Stop(t *testing.T) {
 	category.Set(t, category.Link)
 
@@ -289,6 +596,7 @@ func TestCombined_Stop(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				workingRouter{},
 				test.dns,
 				&workingIpv6{},
@@ -299,6 +607,7 @@ func TestCombined_Stop(t *testing.T) {
 				workingRouter{},
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			netw.vpnet = test.vpn
@@ -308,6 +617,97 @@ func TestCombined_Stop(t *testing.T) {
 	}
 }
 
+func TestCombined_ReconnectPublishesDisconnect(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	tests := []struct {
+		name        string
+		vpn         vpn.VPN
+		dns         dns.Setter
+		typ         events.TypeDisconnect
+		reason      string
+		transferred uint64
+	}{
+		{
+			name:        "network change",
+			vpn:         testvpn.Working{},
+			dns:         workingDNS{},
+			typ:         events.DisconnectSuccess,
+			reason:      DisconnectReasonNetworkChange,
+			transferred: 1337,
+		},
+		{
+			name:   "connection lost",
+			vpn:    testvpn.WorkingInactive{},
+			dns:    workingDNS{},
+			typ:    events.DisconnectFailure,
+			reason: DisconnectReasonConnectionLost,
+		},
+		{
+			name:        "reconnect failed",
+			vpn:         testvpn.Working{},
+			dns:         failingDNS{},
+			typ:         events.DisconnectFailure,
+			reason:      DisconnectReasonReconnectFailed,
+			transferred: 1337,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var published []events.DataDisconnect
+			disconnects := &subs.Subject[events.DataDisconnect]{}
+			disconnects.Subscribe(func(data events.DataDisconnect) error {
+				published = append(published, data)
+				return nil
+			})
+			netw := NewCombined(
+				testvpn.WorkingInactive{},
+				nil,
+				workingGateway{},
+				&subs.Subject[string]{},
+				disconnects,
+				workingRouter{},
+				workingDNS{},
+				&workingIpv6{},
+				workingFirewall{},
+				workingDeviceList,
+				workingRoutingSetup{},
+				nil,
+				workingRouter{},
+				nil,
+				nil,
+				nil,
+				0,
+			)
+			assert.NoError(t, netw.Start(
+				vpn.Credentials{},
+				vpn.ServerData{
+					IP:                netip.MustParseAddr("1.2.3.4"),
+					Hostname:          "lt16.nordvpn.com",
+					NordLynxPublicKey: "key",
+				},
+				config.NewWhitelist(nil, nil, nil),
+				[]string{"1.1.1.1"},
+			))
+			netw.vpnet = test.vpn
+			netw.dnsSetter = test.dns
+
+			netw.Reconnect(true)
+			assert.Len(t, published, 1)
+			if len(published) > 0 {
+				assert.Equal(t, test.typ, published[0].Type)
+				assert.Equal(t, test.reason, published[0].Reason)
+				assert.Equal(t, "lt16.nordvpn.com", published[0].TargetServerDomain)
+				assert.Equal(t, config.Technology_NORDLYNX, published[0].Technology)
+				// statistics of a lost tunnel are gone
+				assert.Equal(t, test.transferred, published[0].Upload)
+				assert.Equal(t, test.transferred, published[0].Download)
+			}
+		})
+	}
+}
+
 func TestCombined_TransferRates(t *testing.T) {
 	category.Set(t, category.Unit)
 
@@ -337,7 +737,7 @@ func TestCombined_TransferRates(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			// Test does not rely on any of the values provided via constructor
 			// so it's fine to pass nils to all of them.
-			netw := NewCombined(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
+			netw := NewCombined(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
 			// injecting VPN implementation without calling netw.Start
 			netw.vpnet = test.vpn
 			connStus, err := netw.ConnectionStatus()
@@ -390,6 +790,7 @@ func TestCombined_SetDNS(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				workingRouter{},
 				test.dns,
 				&workingIpv6{},
@@ -400,6 +801,7 @@ func TestCombined_SetDNS(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			netw.vpnet = testvpn.Working{}
@@ -436,6 +838,7 @@ func TestCombined_UnsetDNS(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				workingRouter{},
 				test.dns,
 				&workingIpv6{},
@@ -446,6 +849,7 @@ func TestCombined_UnsetDNS(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			netw.vpnet = testvpn.Working{}
@@ -494,6 +898,7 @@ func TestCombined_ResetWhitelist(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				workingRouter{},
 				workingDNS{},
 				workingIpv6{},
@@ -504,6 +909,7 @@ func TestCombined_ResetWhitelist(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.resetWhitelist(), test.err)
@@ -555,6 +961,7 @@ func TestCombined_BlockTraffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				test.devices,
 				test.routing,
@@ -562,6 +969,7 @@ func TestCombined_BlockTraffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.blockTraffic(), test.err)
@@ -600,6 +1008,7 @@ func TestCombined_UnblockTraffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -607,6 +1016,7 @@ func TestCombined_UnblockTraffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.unblockTraffic(), test.err)
@@ -658,6 +1068,7 @@ func TestCombined_AllowIPv6Traffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				test.devices,
 				test.routing,
@@ -665,6 +1076,7 @@ func TestCombined_AllowIPv6Traffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.allowIPv6Traffic(), test.err)
@@ -703,6 +1115,7 @@ func TestCombined_StopAllowedIPv6Traffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -710,6 +1123,7 @@ func TestCombined_StopAllowedIPv6Traffic(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.stopAllowedIPv6Traffic(), test.err)
@@ -789,6 +1203,7 @@ func TestCombined_SetWhitelist(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -799,6 +1214,7 @@ func TestCombined_SetWhitelist(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.setWhitelist(test.whitelist), test.err)
@@ -841,6 +1257,7 @@ func TestCombined_UnsetWhitelist(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -851,14 +1268,99 @@ func TestCombined_UnsetWhitelist(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
+			// rules of the previously set whitelist are removed
+			netw.whitelistRules = []string{whitelistRuleName(0), whitelistRuleName(1)}
 			err := netw.unsetWhitelist()
 			assert.ErrorIs(t, err, test.err)
 		})
 	}
 }
 
+func TestCombined_UnsetWhitelistRemovesSetRules(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	fw := &namedFirewall{rules: map[string]firewall.Rule{}}
+	netw := Combined{fw: fw, whitelistRouter: workingRouter{}}
+	assert.NoError(t, fw.Add([]firewall.Rule{
+		{Name: whitelistRuleName(0)},
+		{Name: whitelistRuleName(1)},
+		{Name: "other"},
+	}))
+	netw.whitelistRules = []string{whitelistRuleName(0), whitelistRuleName(1)}
+
+	// whitelist in memory no longer matches the rules
+	netw.whitelist = config.Whitelist{}
+	assert.NoError(t, netw.unsetWhitelist())
+	assert.Len(t, fw.rules, 1)
+	assert.Contains(t, fw.rules, "other")
+	assert.Empty(t, netw.whitelistRules)
+}
+
+func TestWhitelistRule(t *testing.T) {
+	category.Set(t, category.Unit)
+
+	ifaces := []net.Interface{{Name: "eth0"}, {Name: "wlan0"}}
+	tests := []struct {
+		name     string
+		entry    config.WhitelistEntry
+		expected firewall.Rule
+	}{
+		{
+			name: "port range for both protocols",
+			entry: config.WhitelistEntry{
+				Ports:     config.NewPortRange(3000, 3100),
+				Protocol:  config.WhitelistProtocolAny,
+				Direction: config.WhitelistDirectionBoth,
+			},
+			expected: firewall.Rule{
+				Interfaces: ifaces,
+				Protocols:  []string{"tcp", "udp"},
+				PortRanges: []firewall.PortRange{{Min: 3000, Max: 3100}},
+				Direction:  firewall.TwoWay,
+				Allow:      true,
+			},
+		},
+		{
+			name: "inbound port on an interface",
+			entry: config.WhitelistEntry{
+				Ports:     config.NewPortRange(22, 22),
+				Protocol:  config.WhitelistProtocolTCP,
+				Direction: config.WhitelistDirectionInbound,
+				Interface: "eth0",
+			},
+			expected: firewall.Rule{
+				Interfaces: []net.Interface{{Name: "eth0"}},
+				Protocols:  []string{"tcp"},
+				PortRanges: []firewall.PortRange{{Min: 22, Max: 22}},
+				Direction:  firewall.Inbound,
+				Allow:      true,
+			},
+		},
+		{
+			name: "outbound subnet",
+			entry: config.WhitelistEntry{
+				Subnet:    "10.0.0.0/8",
+				Protocol:  config.WhitelistProtocolAny,
+				Direction: config.WhitelistDirectionOutbound,
+			},
+			expected: firewall.Rule{
+				Interfaces: ifaces,
+				Direction:  firewall.Outbound,
+				Allow:      true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, whitelistRule(test.entry, ifaces))
+		})
+	}
+}
+
 func TestCombined_SetNetwork(t *testing.T) {
 	category.Set(t, category.Unit)
 
@@ -913,6 +1415,7 @@ func TestCombined_SetNetwork(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -923,6 +1426,7 @@ func TestCombined_SetNetwork(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			err := netw.setNetwork(
@@ -971,6 +1475,7 @@ func TestCombined_UnsetNetwork(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -981,6 +1486,7 @@ func TestCombined_UnsetNetwork(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, netw.unsetNetwork(), test.err)
@@ -1033,6 +1539,7 @@ func TestCombined_AllowIncoming(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -1043,6 +1550,7 @@ func TestCombined_AllowIncoming(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			uniqueAddress := meshnet.UniqueAddress{UID: test.publicKey, Address: netip.MustParseAddr(test.address)}
@@ -1097,6 +1605,7 @@ func TestCombined_BlockIncoming(t *testing.T) {
 				nil,
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -1107,6 +1616,7 @@ func TestCombined_BlockIncoming(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			uniqueAddress := meshnet.UniqueAddress{UID: test.publicKey, Address: netip.MustParseAddr(test.address)}
@@ -1145,6 +1655,7 @@ func TestCombined_SetMesh(t *testing.T) {
 				workingMesh{},
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -1155,6 +1666,7 @@ func TestCombined_SetMesh(t *testing.T) {
 				workingRouter{},
 				workingRouter{},
 				workingExitNode{},
+				nil,
 				0,
 			)
 			assert.ErrorIs(t, test.err, netw.SetMesh(
@@ -1193,6 +1705,7 @@ func TestCombined_UnSetMesh(t *testing.T) {
 				workingMesh{},
 				workingGateway{},
 				&subs.Subject[string]{},
+				nil,
 				test.rt,
 				&workingDNS{},
 				&workingIpv6{},
@@ -1203,6 +1716,7 @@ func TestCombined_UnSetMesh(t *testing.T) {
 				workingRouter{},
 				workingRouter{},
 				workingExitNode{},
+				nil,
 				0,
 			)
 			netw.isMeshnetSet = true
@@ -1248,6 +1762,7 @@ func TestCombined_allow(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -1255,6 +1770,7 @@ func TestCombined_allow(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			netw.allow(test.name, netip.MustParseAddr(test.address))
@@ -1300,6 +1816,7 @@ func TestCombined_Block(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -1307,6 +1824,7 @@ func TestCombined_Block(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			netw.allow(test.name, netip.MustParseAddr(test.address))
@@ -1354,6 +1872,7 @@ func TestCombined_allowGeneratedRule(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -1361,6 +1880,7 @@ func TestCombined_allowGeneratedRule(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			err := netw.allow(test.name, netip.MustParseAddr(test.address))
@@ -1395,6 +1915,7 @@ func TestCombined_BlocNonExistingRuleFail(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -1402,6 +1923,7 @@ func TestCombined_BlocNonExistingRuleFail(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			// Should fail to block rule non existing
@@ -1437,6 +1959,7 @@ func TestCombined_allowExistingRuleFail(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				test.fw,
 				nil,
 				nil,
@@ -1444,6 +1967,7 @@ func TestCombined_allowExistingRuleFail(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				0,
 			)
 			err := netw.allow(test.name, netip.MustParseAddr(test.address))
@@ -2,7 +2,26 @@ package networker
 
 import (
 	"fmt"
+	"log"
 	"net/netip"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
+	"github.com/NordSecurity/nordvpn-linux/events"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+)
+
+const (
+	// DisconnectReasonNetworkChange is published when the tunnel is
+	// re-created after the network has changed
+	DisconnectReasonNetworkChange = "network change"
+	// DisconnectReasonConnectionLost is published when the tunnel is found
+	// down while it should be running
+	DisconnectReasonConnectionLost = "connection lost"
+	// DisconnectReasonReconnectFailed is published when the tunnel could not
+	// be re-created
+	DisconnectReasonReconnectFailed = "reconnect failed"
 )
 
 // IsVPNActive returns true when connection to VPN server is established.
@@ -21,14 +40,16 @@ func (netw *Combined) IsVPNActive() bool {
 // therefore, full tunnel must be re-created
 //
 // Thread unsafe.
-func (netw *Combined) refreshVPN() error {
+func (netw *Combined) refreshVPN() (err error) {
 	meshnetSet := netw.isMeshnetSet
 	started := netw.isVpnSet
 	killswitch := netw.isKillSwitchSet
+	// host network is not touched while the tunnel is in the namespace
+	isolated := netw.isIsolated || netw.isIsolationEnabled
 	var ip netip.Addr
 
 	if started {
-		if !killswitch {
+		if !killswitch && !isolated {
 			if err := netw.setKillSwitch(netw.whitelist); err != nil {
 				return fmt.Errorf("setting killswitch: %w", err)
 			}
@@ -38,6 +59,19 @@ func (netw *Combined) refreshVPN() error {
 			ip = netw.vpnet.Tun().IPs()[0]
 		}
 
+		disconnect := netw.disconnectData(DisconnectReasonNetworkChange)
+		if !netw.isConnectedToVPN() {
+			disconnect.Type = events.DisconnectFailure
+			disconnect.Reason = DisconnectReasonConnectionLost
+		}
+		defer func() {
+			if err != nil {
+				disconnect.Type = events.DisconnectFailure
+				disconnect.Reason = DisconnectReasonReconnectFailed
+			}
+			netw.publishDisconnect(disconnect)
+		}()
+
 		if err := netw.stop(); err != nil {
 			return fmt.Errorf("stopping networker: %w", err)
 		}
@@ -57,6 +91,17 @@ func (netw *Combined) refreshVPN() error {
 		}
 	}
 
+	if started && isolated {
+		if err := netw.startIsolated(
+			netw.lastCreds,
+			netw.lastServer,
+			netw.lastNameservers,
+		); err != nil {
+			return fmt.Errorf("starting isolated networker: %w", err)
+		}
+		return nil
+	}
+
 	if started {
 		if err := netw.start(
 			netw.lastCreds,
@@ -77,6 +122,73 @@ func (netw *Combined) refreshVPN() error {
 	return nil
 }
 
+// disconnectData describes the running connection before it is stopped
+//
+// Thread unsafe.
+func (netw *Combined) disconnectData(reason string) events.DataDisconnect {
+	data := events.DataDisconnect{
+		Protocol:           netw.lastServer.Protocol,
+		Type:               events.DisconnectSuccess,
+		Technology:         config.Technology_OPENVPN,
+		TargetServerDomain: netw.lastServer.Hostname,
+		Reason:             reason,
+	}
+	if netw.lastServer.NordLynxPublicKey != "" {
+		data.Technology = config.Technology_NORDLYNX
+	}
+	if netw.startTime != nil {
+		data.Uptime = time.Since(*netw.startTime)
+	}
+	if netw.isConnectedToVPN() {
+		if stats, err := netw.transferRates(); err == nil {
+			data.Download, data.Upload = stats.Rx, stats.Tx
+		}
+	}
+	return data
+}
+
+func (netw *Combined) publishDisconnect(data events.DataDisconnect) {
+	if netw.disconnects == nil {
+		return
+	}
+	log.Println(internal.InfoPrefix, "connection to", data.TargetServerDomain, "ended:", data.Reason)
+	netw.disconnects.Publish(data)
+}
+
+// changeNetwork lets the running tunnels roam to the new network without
+// re-creating them. Returns false if it is not supported by any of them, so
+// that the caller could fall back to refreshVPN.
+//
+// Thread unsafe.
+func (netw *Combined) changeNetwork() (bool, error) {
+	var changers []vpn.NetworkChanger
+	if netw.isVpnSet {
+		changer, ok := netw.vpnet.(vpn.NetworkChanger)
+		if !ok {
+			return false, nil
+		}
+		changers = append(changers, changer)
+	}
+	// meshnet and VPN can be handled by the same implementation
+	if netw.isMeshnetSet && (!netw.isVpnSet || any(netw.mesh) != any(netw.vpnet)) {
+		changer, ok := netw.mesh.(vpn.NetworkChanger)
+		if !ok {
+			return false, nil
+		}
+		changers = append(changers, changer)
+	}
+	if len(changers) == 0 {
+		return false, nil
+	}
+
+	for _, changer := range changers {
+		if err := changer.NetworkChange(); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // Thread unsafe.
 func (netw *Combined) isConnectedToVPN() bool {
 	if netw.vpnet == nil || netw.vpnet.Tun() == nil {
@@ -1,9 +1,13 @@
 package networker
 
 import (
+	"net/netip"
 	"testing"
 
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/core/mesh"
 	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
+	"github.com/NordSecurity/nordvpn-linux/test/errors"
 	testtunnel "github.com/NordSecurity/nordvpn-linux/test/tunnel"
 	"github.com/NordSecurity/nordvpn-linux/tunnel"
 
@@ -78,6 +82,8 @@ func TestVPNNetworker_IsVPNActive(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 				0,
 			)
 			// injecting VPN implementation without calling netw.Start
@@ -86,3 +92,104 @@ func TestVPNNetworker_IsVPNActive(t *testing.T) {
 		})
 	}
 }
+
+// roamingTunnel is both VPN and meshnet which supports network changes
+type roamingTunnel struct {
+	activeVPN
+	changes int
+	err     error
+}
+
+func (r *roamingTunnel) NetworkChange() error {
+	r.changes++
+	return r.err
+}
+func (*roamingTunnel) Enable(netip.Addr, string) error       { return nil }
+func (*roamingTunnel) Disable() error                        { return nil }
+func (*roamingTunnel) Refresh(mesh.MachineMap) error         { return nil }
+func (*roamingTunnel) StatusMap() (map[string]string, error) { return nil, nil }
+
+func TestCombined_changeNetwork(t *testing.T) {
+	tests := []struct {
+		name       string
+		vpn        func(*roamingTunnel) vpn.VPN
+		vpnSet     bool
+		meshnetSet bool
+		err        error
+		changed    bool
+		changes    int
+	}{
+		{
+			name:    "vpn supports network changes",
+			vpn:     func(r *roamingTunnel) vpn.VPN { return r },
+			vpnSet:  true,
+			changed: true,
+			changes: 1,
+		},
+		{
+			name:   "vpn does not support network changes",
+			vpn:    func(*roamingTunnel) vpn.VPN { return activeVPN{} },
+			vpnSet: true,
+		},
+		{
+			name:       "vpn and meshnet are the same tunnel",
+			vpn:        func(r *roamingTunnel) vpn.VPN { return r },
+			vpnSet:     true,
+			meshnetSet: true,
+			changed:    true,
+			changes:    1,
+		},
+		{
+			name:       "only meshnet is set",
+			vpn:        func(*roamingTunnel) vpn.VPN { return activeVPN{} },
+			meshnetSet: true,
+			changed:    true,
+			changes:    1,
+		},
+		{
+			name:   "nothing is set",
+			vpn:    func(r *roamingTunnel) vpn.VPN { return r },
+			vpnSet: false,
+		},
+		{
+			name:    "network change fails",
+			vpn:     func(r *roamingTunnel) vpn.VPN { return r },
+			vpnSet:  true,
+			err:     errors.ErrOnPurpose,
+			changes: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tunnel := &roamingTunnel{err: test.err}
+			netw := &Combined{
+				vpnet:        test.vpn(tunnel),
+				mesh:         tunnel,
+				isVpnSet:     test.vpnSet,
+				isMeshnetSet: test.meshnetSet,
+			}
+			changed, err := netw.changeNetwork()
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.changed, changed)
+			assert.Equal(t, test.changes, tunnel.changes)
+		})
+	}
+}
+
+func TestCombined_ReconnectFast(t *testing.T) {
+	tunnel := &roamingTunnel{}
+	netw := &Combined{
+		vpnet:           tunnel,
+		isVpnSet:        true,
+		ipv6Enabled:     true,
+		reconnectPolicy: config.ReconnectPolicyFast,
+	}
+	netw.Reconnect(true)
+	assert.Equal(t, 1, tunnel.changes)
+	// tunnel is not re-created
+	assert.True(t, netw.isVpnSet)
+
+	netw.Reconnect(false)
+	assert.Equal(t, 1, tunnel.changes)
+}
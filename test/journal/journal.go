@@ -0,0 +1,41 @@
+package journal
+
+import (
+	"encoding/json"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/journal"
+)
+
+// Memory is a journal.Recorder which keeps entries in memory
+type Memory struct {
+	Entries []journal.Entry
+}
+
+func (m *Memory) Record(kind journal.Kind, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	m.Forget(kind, key)
+	m.Entries = append(m.Entries, journal.Entry{Kind: kind, Key: key, Value: data})
+}
+
+func (m *Memory) Forget(kind journal.Kind, key string) {
+	for i, entry := range m.Entries {
+		if entry.Kind == kind && entry.Key == key {
+			m.Entries = append(m.Entries[:i], m.Entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Keys returns keys of entries with a given kind
+func (m *Memory) Keys(kind journal.Kind) []string {
+	var keys []string
+	for _, entry := range m.Entries {
+		if entry.Kind == kind {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,533 @@
+/*
+Package leak provides an environment for integration tests checking that no
+traffic escapes the VPN tunnel.
+
+Tests run in dedicated network and mount namespaces, so that the host network
+is never changed. The namespace of the test process plays the client. It is
+connected by a veth pair to the server namespace, which runs a stand-in
+WireGuard server and a target host reachable both through the tunnel and
+directly. Every packet sent by the client over the veth pair is captured, so
+that the traffic bypassing the tunnel can be detected.
+*/
+package leak
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/tun"
+)
+
+const (
+	// Underlay is the client side of the link to the server
+	Underlay = "nordtest0"
+	// peer is the server side of Underlay
+	peer = "nordtest1"
+	// serverTunnel is the WireGuard interface of the server
+	serverTunnel = "nordtestwg"
+	// serverPort is the same as used by NordLynx
+	serverPort = 51820
+	// targetPort receives the traffic generated by StartTraffic
+	targetPort = 9999
+
+	envIsolated = "NORDVPN_LEAK_TEST_ISOLATED"
+)
+
+var (
+	// ServerIP is the endpoint of the server on the underlay
+	ServerIP = netip.MustParseAddr("192.0.2.1")
+	// TargetIP is reachable both through the tunnel and directly
+	TargetIP = netip.MustParseAddr("198.51.100.1")
+	// TunnelIP is the address assigned to the NordLynx interface
+	TunnelIP = netip.MustParseAddr("10.5.0.2")
+
+	serverTunnelIP = netip.MustParsePrefix("10.5.0.1/16")
+	// clientIPs are used in the order of the network switches
+	clientIPs = []netip.Addr{
+		netip.MustParseAddr("192.0.2.2"),
+		netip.MustParseAddr("192.0.2.3"),
+	}
+)
+
+// Main runs the tests of the package in new network and mount namespaces. It
+// has to be called from TestMain.
+func Main(m *testing.M) int {
+	if os.Getenv(envIsolated) != "" {
+		if err := setUpMounts(); err != nil {
+			fmt.Fprintln(os.Stderr, "setting up mounts:", err)
+			return 1
+		}
+		return m.Run()
+	}
+	if os.Geteuid() != 0 {
+		// namespaces cannot be created, so the tests are skipped by Setup
+		return m.Run()
+	}
+
+	// namespaces are created for the whole process, because network
+	// namespace of a thread is not inherited by the threads started by Go
+	// runtime
+	// #nosec G204 -- test binary is executed again with the same arguments
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), envIsolated+"=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Cloneflags: syscall.CLONE_NEWNET | syscall.CLONE_NEWNS}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintln(os.Stderr, "running tests in a new network namespace:", err)
+		return 1
+	}
+	return 0
+}
+
+// setUpMounts keeps runtime files, such as WireGuard UAPI sockets, inside of
+// the mount namespace
+func setUpMounts() error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+	if err := unix.Mount("tmpfs", "/run", "tmpfs", 0, ""); err != nil {
+		return fmt.Errorf("mounting /run: %w", err)
+	}
+	return nil
+}
+
+// Env is the client and server topology. Client is the network namespace of
+// the test process.
+type Env struct {
+	// PrivateKey of the client to be used in vpn.Credentials
+	PrivateKey string
+	// ServerData of the stand-in server
+	ServerData vpn.ServerData
+	handle     *netlink.Handle
+	server     netns.NsHandle
+	clientIP   int
+	capture    *capture
+	// tunneled is the number of datagrams received by the target through the tunnel
+	tunneled atomic.Int64
+}
+
+// Setup creates the topology which is removed after the test. Test is skipped
+// if it is not run in the namespaces created by Main.
+func Setup(t *testing.T) *Env {
+	t.Helper()
+	if os.Getenv(envIsolated) == "" {
+		t.Skip("test must be run as root, so that it could be isolated by leak.Main")
+	}
+
+	handle, err := netlink.NewHandle()
+	require.NoError(t, err)
+	t.Cleanup(handle.Delete)
+
+	server := newNamespace(t)
+	serverHandle, err := netlink.NewHandleAt(server)
+	require.NoError(t, err)
+	t.Cleanup(serverHandle.Delete)
+
+	require.NoError(t, handle.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: Underlay},
+		PeerName:  peer,
+	}))
+	t.Cleanup(func() {
+		// peer is removed together with it
+		if link, err := handle.LinkByName(Underlay); err == nil {
+			_ = handle.LinkDel(link)
+		}
+	})
+	link, err := handle.LinkByName(peer)
+	require.NoError(t, err)
+	require.NoError(t, handle.LinkSetNsFd(link, int(server)))
+
+	env := &Env{handle: handle, server: server}
+	setUp(t, handle, "lo")
+	env.setUpUnderlay(t)
+	setUp(t, serverHandle, "lo", prefix(TargetIP))
+	setUp(t, serverHandle, peer, netip.PrefixFrom(ServerIP, 24))
+
+	clientPrivate, clientPublic := newKeys(t)
+	serverPrivate, serverPublic := newKeys(t)
+	env.PrivateKey = base64.StdEncoding.EncodeToString(clientPrivate)
+	env.ServerData = vpn.ServerData{
+		IP:                ServerIP,
+		NordLynxPublicKey: base64.StdEncoding.EncodeToString(serverPublic),
+	}
+
+	inNamespace(t, server, func() {
+		env.startServer(t, serverPrivate, clientPublic)
+		env.startTarget(t)
+		env.capture = newCapture(t, peer)
+	})
+	setUp(t, serverHandle, serverTunnel, serverTunnelIP)
+	return env
+}
+
+// setUpUnderlay assigns the current client address and the default route
+func (e *Env) setUpUnderlay(t *testing.T) {
+	t.Helper()
+	setUp(t, e.handle, Underlay, netip.PrefixFrom(clientIPs[e.clientIP], 24))
+	link, err := e.handle.LinkByName(Underlay)
+	require.NoError(t, err)
+	require.NoError(t, e.handle.RouteAdd(&netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Gw:        ServerIP.AsSlice(),
+	}))
+}
+
+// SwitchNetwork simulates a move to another network by taking the underlay
+// down and bringing it up with a different address. VPN implementation is
+// expected to be notified about it by the caller.
+func (e *Env) SwitchNetwork(t *testing.T) {
+	t.Helper()
+	link, err := e.handle.LinkByName(Underlay)
+	require.NoError(t, err)
+	require.NoError(t, e.handle.LinkSetDown(link))
+	addr := &netlink.Addr{IPNet: toIPNet(netip.PrefixFrom(clientIPs[e.clientIP], 24))}
+	require.NoError(t, e.handle.AddrDel(link, addr))
+	e.clientIP = (e.clientIP + 1) % len(clientIPs)
+	e.setUpUnderlay(t)
+}
+
+// Devices lists the underlay as the only physical interface
+func (e *Env) Devices() ([]net.Interface, error) {
+	iface, err := net.InterfaceByName(Underlay)
+	if err != nil {
+		return nil, err
+	}
+	return []net.Interface{*iface}, nil
+}
+
+// startServer runs the userspace WireGuard implementation. Has to be called
+// in the server namespace.
+func (e *Env) startServer(t *testing.T, private []byte, clientPublic []byte) {
+	t.Helper()
+	tunDevice, err := tun.CreateTUN(serverTunnel, device.DefaultMTU)
+	require.NoError(t, err)
+	// device looks up MTU of the interface outside of the server namespace,
+	// so logs would only contain errors about it
+	wg := device.NewDevice(tunDevice, conn.NewStdNetBind(), device.NewLogger(device.LogLevelSilent, ""))
+	t.Cleanup(wg.Close)
+
+	config := fmt.Sprintf("private_key=%s\nlisten_port=%d\npublic_key=%s\nallowed_ip=%s\n",
+		hex.EncodeToString(private),
+		serverPort,
+		hex.EncodeToString(clientPublic),
+		prefix(TunnelIP),
+	)
+	require.NoError(t, wg.IpcSetOperation(bufio.NewReader(strings.NewReader(config))))
+	// sockets are opened when the device goes up, so it must happen here
+	// instead of the goroutine watching the interface state
+	require.NoError(t, wg.Up())
+}
+
+// startTarget counts datagrams received through the tunnel. Has to be called
+// in the server namespace.
+func (e *Env) startTarget(t *testing.T) {
+	t.Helper()
+	target, err := net.ListenUDP("udp4", net.UDPAddrFromAddrPort(netip.AddrPortFrom(TargetIP, targetPort)))
+	require.NoError(t, err)
+	t.Cleanup(func() { target.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			_, addr, err := target.ReadFromUDPAddrPort(buf)
+			if err != nil {
+				return
+			}
+			if addr.Addr() == TunnelIP {
+				e.tunneled.Add(1)
+			}
+		}
+	}()
+}
+
+// StartTraffic keeps sending datagrams from the client to the target until
+// the test ends. Errors are ignored, because blocked traffic is expected.
+func (e *Env) StartTraffic(t *testing.T) {
+	t.Helper()
+	sender, err := net.ListenUDP("udp4", nil)
+	require.NoError(t, err)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+		sender.Close()
+	})
+
+	target := net.UDPAddrFromAddrPort(netip.AddrPortFrom(TargetIP, targetPort))
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(2 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_, _ = sender.WriteToUDP([]byte("nordvpn leak test"), target)
+			}
+		}
+	}()
+}
+
+// WaitForTunnel waits until traffic generated after the call reaches the
+// target through the tunnel
+func (e *Env) WaitForTunnel(t *testing.T) {
+	t.Helper()
+	count := e.tunneled.Load()
+	require.Eventually(t, func() bool { return e.tunneled.Load() > count+10 },
+		5*time.Second, 10*time.Millisecond, "traffic does not go through the tunnel")
+}
+
+// ResetCapture forgets the packets captured so far
+func (e *Env) ResetCapture() {
+	e.capture.reset()
+}
+
+// Leaks returns packets sent by the client outside of the tunnel since the
+// last ResetCapture
+func (e *Env) Leaks() []Packet {
+	var leaks []Packet
+	for _, packet := range e.capture.packets() {
+		if !packet.isTunnel() && !packet.isNeighborDiscovery() {
+			leaks = append(leaks, packet)
+		}
+	}
+	return leaks
+}
+
+// Packet is an IP packet captured on the underlay
+type Packet struct {
+	Source          netip.Addr
+	Destination     netip.Addr
+	Protocol        uint8
+	DestinationPort uint16
+}
+
+func (p Packet) String() string {
+	return fmt.Sprintf("%s -> %s protocol %d port %d", p.Source, p.Destination, p.Protocol, p.DestinationPort)
+}
+
+// isTunnel reports whether the packet is encrypted WireGuard traffic
+func (p Packet) isTunnel() bool {
+	return p.Protocol == unix.IPPROTO_UDP && p.Destination == ServerIP && p.DestinationPort == serverPort
+}
+
+// isNeighborDiscovery reports whether the packet is link-local ICMPv6 which
+// the kill switch allows for IPv6 to keep working
+func (p Packet) isNeighborDiscovery() bool {
+	return p.Protocol == unix.IPPROTO_ICMPV6 &&
+		(p.Source.IsLinkLocalUnicast() || p.Source.IsUnspecified())
+}
+
+// parsePacket from an ethernet frame. Returns false for non-IP frames.
+func parsePacket(frame []byte) (Packet, bool) {
+	const ethernetHeader = 14
+	if len(frame) < ethernetHeader {
+		return Packet{}, false
+	}
+	payload := frame[ethernetHeader:]
+	var packet Packet
+	var transport []byte
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case unix.ETH_P_IP:
+		if len(payload) < 20 {
+			return Packet{}, false
+		}
+		headerLen := int(payload[0]&0x0f) * 4
+		packet.Protocol = payload[9]
+		packet.Source, _ = netip.AddrFromSlice(payload[12:16])
+		packet.Destination, _ = netip.AddrFromSlice(payload[16:20])
+		if len(payload) > headerLen {
+			transport = payload[headerLen:]
+		}
+	case unix.ETH_P_IPV6:
+		if len(payload) < 40 {
+			return Packet{}, false
+		}
+		// extension headers are not parsed, so only the first header is used
+		packet.Protocol = payload[6]
+		packet.Source, _ = netip.AddrFromSlice(payload[8:24])
+		packet.Destination, _ = netip.AddrFromSlice(payload[24:40])
+		transport = payload[40:]
+	default:
+		return Packet{}, false
+	}
+	if (packet.Protocol == unix.IPPROTO_UDP || packet.Protocol == unix.IPPROTO_TCP) && len(transport) >= 4 {
+		packet.DestinationPort = binary.BigEndian.Uint16(transport[2:4])
+	}
+	return packet, true
+}
+
+// capture records packets received on the interface
+type capture struct {
+	fd       int
+	mu       sync.Mutex
+	captured []Packet
+}
+
+// newCapture opens a packet socket. Has to be called in the namespace of the
+// interface.
+func newCapture(t *testing.T, iface string) *capture {
+	t.Helper()
+	link, err := netlink.LinkByName(iface)
+	require.NoError(t, err)
+
+	protocol := int(htons(unix.ETH_P_ALL))
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, protocol)
+	require.NoError(t, err)
+	require.NoError(t, unix.Bind(fd, &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ALL),
+		Ifindex:  link.Attrs().Index,
+	}))
+	// reading is interrupted periodically, so that the socket could be closed
+	timeout := unix.NsecToTimeval((100 * time.Millisecond).Nanoseconds())
+	require.NoError(t, unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout))
+
+	c := &capture{fd: fd}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+		unix.Close(fd)
+	})
+	go func() {
+		defer close(stopped)
+		buf := make([]byte, 65536)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			n, from, err := unix.Recvfrom(fd, buf, 0)
+			if err != nil {
+				continue
+			}
+			// only the packets sent by the client are of interest
+			if ll, ok := from.(*unix.SockaddrLinklayer); ok && ll.Pkttype == unix.PACKET_OUTGOING {
+				continue
+			}
+			if packet, ok := parsePacket(buf[:n]); ok {
+				c.mu.Lock()
+				c.captured = append(c.captured, packet)
+				c.mu.Unlock()
+			}
+		}
+	}()
+	return c
+}
+
+func (c *capture) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.captured = nil
+}
+
+func (c *capture) packets() []Packet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Packet{}, c.captured...)
+}
+
+// newNamespace creates a network namespace without entering it
+func newNamespace(t *testing.T) netns.NsHandle {
+	t.Helper()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	require.NoError(t, err)
+	defer origin.Close()
+	ns, err := netns.New()
+	require.NoError(t, err)
+	require.NoError(t, netns.Set(origin))
+	t.Cleanup(func() { ns.Close() })
+	return ns
+}
+
+// inNamespace runs f with the current thread in the namespace. Sockets and
+// interfaces created by f stay in the namespace.
+func inNamespace(t *testing.T, ns netns.NsHandle, f func()) {
+	t.Helper()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	require.NoError(t, err)
+	defer origin.Close()
+	require.NoError(t, netns.Set(ns))
+	defer func() {
+		require.NoError(t, netns.Set(origin))
+	}()
+	f()
+}
+
+// setUp brings the link up and assigns the addresses
+func setUp(t *testing.T, handle *netlink.Handle, name string, addrs ...netip.Prefix) {
+	t.Helper()
+	link, err := handle.LinkByName(name)
+	require.NoError(t, err)
+	for _, addr := range addrs {
+		require.NoError(t, handle.AddrAdd(link, &netlink.Addr{IPNet: toIPNet(addr)}))
+	}
+	require.NoError(t, handle.LinkSetUp(link))
+}
+
+func newKeys(t *testing.T) (private []byte, public []byte) {
+	t.Helper()
+	private = make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(private)
+	require.NoError(t, err)
+	// clamping as described in RFC 7748
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+	public, err = curve25519.X25519(private, curve25519.Basepoint)
+	require.NoError(t, err)
+	return private, public
+}
+
+func prefix(addr netip.Addr) netip.Prefix {
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
+
+func toIPNet(prefix netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   prefix.Addr().AsSlice(),
+		Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+	}
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
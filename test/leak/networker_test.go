@@ -0,0 +1,157 @@
+package leak_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NordSecurity/nordvpn-linux/config"
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall"
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/iptables"
+	"github.com/NordSecurity/nordvpn-linux/daemon/firewall/notables"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/netlinkrouter"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/netlinkrule"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/norouter"
+	"github.com/NordSecurity/nordvpn-linux/daemon/routes/norule"
+	"github.com/NordSecurity/nordvpn-linux/daemon/vpn"
+	"github.com/NordSecurity/nordvpn-linux/daemon/vpn/nordlynx"
+	"github.com/NordSecurity/nordvpn-linux/events/subs"
+	"github.com/NordSecurity/nordvpn-linux/internal"
+	"github.com/NordSecurity/nordvpn-linux/networker"
+	"github.com/NordSecurity/nordvpn-linux/test/category"
+	testjournal "github.com/NordSecurity/nordvpn-linux/test/journal"
+	"github.com/NordSecurity/nordvpn-linux/test/leak"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+const fwmark = 0xe1f1
+
+func TestMain(m *testing.M) {
+	os.Exit(leak.Main(m))
+}
+
+type noopDNS struct{}
+
+func (noopDNS) Set(string, []string) error { return nil }
+func (noopDNS) Unset(string) error         { return nil }
+
+type noopIpv6 struct{}
+
+func (noopIpv6) Block() error   { return nil }
+func (noopIpv6) Unblock() error { return nil }
+
+// newNetworker with the real userspace NordLynx and system routing. Firewall
+// is enabled only if iptables are available.
+func newNetworker(t *testing.T, env *leak.Env, withFirewall bool) *networker.Combined {
+	t.Helper()
+	handle, err := netlink.NewHandle()
+	require.NoError(t, err)
+	t.Cleanup(handle.Delete)
+
+	journal := &testjournal.Memory{}
+	fw := firewall.NewFirewall(&notables.Facade{}, &notables.Facade{}, &subs.Subject[string]{}, false)
+	if withFirewall {
+		supported := iptables.FilterSupportedIPTables(internal.GetSupportedIPTables())
+		if len(supported) == 0 {
+			t.Skip("iptables are not available")
+		}
+		fw = firewall.NewFirewall(
+			&notables.Facade{},
+			iptables.New("conntrack", "--ctstate", "", supported),
+			&subs.Subject[string]{},
+			true,
+		)
+	}
+
+	router := routes.NewRouter(&norouter.Facade{}, netlinkrouter.NewRouter(handle, journal), true)
+	netw := networker.NewCombined(
+		nordlynx.NewUserSpace(fwmark),
+		nil,
+		routes.IPGatewayRetriever{},
+		&subs.Subject[string]{},
+		nil,
+		router,
+		noopDNS{},
+		noopIpv6{},
+		fw,
+		env.Devices,
+		routes.NewPolicyRouter(
+			&norule.Facade{},
+			netlinkrule.NewRouter(handle, routes.NewSysctlRPFilterManager(journal), fwmark, journal),
+			true,
+		),
+		nil,
+		router,
+		routes.NewRouter(&norouter.Facade{}, &norouter.Facade{}, false),
+		nil,
+		nil,
+		fwmark,
+	)
+	t.Cleanup(func() {
+		// networker is left stopped even if the test fails midway
+		_ = netw.Stop()
+		_ = netw.UnsetKillSwitch()
+	})
+	return netw
+}
+
+func start(t *testing.T, netw *networker.Combined, env *leak.Env) {
+	t.Helper()
+	require.NoError(t, netw.Start(
+		vpn.Credentials{NordLynxPrivateKey: env.PrivateKey},
+		env.ServerData,
+		config.NewWhitelist(nil, nil, nil),
+		config.DNS{"1.1.1.1"},
+	))
+}
+
+func TestLeak_Connected(t *testing.T) {
+	category.Set(t, category.Root, category.Link, category.Route)
+
+	env := leak.Setup(t)
+	netw := newNetworker(t, env, false)
+	env.StartTraffic(t)
+
+	start(t, netw, env)
+	env.WaitForTunnel(t)
+	env.ResetCapture()
+	env.WaitForTunnel(t)
+	assert.Empty(t, env.Leaks())
+
+	// makes sure that the harness would notice traffic outside of the tunnel
+	require.NoError(t, netw.Stop())
+	assert.Eventually(t, func() bool { return len(env.Leaks()) > 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestLeak_KillSwitch(t *testing.T) {
+	category.Set(t, category.Root, category.Link, category.Route, category.Firewall)
+
+	env := leak.Setup(t)
+	netw := newNetworker(t, env, true)
+	require.NoError(t, netw.SetKillSwitch(config.NewWhitelist(nil, nil, nil)))
+	env.StartTraffic(t)
+
+	start(t, netw, env)
+	env.WaitForTunnel(t)
+
+	// restart
+	start(t, netw, env)
+	env.WaitForTunnel(t)
+
+	env.SwitchNetwork(t)
+	netw.Reconnect(false)
+	netw.Reconnect(true)
+	env.WaitForTunnel(t)
+
+	require.NoError(t, netw.Stop())
+	// traffic keeps being blocked for a while after disconnecting
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, env.Leaks())
+
+	require.NoError(t, netw.UnsetKillSwitch())
+	assert.Eventually(t, func() bool { return len(env.Leaks()) > 0 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,69 @@
+// Package netns provides isolated network namespaces for integration tests.
+package netns
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// Device is a name of the link created by NewHandle
+	Device = "nordtest0"
+	// Peer is a name of the other end of Device
+	Peer = "nordtest1"
+)
+
+// NewHandle creates a new network namespace with a veth pair of Device and
+// Peer, which are up and Device has the given addresses, and returns a netlink handle
+// operating in it. Namespace is removed after the test.
+func NewHandle(t *testing.T, addrs ...string) *netlink.Handle {
+	t.Helper()
+	_, handle := New(t, addrs...)
+	return handle
+}
+
+// New is the same as NewHandle, but also returns the namespace itself
+func New(t *testing.T, addrs ...string) (netns.NsHandle, *netlink.Handle) {
+	t.Helper()
+
+	// namespace is switched for the current thread only
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	require.NoError(t, err)
+	defer origin.Close()
+
+	ns, err := netns.New()
+	require.NoError(t, err)
+	require.NoError(t, netns.Set(origin))
+	t.Cleanup(func() { ns.Close() })
+
+	handle, err := netlink.NewHandleAt(ns)
+	require.NoError(t, err)
+	t.Cleanup(handle.Delete)
+
+	// veth is used instead of dummy as it is available in more kernels
+	require.NoError(t, handle.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: Device},
+		PeerName:  Peer,
+	}))
+	for _, name := range []string{"lo", Device, Peer} {
+		link, err := handle.LinkByName(name)
+		require.NoError(t, err)
+		require.NoError(t, handle.LinkSetUp(link))
+	}
+
+	link, err := handle.LinkByName(Device)
+	require.NoError(t, err)
+	for _, addr := range addrs {
+		nlAddr, err := netlink.ParseAddr(addr)
+		require.NoError(t, err)
+		require.NoError(t, handle.AddrAdd(link, nlAddr))
+	}
+	return ns, handle
+}